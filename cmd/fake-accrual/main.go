@@ -0,0 +1,60 @@
+// Команда fake-accrual — фиктивная система accrual для демо и end-to-end тестов: реализует
+// GET /api/orders/{number} по сценарию (см. mock.Scenario) или, без сценария, отвечает
+// воспроизводимыми случайными статусами.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"loyalty/internal/adapter/accrual/mock"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// shutdownTimeout — сколько ждать завершения запросов при остановке.
+const shutdownTimeout = 5 * time.Second
+
+func main() {
+	address := flag.String("a", ":8081", "listen address")
+	scenarioPath := flag.String("scenario", os.Getenv("ACCRUAL_SCENARIO"), "scenario file (YAML or JSON); empty answers randomly")
+	seed := flag.Int64("seed", 0, "seed of random answers (overrides the scenario seed)")
+	flag.Parse()
+
+	scenario := mock.Scenario{Seed: mock.DefaultSeed}
+	if *scenarioPath != "" {
+		loaded, err := mock.LoadScenario(*scenarioPath)
+		if err != nil {
+			log.Fatal().Err(err).Str("scenario", *scenarioPath).Msg("failed to load scenario")
+		}
+		scenario = loaded
+	}
+	if *seed != 0 {
+		scenario.Seed = *seed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr:              *address,
+		Handler:           mock.NewHandler(mock.NewScript(scenario)),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Info().Str("address", *address).Int("rules", len(scenario.Rules)).Int64("seed", scenario.Seed).Msg("fake accrual started")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal().Err(err).Msg("fake accrual failed")
+	}
+}
@@ -12,7 +12,7 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	if err := app.Run(ctx); err != nil {
+	if err := app.Run(ctx, os.Args[1:]); err != nil {
 		os.Exit(1)
 	}
 }
@@ -7,33 +7,95 @@ import (
 	"fmt"
 	"io"
 	"loyalty/internal/domain/accrual/model"
+	"loyalty/internal/metrics"
+	"loyalty/internal/tracing"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+// maxStatusBodySize — сколько байт тела ответа 429 читается для разбора заявленного лимита.
+const maxStatusBodySize = 1024
+
 // Client реализует accrual.AccrualClient через HTTP.
 type Client struct {
+	provider   string
 	baseURL    string
 	httpClient *http.Client
 	breaker    *gobreaker.CircuitBreaker
+	limiter    *adaptiveLimiter
+	retry      RetryConfig
 }
 
-// NewClient создаёт HTTP-клиент для системы accrual.
-func NewClient(baseURL string, timeout time.Duration) *Client {
-	cb := initBreaker()
+// NewClient создаёт HTTP-клиент для системы accrual; provider — имя системы в метриках и логах
+// (accrualclient.DefaultProvider для основной).
+// Исходящие запросы несут заголовок traceparent (W3C Trace Context) текущего спана.
+// Все запросы клиента проходят через общий адаптивный token bucket (см. RateLimitConfig),
+// у каждого клиента — свои лимитер, circuit breaker и политика повторов.
+func NewClient(provider, baseURL string, cfg Config) *Client {
+	cb := initBreaker(BreakerName(provider), cfg.Breaker)
+	limiter := newAdaptiveLimiter(cfg.RateLimit)
+	metrics.AccrualRateLimit.WithLabelValues(provider).Set(limiter.Limit())
 
 	return &Client{
-		baseURL:    strings.TrimSuffix(baseURL, "/"),
-		httpClient: &http.Client{Timeout: timeout},
-		breaker:    cb,
+		provider: provider,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		breaker: cb,
+		limiter: limiter,
+		retry:   cfg.Retry,
+	}
+}
+
+// RateLimit возвращает текущий лимит запросов к accrual в минуту.
+func (client *Client) RateLimit() float64 {
+	return client.limiter.Limit()
+}
+
+// GetOrderAccrual получает информацию о начислении для заказа. Попытки, завершившиеся временной
+// ошибкой (см. RetryConfig), повторяются с экспоненциальной паузой, пока пауза укладывается
+// в дедлайн ctx.
+func (client *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (_ *model.Accrual, err error) {
+	ctx, span := tracing.Start(ctx, "AccrualClient.GetOrderAccrual", tracing.OrderNumber(orderNumber))
+	defer func() { tracing.End(span, err) }()
+
+	for retry := 0; ; retry++ {
+		accrualResp, err := client.attempt(ctx, orderNumber)
+		if err == nil || retry >= client.retry.Retries || !client.retry.retryable(err) {
+			return accrualResp, err
+		}
+
+		delay := client.retry.backoff(retry)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return nil, err
+		}
+		metrics.AccrualRetries.WithLabelValues(client.provider).Inc()
+		zerolog.Ctx(ctx).Debug().Err(err).Int("retry", retry+1).Dur("backoff", delay).Msg("retrying accrual request")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
 	}
 }
 
-// GetOrderAccrual получает информацию о начислении для заказа.
-func (client *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (*model.Accrual, error) {
+// attempt выполняет одну попытку запроса через лимитер и circuit breaker.
+func (client *Client) attempt(ctx context.Context, orderNumber string) (*model.Accrual, error) {
+	// Ожидание токена не дольше таймаута запроса: иначе заказ лучше отложить, чем держать воркер.
+	if err := client.limiter.Wait(ctx, client.httpClient.Timeout); err != nil {
+		return nil, err
+	}
+
 	res, err := client.breaker.Execute(func() (any, error) {
 		url := fmt.Sprintf("%s/api/orders/%s", client.baseURL, orderNumber)
 
@@ -44,27 +106,36 @@ func (client *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (
 
 		response, err := client.httpClient.Do(request)
 		if err != nil {
-			return nil, fmt.Errorf("http request: %w", err)
+			return nil, &transportError{err: err}
 		}
 		defer func() { _ = response.Body.Close() }()
 
 		switch response.StatusCode {
 		case http.StatusOK:
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				return nil, fmt.Errorf("read response: %w", err)
+			}
 			var accrualResp model.Accrual
-			if err := json.NewDecoder(response.Body).Decode(&accrualResp); err != nil {
+			if err := json.Unmarshal(body, &accrualResp); err != nil {
 				return nil, fmt.Errorf("decode response: %w", err)
 			}
+			accrualResp.Raw = body
+			client.onSuccess(ctx)
 			return &accrualResp, nil
 
 		case http.StatusNoContent:
+			client.onSuccess(ctx)
 			return (*model.Accrual)(nil), nil
 
 		case http.StatusTooManyRequests:
+			body, _ := io.ReadAll(io.LimitReader(response.Body, maxStatusBodySize))
+			client.onThrottle(ctx, parseStatedLimit(body), getRetryAfter(response))
 			return (*model.Accrual)(nil), model.ErrTooManyRequests
 
 		default:
-			body, _ := io.ReadAll(response.Body)
-			return nil, fmt.Errorf("unexpected status %d: %s", response.StatusCode, string(body))
+			body, _ := io.ReadAll(io.LimitReader(response.Body, maxStatusBodySize))
+			return nil, &statusError{code: response.StatusCode, body: string(body)}
 		}
 	})
 	if err != nil {
@@ -84,18 +155,26 @@ func (client *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (
 	return accrualResp, nil
 }
 
-func initBreaker() *gobreaker.CircuitBreaker {
-	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
-		Name:        "accrual",
-		MaxRequests: 3,
-		Interval:    30 * time.Second,
-		Timeout:     30 * time.Second,
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			// Открываем breaker при серии подряд ошибок (типичный симптом деградации/падения сервиса).
-			return counts.ConsecutiveFailures >= 5
-		},
-		IsSuccessful: func(err error) bool {
-			return err == nil || errors.Is(err, model.ErrTooManyRequests)
-		},
-	})
+// onSuccess даёт лимитеру пробно увеличить лимит после периода без 429.
+func (client *Client) onSuccess(ctx context.Context) {
+	if limit, changed := client.limiter.OnSuccess(); changed {
+		metrics.AccrualRateLimit.WithLabelValues(client.provider).Set(limit)
+		zerolog.Ctx(ctx).Debug().Float64("limit_rpm", limit).Msg("accrual rate limit increased")
+	}
+}
+
+// onThrottle снижает лимит после 429 и приостанавливает запросы на Retry-After.
+func (client *Client) onThrottle(ctx context.Context, stated float64, retryAfter time.Duration) {
+	limit := client.limiter.OnThrottle(stated, retryAfter)
+	metrics.AccrualRateLimit.WithLabelValues(client.provider).Set(limit)
+	zerolog.Ctx(ctx).Info().
+		Float64("limit_rpm", limit).
+		Float64("stated_limit_rpm", stated).
+		Dur("retry_after", retryAfter).
+		Msg("accrual rate limit decreased")
+}
+
+// BreakerState возвращает текущее состояние circuit breaker ("closed", "half-open" или "open").
+func (client *Client) BreakerState() string {
+	return client.breaker.State().String()
 }
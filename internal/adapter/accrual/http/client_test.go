@@ -3,13 +3,22 @@ package http
 import (
 	"context"
 	"errors"
+	"loyalty/internal/adapter/accrual/mock"
+	accrualclient "loyalty/internal/domain/accrual/client"
 	"loyalty/internal/domain/accrual/model"
+	"loyalty/internal/metrics"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestClient_GetOrderAccrual(t *testing.T) {
@@ -67,7 +76,7 @@ func TestClient_GetOrderAccrual(t *testing.T) {
 			}))
 			defer server.Close()
 
-			c := NewClient(server.URL, 5*time.Second)
+			c := NewClient(accrualclient.DefaultProvider, server.URL, DefaultConfig())
 			resp, err := c.GetOrderAccrual(context.Background(), "123")
 
 			if (err != nil) != tt.wantErr {
@@ -82,6 +91,9 @@ func TestClient_GetOrderAccrual(t *testing.T) {
 			if (resp == nil) != tt.wantNil {
 				t.Errorf("GetOrderAccrual() nil = %v, want %v", resp == nil, tt.wantNil)
 			}
+			if resp != nil && string(resp.Raw) != tt.serverResponse {
+				t.Errorf("GetOrderAccrual() raw = %s, want %s", resp.Raw, tt.serverResponse)
+			}
 		})
 	}
 }
@@ -93,7 +105,7 @@ func TestClient_CircuitBreakerOpens(t *testing.T) {
 	}))
 	defer server.Close()
 
-	c := NewClient(server.URL, 5*time.Second)
+	c := NewClient(accrualclient.DefaultProvider, server.URL, DefaultConfig())
 	// Делаем тест детерминированным: открываем breaker после 1 ошибки.
 	c.breaker = gobreaker.NewCircuitBreaker(gobreaker.Settings{
 		Name: "accrual-test",
@@ -117,6 +129,56 @@ func TestClient_CircuitBreakerOpens(t *testing.T) {
 	}
 }
 
+func TestClient_BreakerTransitionsExported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	transitions := metrics.BreakerTransitions.WithLabelValues(BreakerName(accrualclient.DefaultProvider), "closed", "open")
+	before := testutil.ToFloat64(transitions)
+
+	c := NewClient(accrualclient.DefaultProvider, server.URL, DefaultConfig())
+	for i := 0; i < 5; i++ {
+		_, _ = c.GetOrderAccrual(context.Background(), "123")
+	}
+
+	if got := testutil.ToFloat64(metrics.BreakerState.WithLabelValues(BreakerName(accrualclient.DefaultProvider))); got != float64(gobreaker.StateOpen) {
+		t.Fatalf("want breaker state %v, got %v", float64(gobreaker.StateOpen), got)
+	}
+	if got := testutil.ToFloat64(transitions); got != before+1 {
+		t.Fatalf("want %v transitions, got %v", before+1, got)
+	}
+}
+
+func TestClient_PropagatesTraceparent(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	c := NewClient(accrualclient.DefaultProvider, server.URL, DefaultConfig())
+	if _, err := c.GetOrderAccrual(ctx, "123"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if !strings.HasPrefix(traceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+		t.Fatalf("expected traceparent with caller trace id, got %q", traceparent)
+	}
+}
+
 func TestGetRetryAfter(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -153,6 +215,264 @@ func TestGetRetryAfter(t *testing.T) {
 			if tt.header != "" {
 				resp.Header.Set("Retry-After", tt.header)
 			}
+			if got := getRetryAfter(resp); got != tt.want {
+				t.Fatalf("getRetryAfter() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
+
+func TestClient_LearnsRateLimitFrom429(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("No more than 120 requests per minute allowed"))
+	}))
+	defer server.Close()
+
+	c := NewClient(accrualclient.DefaultProvider, server.URL, DefaultConfig())
+	if _, err := c.GetOrderAccrual(context.Background(), "123"); !errors.Is(err, model.ErrTooManyRequests) {
+		t.Fatalf("expected ErrTooManyRequests, got %v", err)
+	}
+	if got := c.RateLimit(); got != 120 {
+		t.Fatalf("want learned limit 120, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.AccrualRateLimit.WithLabelValues(accrualclient.DefaultProvider)); got != 120 {
+		t.Fatalf("want exported limit 120, got %v", got)
+	}
+
+	// Пока действует Retry-After, запрос не отправляется.
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent during Retry-After pause")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if _, err := c.GetOrderAccrual(context.Background(), "123"); !errors.Is(err, model.ErrTooManyRequests) {
+		t.Fatalf("expected ErrTooManyRequests during pause, got %v", err)
+	}
+}
+
+func TestClient_ProviderHasOwnBreakerAndLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	main := NewClient(accrualclient.DefaultProvider, server.URL, DefaultConfig())
+	cfg := DefaultConfig()
+	cfg.RateLimit.Initial = 600
+	merchant := NewClient("merchant2", server.URL, cfg)
+	for i := 0; i < 5; i++ {
+		_, _ = merchant.GetOrderAccrual(context.Background(), "123")
+	}
+
+	if got := merchant.BreakerState(); got != gobreaker.StateOpen.String() {
+		t.Fatalf("want merchant2 breaker open, got %s", got)
+	}
+	if got := main.BreakerState(); got != gobreaker.StateClosed.String() {
+		t.Fatalf("failures of another provider must not open the default breaker, got %s", got)
+	}
+	if got := testutil.ToFloat64(metrics.BreakerState.WithLabelValues("accrual_merchant2")); got != float64(gobreaker.StateOpen) {
+		t.Fatalf("want exported merchant2 breaker state open, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.AccrualRateLimit.WithLabelValues("merchant2")); got != 600 {
+		t.Fatalf("want exported merchant2 limit 600, got %v", got)
+	}
+}
+
+func TestClient_AgainstFakeAccrual(t *testing.T) {
+	scenario, err := mock.ParseScenario([]byte(`
+orders:
+  - order: "12345678903"
+    steps:
+      - status: PROCESSING
+      - status: PROCESSED
+        accrual: 729.98
+  - order: "2377225624"
+    steps:
+      - code: 204
+  - order: "79927398713"
+    steps:
+      - code: 500
+      - code: 429
+        retry_after: 1m
+        rate_limit: 120
+`))
+	if err != nil {
+		t.Fatalf("parse scenario: %v", err)
+	}
+	server := httptest.NewServer(mock.NewHandler(mock.NewScript(scenario)))
+	defer server.Close()
+
+	c := NewClient("fake", server.URL, DefaultConfig())
+	ctx := context.Background()
+
+	resp, err := c.GetOrderAccrual(ctx, "12345678903")
+	if err != nil || resp.Status != model.StatusProcessing || resp.Accrual != nil {
+		t.Fatalf("want PROCESSING, got %+v, %v", resp, err)
+	}
+	resp, err = c.GetOrderAccrual(ctx, "12345678903")
+	if err != nil || resp.Status != model.StatusProcessed || resp.Accrual == nil || resp.Accrual.String() != "729.98" {
+		t.Fatalf("want PROCESSED 729.98, got %+v, %v", resp, err)
+	}
+	if !strings.Contains(string(resp.Raw), `"accrual":729.98`) {
+		t.Fatalf("fake accrual must send the amount as a JSON number, got %s", resp.Raw)
+	}
+
+	if resp, err := c.GetOrderAccrual(ctx, "2377225624"); err != nil || resp != nil {
+		t.Fatalf("want not registered, got %+v, %v", resp, err)
+	}
+	if _, err := c.GetOrderAccrual(ctx, "79927398713"); err == nil {
+		t.Fatalf("want error on injected 500")
+	}
+	if _, err := c.GetOrderAccrual(ctx, "79927398713"); !errors.Is(err, model.ErrTooManyRequests) {
+		t.Fatalf("want ErrTooManyRequests on injected 429, got %v", err)
+	}
+	if got := c.RateLimit(); got != 120 {
+		t.Fatalf("want limit 120 learned from fake accrual, got %v", got)
+	}
+}
+
+func TestClient_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   func(attempt int32, w http.ResponseWriter)
+		timeout   time.Duration
+		deadline  time.Duration
+		wantCalls int32
+		wantErr   bool
+	}{
+		{
+			name: "retries configured status and succeeds",
+			handler: func(attempt int32, w http.ResponseWriter) {
+				if attempt < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write([]byte(`{"order":"123","status":"PROCESSED","accrual":5}`))
+			},
+			wantCalls: 3,
+		},
+		{
+			name: "gives up after retry count",
+			handler: func(_ int32, w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name: "does not retry other statuses",
+			handler: func(_ int32, w http.ResponseWriter) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "does not retry 429",
+			handler: func(_ int32, w http.ResponseWriter) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "does not retry a timed out request",
+			handler: func(_ int32, w http.ResponseWriter) {
+				time.Sleep(100 * time.Millisecond)
+				w.WriteHeader(http.StatusNoContent)
+			},
+			timeout:   20 * time.Millisecond,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "does not retry past the context deadline",
+			handler: func(_ int32, w http.ResponseWriter) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			deadline:  5 * time.Millisecond,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.handler(calls.Add(1), w)
+			}))
+			defer server.Close()
+
+			cfg := DefaultConfig()
+			cfg.Retry.Backoff = 10 * time.Millisecond
+			if tt.timeout > 0 {
+				cfg.Timeout = tt.timeout
+			}
+			c := NewClient("retry_test", server.URL, cfg)
+
+			ctx := context.Background()
+			if tt.deadline > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.deadline)
+				defer cancel()
+			}
+			_, err := c.GetOrderAccrual(ctx, "123")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetOrderAccrual() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Fatalf("want %d calls, got %d", tt.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestClient_BreakerConfig(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Breaker.Failures = 2
+	c := NewClient("breaker_test", server.URL, cfg)
+	for i := 0; i < 3; i++ {
+		_, _ = c.GetOrderAccrual(context.Background(), "123")
+	}
+
+	if got := c.BreakerState(); got != gobreaker.StateOpen.String() {
+		t.Fatalf("want breaker open after 2 failures, got %s", got)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("open breaker must not send requests, got %d calls", got)
+	}
+}
+
+func TestRetryConfig_Backoff(t *testing.T) {
+	cfg := RetryConfig{Backoff: 100 * time.Millisecond, MaxBackoff: 350 * time.Millisecond}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 350 * time.Millisecond, 350 * time.Millisecond}
+	for retry, delay := range want {
+		if got := cfg.backoff(retry); got != delay {
+			t.Fatalf("backoff(%d) = %v, want %v", retry, got, delay)
+		}
+	}
+}
+
+func TestConfig_Budget(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Timeout = time.Second
+	cfg.Retry.Retries = 2
+	cfg.Retry.Backoff = 100 * time.Millisecond
+	if got, want := cfg.Budget(), 3*time.Second+300*time.Millisecond; got != want {
+		t.Fatalf("Budget() = %v, want %v", got, want)
+	}
+
+	cfg.Timeout = 0
+	if got := cfg.Budget(); got != 0 {
+		t.Fatalf("Budget() without timeout = %v, want 0", got)
+	}
+}
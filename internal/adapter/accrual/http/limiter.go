@@ -0,0 +1,192 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"loyalty/internal/domain/accrual/model"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig содержит параметры адаптивного ограничения запросов к accrual (в запросах в минуту).
+type RateLimitConfig struct {
+	Initial     float64       // Стартовый лимит (по умолчанию 3000 rpm)
+	Min         float64       // Нижняя граница лимита (по умолчанию 6 rpm)
+	Max         float64       // Верхняя граница лимита при пробах (по умолчанию 6000 rpm)
+	Step        float64       // Аддитивный шаг увеличения (по умолчанию 60 rpm)
+	Decrease    float64       // Множитель уменьшения при 429 без явного лимита (по умолчанию 0.5)
+	QuietPeriod time.Duration // Период без 429, после которого лимит увеличивается на Step (по умолчанию 30s)
+}
+
+// DefaultRateLimitConfig возвращает дефолтные параметры ограничения запросов.
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Initial:     3000,
+		Min:         6,
+		Max:         6000,
+		Step:        60,
+		Decrease:    0.5,
+		QuietPeriod: 30 * time.Second,
+	}
+}
+
+// statedLimitPattern — формат тела ответа 429 системы accrual.
+var statedLimitPattern = regexp.MustCompile(`No more than (\d+) requests per minute allowed`)
+
+// adaptiveLimiter — общий для всех запросов клиента token bucket, лимит которого подстраивается
+// по схеме AIMD: при 429 уменьшается до заявленного в ответе лимита (или в Decrease раз),
+// после QuietPeriod без 429 — увеличивается на Step. Retry-After приостанавливает запросы целиком.
+type adaptiveLimiter struct {
+	cfg RateLimitConfig
+	now func() time.Time
+
+	mu          sync.Mutex
+	limiter     *rate.Limiter
+	limit       float64
+	lastChange  time.Time
+	pausedUntil time.Time
+}
+
+func newAdaptiveLimiter(cfg RateLimitConfig) *adaptiveLimiter {
+	defaults := DefaultRateLimitConfig()
+	if cfg.Min <= 0 {
+		cfg.Min = defaults.Min
+	}
+	if cfg.Max < cfg.Min {
+		cfg.Max = max(defaults.Max, cfg.Min)
+	}
+	if cfg.Initial <= 0 {
+		cfg.Initial = defaults.Initial
+	}
+	cfg.Initial = min(max(cfg.Initial, cfg.Min), cfg.Max)
+	if cfg.Step <= 0 {
+		cfg.Step = defaults.Step
+	}
+	if cfg.Decrease <= 0 || cfg.Decrease >= 1 {
+		cfg.Decrease = defaults.Decrease
+	}
+	if cfg.QuietPeriod <= 0 {
+		cfg.QuietPeriod = defaults.QuietPeriod
+	}
+
+	limiter := &adaptiveLimiter{
+		cfg:     cfg,
+		now:     time.Now,
+		limiter: rate.NewLimiter(perSecond(cfg.Initial), 1),
+		limit:   cfg.Initial,
+	}
+	limiter.lastChange = limiter.now()
+	return limiter
+}
+
+// Limit возвращает текущий лимит в запросах в минуту.
+func (limiter *adaptiveLimiter) Limit() float64 {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.limit
+}
+
+// Wait ждёт свободного токена. Если запросы приостановлены по Retry-After или токен освободится
+// позже maxWait (или дедлайна ctx), запрос не отправляется и возвращается model.ErrTooManyRequests.
+func (limiter *adaptiveLimiter) Wait(ctx context.Context, maxWait time.Duration) error {
+	limiter.mu.Lock()
+	now := limiter.now()
+	if now.Before(limiter.pausedUntil) {
+		pause := limiter.pausedUntil.Sub(now)
+		limiter.mu.Unlock()
+		return fmt.Errorf("%w: paused for %s by Retry-After", model.ErrTooManyRequests, pause.Round(time.Second))
+	}
+	reservation := limiter.limiter.ReserveN(now, 1)
+	limiter.mu.Unlock()
+
+	delay := reservation.DelayFrom(now)
+	if deadline, ok := ctx.Deadline(); ok && maxWait > time.Until(deadline) {
+		maxWait = time.Until(deadline)
+	}
+	if delay > maxWait {
+		reservation.CancelAt(now)
+		return fmt.Errorf("%w: client-side limit of %.0f requests per minute", model.ErrTooManyRequests, limiter.Limit())
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// OnSuccess учитывает успешный ответ: после QuietPeriod без 429 лимит растёт на Step.
+// Возвращает новый лимит и признак его изменения.
+func (limiter *adaptiveLimiter) OnSuccess() (float64, bool) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	if limiter.limit >= limiter.cfg.Max || now.Sub(limiter.lastChange) < limiter.cfg.QuietPeriod {
+		return limiter.limit, false
+	}
+	limiter.setLimit(min(limiter.limit+limiter.cfg.Step, limiter.cfg.Max), now)
+	return limiter.limit, true
+}
+
+// OnThrottle учитывает ответ 429: лимит снижается до заявленного stated (если он ниже текущего)
+// или в Decrease раз, а retryAfter > 0 приостанавливает запросы. Возвращает новый лимит.
+func (limiter *adaptiveLimiter) OnThrottle(stated float64, retryAfter time.Duration) float64 {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	next := limiter.limit * limiter.cfg.Decrease
+	if stated > 0 && stated < limiter.limit {
+		next = stated
+	}
+	limiter.setLimit(max(next, limiter.cfg.Min), now)
+	if retryAfter > 0 && now.Add(retryAfter).After(limiter.pausedUntil) {
+		limiter.pausedUntil = now.Add(retryAfter)
+	}
+	return limiter.limit
+}
+
+func (limiter *adaptiveLimiter) setLimit(limit float64, now time.Time) {
+	limiter.limit = limit
+	limiter.lastChange = now
+	limiter.limiter.SetLimitAt(now, perSecond(limit))
+}
+
+func perSecond(perMinute float64) rate.Limit {
+	return rate.Limit(perMinute / 60)
+}
+
+// parseStatedLimit извлекает N из тела 429 «No more than N requests per minute allowed» (0, если формат иной).
+func parseStatedLimit(body []byte) float64 {
+	match := statedLimitPattern.FindSubmatch(body)
+	if match == nil {
+		return 0
+	}
+	limit, err := strconv.ParseFloat(string(match[1]), 64)
+	if err != nil {
+		return 0
+	}
+	return limit
+}
+
+// getRetryAfter возвращает паузу из заголовка Retry-After в секундах (0, если заголовка нет или он некорректен).
+func getRetryAfter(response *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
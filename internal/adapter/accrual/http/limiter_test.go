@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"loyalty/internal/domain/accrual/model"
+	"testing"
+	"time"
+)
+
+// newTestLimiter создаёт лимитер с управляемыми часами.
+func newTestLimiter(cfg RateLimitConfig) (*adaptiveLimiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newAdaptiveLimiter(cfg)
+	limiter.now = func() time.Time { return now }
+	limiter.lastChange = now
+	return limiter, &now
+}
+
+func TestAdaptiveLimiter_AIMD(t *testing.T) {
+	cfg := DefaultRateLimitConfig()
+	cfg.Initial = 600
+	cfg.Max = 700
+	limiter, now := newTestLimiter(cfg)
+
+	if got := limiter.OnThrottle(0, 0); got != 300 {
+		t.Fatalf("429 without stated limit: want 300, got %v", got)
+	}
+	if got := limiter.OnThrottle(200, 0); got != 200 {
+		t.Fatalf("429 with lower stated limit: want 200, got %v", got)
+	}
+	if got := limiter.OnThrottle(500, 0); got != 100 {
+		t.Fatalf("429 with stated limit above current: want 100, got %v", got)
+	}
+
+	if _, changed := limiter.OnSuccess(); changed {
+		t.Fatalf("limit must not grow before the quiet period")
+	}
+	*now = now.Add(cfg.QuietPeriod)
+	if got, changed := limiter.OnSuccess(); !changed || got != 160 {
+		t.Fatalf("after quiet period: want 160, got %v (changed=%v)", got, changed)
+	}
+	if _, changed := limiter.OnSuccess(); changed {
+		t.Fatalf("next increase must wait for another quiet period")
+	}
+
+	for range 20 {
+		*now = now.Add(cfg.QuietPeriod)
+		limiter.OnSuccess()
+	}
+	if got := limiter.Limit(); got != cfg.Max {
+		t.Fatalf("want limit capped at %v, got %v", cfg.Max, got)
+	}
+
+	for range 20 {
+		limiter.OnThrottle(0, 0)
+	}
+	if got := limiter.Limit(); got != cfg.Min {
+		t.Fatalf("want limit floored at %v, got %v", cfg.Min, got)
+	}
+}
+
+func TestAdaptiveLimiter_RetryAfterPausesRequests(t *testing.T) {
+	limiter, now := newTestLimiter(DefaultRateLimitConfig())
+	limiter.OnThrottle(0, 10*time.Second)
+
+	if err := limiter.Wait(context.Background(), time.Second); !errors.Is(err, model.ErrTooManyRequests) {
+		t.Fatalf("expected ErrTooManyRequests while paused, got %v", err)
+	}
+
+	*now = now.Add(10 * time.Second)
+	if err := limiter.Wait(context.Background(), time.Second); err != nil {
+		t.Fatalf("expected request allowed after pause, got %v", err)
+	}
+}
+
+func TestAdaptiveLimiter_WaitBoundedByMaxWait(t *testing.T) {
+	cfg := DefaultRateLimitConfig()
+	cfg.Initial = 6 // один токен в 10 секунд
+	limiter, _ := newTestLimiter(cfg)
+
+	if err := limiter.Wait(context.Background(), time.Second); err != nil {
+		t.Fatalf("first request should use the burst token, got %v", err)
+	}
+	if err := limiter.Wait(context.Background(), time.Second); !errors.Is(err, model.ErrTooManyRequests) {
+		t.Fatalf("expected ErrTooManyRequests when the token is too far away, got %v", err)
+	}
+}
+
+func TestParseStatedLimit(t *testing.T) {
+	tests := []struct {
+		body string
+		want float64
+	}{
+		{body: "No more than 10 requests per minute allowed", want: 10},
+		{body: "No more than 1500 requests per minute allowed\n", want: 1500},
+		{body: "Too Many Requests", want: 0},
+		{body: "", want: 0},
+	}
+	for _, tt := range tests {
+		if got := parseStatedLimit([]byte(tt.body)); got != tt.want {
+			t.Errorf("parseStatedLimit(%q) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	accrualclient "loyalty/internal/domain/accrual/client"
+	"loyalty/internal/domain/accrual/model"
+	"loyalty/internal/metrics"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sony/gobreaker"
+)
+
+// Config содержит параметры HTTP-клиента одной системы accrual.
+type Config struct {
+	Timeout   time.Duration   // Таймаут одной попытки запроса (по умолчанию 5s)
+	RateLimit RateLimitConfig // Адаптивный лимит запросов
+	Breaker   BreakerConfig   // Circuit breaker
+	Retry     RetryConfig     // Повторы при временных ошибках
+}
+
+// DefaultConfig возвращает дефолтные параметры клиента.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:   5 * time.Second,
+		RateLimit: DefaultRateLimitConfig(),
+		Breaker:   DefaultBreakerConfig(),
+		Retry:     DefaultRetryConfig(),
+	}
+}
+
+// Budget возвращает время, за которое запрос успевает пройти все попытки: по таймауту на каждую
+// и паузы между ними (0, если таймаут попытки не задан).
+func (cfg Config) Budget() time.Duration {
+	if cfg.Timeout <= 0 {
+		return 0
+	}
+	budget := time.Duration(cfg.Retry.Retries+1) * cfg.Timeout
+	for retry := range cfg.Retry.Retries {
+		budget += cfg.Retry.backoff(retry)
+	}
+	return budget
+}
+
+// BreakerConfig содержит параметры circuit breaker'а клиента.
+type BreakerConfig struct {
+	Failures       uint32        // Ошибок подряд до открытия (по умолчанию 5)
+	OpenTimeout    time.Duration // Сколько breaker открыт до перехода в half-open (по умолчанию 30s)
+	HalfOpenProbes uint32        // Пробных запросов в half-open; столько успехов подряд закрывают breaker (по умолчанию 3)
+	Interval       time.Duration // Период сброса счётчиков в closed (по умолчанию 30s)
+}
+
+// DefaultBreakerConfig возвращает дефолтные параметры circuit breaker'а.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		Failures:       5,
+		OpenTimeout:    30 * time.Second,
+		HalfOpenProbes: 3,
+		Interval:       30 * time.Second,
+	}
+}
+
+// RetryConfig содержит политику повторов запроса. Повторяются только ответы с кодами из Statuses
+// и сетевые ошибки до получения ответа (например, отказ в соединении); таймаут попытки, 429,
+// открытый breaker и некорректный ответ не повторяются.
+type RetryConfig struct {
+	Retries    int           // Повторов после первой попытки, 0 отключает (по умолчанию 2)
+	Backoff    time.Duration // Пауза перед первым повтором, дальше удваивается (по умолчанию 200ms)
+	MaxBackoff time.Duration // Верхняя граница паузы (по умолчанию 2s)
+	Statuses   []int         // Повторяемые коды ответа (по умолчанию 502, 503, 504)
+}
+
+// DefaultRetryConfig возвращает дефолтную политику повторов.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		Retries:    2,
+		Backoff:    200 * time.Millisecond,
+		MaxBackoff: 2 * time.Second,
+		Statuses:   []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// backoff возвращает паузу перед повтором с номером retry (с нуля).
+func (cfg RetryConfig) backoff(retry int) time.Duration {
+	delay := cfg.Backoff
+	for i := 0; i < retry && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if cfg.MaxBackoff > 0 {
+		delay = min(delay, cfg.MaxBackoff)
+	}
+	return delay
+}
+
+// retryable сообщает, стоит ли повторять запрос после ошибки попытки.
+func (cfg RetryConfig) retryable(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		return slices.Contains(cfg.Statuses, status.code)
+	}
+	var transport *transportError
+	if !errors.As(err, &transport) {
+		return false
+	}
+	// Запрос, не дождавшийся ответа за таймаут, мог быть уже обработан и, скорее всего, упрётся
+	// в таймаут снова: вслепую не повторяем.
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return false
+	}
+	return true
+}
+
+// statusError — ответ accrual с неожиданным кодом.
+type statusError struct {
+	code int
+	body string
+}
+
+func (err *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", err.code, err.body)
+}
+
+// transportError — ошибка отправки запроса или получения ответа.
+type transportError struct {
+	err error
+}
+
+func (err *transportError) Error() string { return "http request: " + err.err.Error() }
+
+func (err *transportError) Unwrap() error { return err.err }
+
+// BreakerName возвращает имя circuit breaker'а провайдера в метриках и логах: "accrual" для основной
+// системы и "accrual_<provider>" для дополнительных.
+func BreakerName(provider string) string {
+	if provider == accrualclient.DefaultProvider {
+		return "accrual"
+	}
+	return "accrual_" + provider
+}
+
+func initBreaker(name string, cfg BreakerConfig) *gobreaker.CircuitBreaker {
+	metrics.BreakerState.WithLabelValues(name).Set(float64(gobreaker.StateClosed))
+
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        name,
+		MaxRequests: cfg.HalfOpenProbes,
+		Interval:    cfg.Interval,
+		Timeout:     cfg.OpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			// Открываем breaker при серии подряд ошибок (типичный симптом деградации/падения сервиса).
+			return counts.ConsecutiveFailures >= cfg.Failures
+		},
+		IsSuccessful: func(err error) bool {
+			return err == nil || errors.Is(err, model.ErrTooManyRequests)
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			metrics.BreakerState.WithLabelValues(name).Set(float64(to))
+			metrics.BreakerTransitions.WithLabelValues(name, from.String(), to.String()).Inc()
+			event := log.Info()
+			if to == gobreaker.StateOpen {
+				event = log.Warn()
+			}
+			event.Str("breaker", name).Str("from", from.String()).Str("to", to.String()).Msg("circuit breaker state changed")
+		},
+	})
+}
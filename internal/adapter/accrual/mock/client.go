@@ -2,52 +2,60 @@ package mock
 
 import (
 	"context"
+	"fmt"
 	"loyalty/internal/domain/accrual/model"
-	"math/rand"
 	"time"
-
-	"github.com/shopspring/decimal"
 )
 
-var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
-
-// Client — простой mock для accrual client (для тестов или когда accrual система недоступна).
+// Client — mock accrual client, который отвечает по сценарию (для тестов или когда accrual система недоступна).
 type Client struct {
+	script *Script
 }
 
-// NewClient создаёт mock клиент с пустыми ответами.
-func NewClient() *Client {
-	return &Client{}
+// NewClient создаёт mock клиент, который отвечает по сценарию.
+func NewClient(scenario Scenario) *Client {
+	return &Client{script: NewScript(scenario)}
 }
 
-// NewClientWithDefaults создаёт mock клиент, который возвращает случайные значения от 0 до 100.
+// NewClientWithDefaults создаёт mock клиент без правил: статусы и начисления от 0 до 100 выбираются
+// случайно, но воспроизводимо (seed DefaultSeed).
 func NewClientWithDefaults() *Client {
-	return NewClient()
+	return NewClient(Scenario{Seed: DefaultSeed})
 }
 
-// GetOrderAccrual возвращает случайный ответ с начислением от 0 до 100 или предопределённый (если задан).
+// GetOrderAccrual возвращает следующий ответ сценария для заказа: 204 — (nil, nil),
+// 429 — model.ErrTooManyRequests, 5xx — ошибка. Задержка шага прерывается отменой ctx.
 func (c *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (*model.Accrual, error) {
-	statuses := []model.AccrualStatus{
-		model.StatusRegistered,
-		model.StatusProcessing,
-		model.StatusProcessed,
-		model.StatusInvalid,
+	step := c.script.Next(orderNumber)
+	if err := sleep(ctx, step.Delay); err != nil {
+		return nil, err
 	}
-	status := statuses[rng.Intn(len(statuses))]
 
-	var accrualPtr *decimal.Decimal
-	if status == model.StatusProcessed {
-		accrualPtr = decimalPtr(rng.Float64() * 100)
+	switch {
+	case step.Code == 204:
+		return nil, nil
+	case step.Code == 429:
+		return nil, model.ErrTooManyRequests
+	case step.Code != 0:
+		return nil, fmt.Errorf("unexpected status %d", step.Code)
 	}
-
 	return &model.Accrual{
 		Order:   orderNumber,
-		Status:  status,
-		Accrual: accrualPtr,
+		Status:  step.Status,
+		Accrual: step.Accrual,
 	}, nil
 }
 
-func decimalPtr(v float64) *decimal.Decimal {
-	value := decimal.NewFromFloat(v)
-	return &value
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
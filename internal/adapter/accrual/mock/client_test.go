@@ -2,11 +2,35 @@ package mock
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"loyalty/internal/domain/accrual/model"
 )
 
+const testScenario = `
+seed: 42
+orders:
+  - order: "12345678903"
+    steps:
+      - status: REGISTERED
+      - code: 429
+        retry_after: 2s
+        rate_limit: 60
+      - status: PROCESSING
+        delay: 10ms
+      - status: PROCESSED
+        accrual: 729.98
+  - prefix: "42"
+    steps:
+      - code: 500
+      - status: INVALID
+  - regex: '^9\d{3}$'
+    steps:
+      - code: 204
+`
+
 func TestClient_GetOrderAccrual(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -16,8 +40,8 @@ func TestClient_GetOrderAccrual(t *testing.T) {
 		checkRandom bool
 	}{
 		{
-			name:        "predefined response",
-			client:      NewClient(),
+			name:        "scenario response",
+			client:      NewClient(mustParse(t, testScenario)),
 			orderNumber: "12345678903",
 			wantNil:     false,
 			checkRandom: false,
@@ -65,3 +89,119 @@ func TestClient_GetOrderAccrual(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_FollowsScenarioSteps(t *testing.T) {
+	client := NewClient(mustParse(t, testScenario))
+	ctx := context.Background()
+
+	want := []struct {
+		status model.AccrualStatus
+		err    error
+	}{
+		{status: model.StatusRegistered},
+		{err: model.ErrTooManyRequests},
+		{status: model.StatusProcessing},
+		{status: model.StatusProcessed},
+		// После последнего шага повторяется последний.
+		{status: model.StatusProcessed},
+	}
+	for i, step := range want {
+		resp, err := client.GetOrderAccrual(ctx, "12345678903")
+		if !errors.Is(err, step.err) {
+			t.Fatalf("step %d: want err %v, got %v", i, step.err, err)
+		}
+		if step.err == nil && (resp == nil || resp.Status != step.status) {
+			t.Fatalf("step %d: want status %s, got %+v", i, step.status, resp)
+		}
+	}
+	resp, _ := client.GetOrderAccrual(ctx, "12345678903")
+	if resp.Accrual == nil || resp.Accrual.String() != "729.98" {
+		t.Fatalf("want accrual 729.98, got %v", resp.Accrual)
+	}
+
+	if _, err := client.GetOrderAccrual(ctx, "4200"); err == nil || errors.Is(err, model.ErrTooManyRequests) {
+		t.Fatalf("want injected server error for prefix rule, got %v", err)
+	}
+	if resp, err := client.GetOrderAccrual(ctx, "4200"); err != nil || resp.Status != model.StatusInvalid {
+		t.Fatalf("want INVALID on second call, got %+v, %v", resp, err)
+	}
+	if resp, err := client.GetOrderAccrual(ctx, "9123"); err != nil || resp != nil {
+		t.Fatalf("want not registered for regex rule, got %+v, %v", resp, err)
+	}
+}
+
+func TestClient_DelayRespectsContext(t *testing.T) {
+	client := NewClient(mustParse(t, `orders: [{order: "1", steps: [{status: PROCESSING, delay: 1m}]}]`))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetOrderAccrual(ctx, "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_SeededFallbackIsReproducible(t *testing.T) {
+	first, second := NewClient(Scenario{Seed: 7}), NewClient(Scenario{Seed: 7})
+	ctx := context.Background()
+
+	// Порядок запросов по разным заказам не влияет на ответы.
+	_, _ = second.GetOrderAccrual(ctx, "other")
+	for i := 0; i < 10; i++ {
+		a, errA := first.GetOrderAccrual(ctx, "79927398713")
+		b, errB := second.GetOrderAccrual(ctx, "79927398713")
+		if errA != nil || errB != nil {
+			t.Fatalf("unexpected errors: %v, %v", errA, errB)
+		}
+		if a.Status != b.Status || (a.Accrual == nil) != (b.Accrual == nil) || (a.Accrual != nil && !a.Accrual.Equal(*b.Accrual)) {
+			t.Fatalf("call %d: responses differ: %+v vs %+v", i, a, b)
+		}
+	}
+}
+
+func TestParseScenario_Invalid(t *testing.T) {
+	for _, content := range []string{
+		`unknown: 1`,
+		`orders: [{steps: [{status: PROCESSED}]}]`,
+		`orders: [{order: "1", prefix: "1", steps: [{status: PROCESSED}]}]`,
+		`orders: [{order: "1"}]`,
+		`orders: [{regex: "(", steps: [{status: PROCESSED}]}]`,
+		`orders: [{order: "1", steps: [{status: DONE}]}]`,
+		`orders: [{order: "1", steps: [{status: INVALID, accrual: 5}]}]`,
+		`orders: [{order: "1", steps: [{code: 404}]}]`,
+		`orders: [{order: "1", steps: [{code: 429, status: PROCESSED}]}]`,
+	} {
+		if _, err := ParseScenario([]byte(content)); !errors.Is(err, ErrInvalidScenario) {
+			t.Errorf("ParseScenario(%q): want ErrInvalidScenario, got %v", content, err)
+		}
+	}
+}
+
+func TestParseScenario_JSON(t *testing.T) {
+	scenario := mustParse(t, `{"seed": 3, "orders": [{"order": "1", "steps": [{"status": "PROCESSED", "accrual": 10.5, "delay": "5ms"}]}]}`)
+	if scenario.Seed != 3 || len(scenario.Rules) != 1 {
+		t.Fatalf("unexpected scenario: %+v", scenario)
+	}
+	step := scenario.Rules[0].Steps[0]
+	if step.Accrual == nil || step.Accrual.String() != "10.5" || step.Delay != 5*time.Millisecond {
+		t.Fatalf("unexpected step: %+v", step)
+	}
+}
+
+func mustParse(t *testing.T, content string) Scenario {
+	t.Helper()
+	scenario, err := ParseScenario([]byte(content))
+	if err != nil {
+		t.Fatalf("parse scenario: %v", err)
+	}
+	return scenario
+}
+
+func TestLoadScenario_Example(t *testing.T) {
+	scenario, err := LoadScenario("../../../../accrual-scenario.example.yaml")
+	if err != nil {
+		t.Fatalf("example scenario must be valid: %v", err)
+	}
+	if scenario.Seed != 42 || len(scenario.Rules) != 3 {
+		t.Fatalf("unexpected example scenario: %+v", scenario)
+	}
+}
@@ -0,0 +1,213 @@
+package mock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"loyalty/internal/domain/accrual/model"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidScenario возвращается для сценария с неизвестными полями, некорректным правилом или шагом.
+var ErrInvalidScenario = errors.New("invalid accrual scenario")
+
+// DefaultSeed — seed запасного генератора, если в сценарии он не задан.
+const DefaultSeed = 1
+
+// Scenario описывает поведение фиктивной системы accrual: правила для заказов (проверяются по порядку)
+// и seed запасного генератора для заказов, которым не подошло ни одно правило.
+type Scenario struct {
+	Seed  int64  `yaml:"seed"`
+	Rules []Rule `yaml:"orders"`
+}
+
+// Rule сопоставляет заказам последовательность ответов. Заказ подходит, если совпадает номер
+// (Order), префикс (Prefix) или регулярное выражение (Regex) — задаётся ровно одно условие.
+// Каждый следующий запрос по заказу получает следующий шаг, после последнего повторяется последний.
+type Rule struct {
+	Order  string `yaml:"order"`
+	Prefix string `yaml:"prefix"`
+	Regex  string `yaml:"regex"`
+	Steps  []Step `yaml:"steps"`
+
+	pattern *regexp.Regexp
+}
+
+// Step — один ответ фиктивной системы accrual.
+type Step struct {
+	// Status — статус расчёта для ответа 200.
+	Status model.AccrualStatus `yaml:"status"`
+	// Accrual — начисление (только для PROCESSED).
+	Accrual *decimal.Decimal `yaml:"accrual"`
+	// Delay — задержка перед ответом.
+	Delay time.Duration `yaml:"delay"`
+	// Code — HTTP-код ответа вместо 200: 204 (заказ не зарегистрирован), 429 или 5xx.
+	Code int `yaml:"code"`
+	// RetryAfter — заголовок Retry-After ответа 429.
+	RetryAfter time.Duration `yaml:"retry_after"`
+	// RateLimit — лимит запросов в минуту в теле ответа 429.
+	RateLimit int `yaml:"rate_limit"`
+}
+
+// LoadScenario читает сценарий из YAML- или JSON-файла.
+func LoadScenario(path string) (Scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("read scenario: %w", err)
+	}
+	return ParseScenario(content)
+}
+
+// ParseScenario разбирает сценарий в YAML или JSON (JSON — подмножество YAML) и проверяет его.
+func ParseScenario(content []byte) (Scenario, error) {
+	var scenario Scenario
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&scenario); err != nil && !errors.Is(err, io.EOF) {
+		return Scenario{}, fmt.Errorf("%w: %w", ErrInvalidScenario, err)
+	}
+	if err := scenario.compile(); err != nil {
+		return Scenario{}, err
+	}
+	return scenario, nil
+}
+
+// compile проверяет правила и шаги и компилирует регулярные выражения.
+func (scenario *Scenario) compile() error {
+	if scenario.Seed == 0 {
+		scenario.Seed = DefaultSeed
+	}
+	for i := range scenario.Rules {
+		rule := &scenario.Rules[i]
+		conditions := 0
+		for _, condition := range []string{rule.Order, rule.Prefix, rule.Regex} {
+			if condition != "" {
+				conditions++
+			}
+		}
+		if conditions != 1 {
+			return fmt.Errorf("%w: orders[%d]: want exactly one of order, prefix, regex", ErrInvalidScenario, i)
+		}
+		if rule.Regex != "" {
+			pattern, err := regexp.Compile(rule.Regex)
+			if err != nil {
+				return fmt.Errorf("%w: orders[%d]: %w", ErrInvalidScenario, i, err)
+			}
+			rule.pattern = pattern
+		}
+		if len(rule.Steps) == 0 {
+			return fmt.Errorf("%w: orders[%d]: no steps", ErrInvalidScenario, i)
+		}
+		for j, step := range rule.Steps {
+			if err := step.validate(); err != nil {
+				return fmt.Errorf("%w: orders[%d].steps[%d]: %w", ErrInvalidScenario, i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (step Step) validate() error {
+	switch {
+	case step.Code == 0:
+		accrual := model.Accrual{Order: "-", Status: step.Status, Accrual: step.Accrual}
+		if err := accrual.Validate(); err != nil {
+			return err
+		}
+		if step.Accrual != nil && step.Status != model.StatusProcessed {
+			return errors.New("accrual is allowed only for PROCESSED")
+		}
+	case step.Code == 204, step.Code == 429, step.Code >= 500 && step.Code <= 599:
+		if step.Status != "" || step.Accrual != nil {
+			return fmt.Errorf("status and accrual are not allowed with code %d", step.Code)
+		}
+	default:
+		return fmt.Errorf("unsupported code %d", step.Code)
+	}
+	if step.Delay < 0 || step.RetryAfter < 0 || step.RateLimit < 0 {
+		return errors.New("negative delay, retry_after or rate_limit")
+	}
+	return nil
+}
+
+func (rule Rule) matches(orderNumber string) bool {
+	switch {
+	case rule.Order != "":
+		return orderNumber == rule.Order
+	case rule.Prefix != "":
+		return strings.HasPrefix(orderNumber, rule.Prefix)
+	default:
+		return rule.pattern.MatchString(orderNumber)
+	}
+}
+
+// Script воспроизводит сценарий: помнит, сколько ответов уже получил каждый заказ.
+// Безопасен для конкурентного использования.
+type Script struct {
+	scenario Scenario
+
+	mu       sync.Mutex
+	attempts map[string]int
+	random   map[string]*rand.Rand
+}
+
+// NewScript создаёт воспроизведение сценария с начала.
+func NewScript(scenario Scenario) *Script {
+	return &Script{
+		scenario: scenario,
+		attempts: make(map[string]int),
+		random:   make(map[string]*rand.Rand),
+	}
+}
+
+// Next возвращает следующий ответ для заказа. Для заказов без подходящего правила ответ выбирается
+// случайно, но детерминированно: последовательность ответов по заказу зависит только от seed и номера.
+func (script *Script) Next(orderNumber string) Step {
+	script.mu.Lock()
+	defer script.mu.Unlock()
+
+	attempt := script.attempts[orderNumber]
+	script.attempts[orderNumber] = attempt + 1
+
+	for _, rule := range script.scenario.Rules {
+		if rule.matches(orderNumber) {
+			return rule.Steps[min(attempt, len(rule.Steps)-1)]
+		}
+	}
+	return script.randomStep(orderNumber)
+}
+
+// randomStep выбирает случайный статус и начисление от 0 до 100 генератором заказа.
+func (script *Script) randomStep(orderNumber string) Step {
+	rng, ok := script.random[orderNumber]
+	if !ok {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(orderNumber))
+		rng = rand.New(rand.NewSource(script.scenario.Seed ^ int64(hash.Sum64())))
+		script.random[orderNumber] = rng
+	}
+
+	statuses := []model.AccrualStatus{
+		model.StatusRegistered,
+		model.StatusProcessing,
+		model.StatusProcessed,
+		model.StatusInvalid,
+	}
+	step := Step{Status: statuses[rng.Intn(len(statuses))]}
+	if step.Status == model.StatusProcessed {
+		accrual := decimal.NewFromFloat(rng.Float64() * 100).Round(2)
+		step.Accrual = &accrual
+	}
+	return step
+}
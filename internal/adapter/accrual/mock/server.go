@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"loyalty/internal/domain/accrual/model"
+)
+
+// orderResponse — ответ системы accrual; начисление — JSON-число, как в настоящей системе.
+type orderResponse struct {
+	Order   string              `json:"order"`
+	Status  model.AccrualStatus `json:"status"`
+	Accrual json.Number         `json:"accrual,omitempty"`
+}
+
+// NewHandler создаёт HTTP-обработчик, который реализует контракт системы accrual
+// (GET /api/orders/{number}) по сценарию: 200 с JSON, 204, 429 с Retry-After и лимитом в теле или 5xx.
+func NewHandler(script *Script) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/orders/{number}", func(w http.ResponseWriter, r *http.Request) {
+		orderNumber := r.PathValue("number")
+		step := script.Next(orderNumber)
+		if err := sleep(r.Context(), step.Delay); err != nil {
+			return
+		}
+
+		switch {
+		case step.Code == http.StatusNoContent:
+			w.WriteHeader(http.StatusNoContent)
+		case step.Code == http.StatusTooManyRequests:
+			if step.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(step.RetryAfter.Seconds()))))
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusTooManyRequests)
+			if step.RateLimit > 0 {
+				_, _ = fmt.Fprintf(w, "No more than %d requests per minute allowed", step.RateLimit)
+			}
+		case step.Code != 0:
+			http.Error(w, http.StatusText(step.Code), step.Code)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			resp := orderResponse{Order: orderNumber, Status: step.Status}
+			if step.Accrual != nil {
+				resp.Accrual = json.Number(step.Accrual.String())
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		}
+	})
+	return mux
+}
@@ -0,0 +1,82 @@
+package router
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	accrualclient "loyalty/internal/domain/accrual/client"
+	"loyalty/internal/domain/accrual/model"
+)
+
+// Rule — условие выбора провайдера по номеру заказа. Заданные условия объединяются по «и»;
+// пустое правило не подходит ни одному заказу.
+type Rule struct {
+	// Prefix — номер заказа начинается с Prefix.
+	Prefix string
+	// Length — длина номера заказа (0 — любая).
+	Length int
+	// Pattern — номер заказа соответствует регулярному выражению (nil — любой).
+	Pattern *regexp.Regexp
+}
+
+// Matches сообщает, подходит ли заказ под правило.
+func (rule Rule) Matches(orderNumber string) bool {
+	if rule.Prefix == "" && rule.Length == 0 && rule.Pattern == nil {
+		return false
+	}
+	if rule.Prefix != "" && !strings.HasPrefix(orderNumber, rule.Prefix) {
+		return false
+	}
+	if rule.Length > 0 && len(orderNumber) != rule.Length {
+		return false
+	}
+	return rule.Pattern == nil || rule.Pattern.MatchString(orderNumber)
+}
+
+// Provider — система accrual со своим клиентом.
+type Provider struct {
+	Name   string
+	Rule   Rule
+	Client accrualclient.AccrualClient
+}
+
+// Client реализует accrual.AccrualClient поверх нескольких систем accrual: заказ уходит первому
+// провайдеру, правило которого ему подходит, остальные — основной системе.
+type Client struct {
+	providers []Provider
+	fallback  Provider
+}
+
+// NewClient создаёт маршрутизирующий клиент. fallback — клиент основной системы
+// (провайдер accrualclient.DefaultProvider); providers проверяются по порядку.
+func NewClient(fallback accrualclient.AccrualClient, providers ...Provider) *Client {
+	return &Client{
+		providers: providers,
+		fallback:  Provider{Name: accrualclient.DefaultProvider, Client: fallback},
+	}
+}
+
+// GetOrderAccrual запрашивает начисление у провайдера, который считает заказ.
+func (client *Client) GetOrderAccrual(ctx context.Context, orderNumber string) (*model.Accrual, error) {
+	return client.route(orderNumber).Client.GetOrderAccrual(ctx, orderNumber)
+}
+
+// Provider возвращает имя провайдера, который считает заказ.
+func (client *Client) Provider(orderNumber string) string {
+	return client.route(orderNumber).Name
+}
+
+// Providers возвращает все провайдеры, включая основной (первым).
+func (client *Client) Providers() []Provider {
+	return append([]Provider{client.fallback}, client.providers...)
+}
+
+func (client *Client) route(orderNumber string) Provider {
+	for _, provider := range client.providers {
+		if provider.Rule.Matches(orderNumber) {
+			return provider
+		}
+	}
+	return client.fallback
+}
@@ -0,0 +1,81 @@
+package router
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	accrualclient "loyalty/internal/domain/accrual/client"
+	"loyalty/internal/domain/accrual/model"
+)
+
+type stubClient struct {
+	calls []string
+}
+
+func (stub *stubClient) GetOrderAccrual(_ context.Context, orderNumber string) (*model.Accrual, error) {
+	stub.calls = append(stub.calls, orderNumber)
+	return &model.Accrual{Order: orderNumber, Status: model.StatusProcessed}, nil
+}
+
+func TestRule_Matches(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		number string
+		want   bool
+	}{
+		{"prefix", Rule{Prefix: "42"}, "4212345", true},
+		{"other prefix", Rule{Prefix: "42"}, "1242345", false},
+		{"length", Rule{Length: 5}, "12345", true},
+		{"other length", Rule{Length: 5}, "123456", false},
+		{"regex", Rule{Pattern: regexp.MustCompile(`^9\d{3}$`)}, "9123", true},
+		{"regex mismatch", Rule{Pattern: regexp.MustCompile(`^9\d{3}$`)}, "91234", false},
+		{"prefix and length", Rule{Prefix: "42", Length: 4}, "4212", true},
+		{"prefix but not length", Rule{Prefix: "42", Length: 4}, "42123", false},
+		{"empty rule", Rule{}, "4212", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.number); got != tt.want {
+				t.Fatalf("Matches(%q) = %v, want %v", tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_RoutesByFirstMatchingProvider(t *testing.T) {
+	main, merchant, legacy := &stubClient{}, &stubClient{}, &stubClient{}
+	client := NewClient(main,
+		Provider{Name: "merchant2", Rule: Rule{Prefix: "42"}, Client: merchant},
+		Provider{Name: "legacy", Rule: Rule{Length: 4}, Client: legacy},
+	)
+
+	for _, number := range []string{"4212", "9999", "79927398713"} {
+		if _, err := client.GetOrderAccrual(context.Background(), number); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+
+	if len(merchant.calls) != 1 || merchant.calls[0] != "4212" {
+		t.Fatalf("merchant2 must get orders with prefix 42 first, got %v", merchant.calls)
+	}
+	if len(legacy.calls) != 1 || legacy.calls[0] != "9999" {
+		t.Fatalf("legacy must get other 4-digit orders, got %v", legacy.calls)
+	}
+	if len(main.calls) != 1 || main.calls[0] != "79927398713" {
+		t.Fatalf("default provider must get unmatched orders, got %v", main.calls)
+	}
+
+	if got := client.Provider("4212"); got != "merchant2" {
+		t.Fatalf("Provider(4212) = %q", got)
+	}
+	if got := client.Provider("79927398713"); got != accrualclient.DefaultProvider {
+		t.Fatalf("Provider(79927398713) = %q", got)
+	}
+	providers := client.Providers()
+	if len(providers) != 3 || providers[0].Name != accrualclient.DefaultProvider || providers[2].Name != "legacy" {
+		t.Fatalf("unexpected providers: %+v", providers)
+	}
+}
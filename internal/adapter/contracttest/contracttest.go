@@ -0,0 +1,448 @@
+// Package contracttest — общий набор контрактных тестов репозиториев. Один и тот же набор
+// запускается для каждой реализации хранилища (PostgreSQL, память), чтобы их семантика не расходилась.
+package contracttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	authmodel "loyalty/internal/domain/auth/model"
+	authrepo "loyalty/internal/domain/auth/repository"
+	balancerepo "loyalty/internal/domain/balance/repository"
+	ordersmodel "loyalty/internal/domain/order/model"
+	ordersrepo "loyalty/internal/domain/order/repository"
+	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
+	withdrawalsrepo "loyalty/internal/domain/withdrawal/repository"
+
+	"github.com/shopspring/decimal"
+)
+
+// Backend — репозитории проверяемой реализации хранилища.
+type Backend struct {
+	Users       authrepo.UserRepository
+	Orders      ordersrepo.OrdersRepository
+	Balance     balancerepo.BalanceRepository
+	Accounts    withdrawalsrepo.AccountRepository
+	Withdrawals withdrawalsrepo.WithdrawalsRepository
+}
+
+// Run запускает все контрактные тесты. newBackend должен возвращать пустое хранилище для каждого подтеста.
+func Run(t *testing.T, newBackend func(t *testing.T) Backend) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, backend Backend)
+	}{
+		{name: "users", run: testUsers},
+		{name: "order upload conflicts", run: testOrderConflicts},
+		{name: "pending orders", run: testPendingOrders},
+		{name: "idempotent accrual", run: testIdempotentAccrual},
+		{name: "concurrent accrual", run: testConcurrentAccrual},
+		{name: "requeue", run: testRequeue},
+		{name: "status history", run: testStatusHistory},
+		{name: "withdrawals", run: testWithdrawals},
+		{name: "concurrent withdrawals", run: testConcurrentWithdrawals},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newBackend(t))
+		})
+	}
+}
+
+func testUsers(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	created := mustCreateUser(t, backend, "alice")
+	if _, err := backend.Users.Create(ctx, "alice", []byte("other"), 0); !errors.Is(err, authmodel.ErrLoginTaken) {
+		t.Fatalf("want ErrLoginTaken for duplicate login, got %v", err)
+	}
+
+	byLogin, err := backend.Users.FindByLogin(ctx, "alice")
+	if err != nil || byLogin.ID != created.ID || string(byLogin.PasswordHash) != "hash" {
+		t.Fatalf("FindByLogin = %+v, %v", byLogin, err)
+	}
+	byID, err := backend.Users.FindByID(ctx, created.ID)
+	if err != nil || byID.Login != "alice" {
+		t.Fatalf("FindByID = %+v, %v", byID, err)
+	}
+	if _, err := backend.Users.FindByLogin(ctx, "bob"); !errors.Is(err, authmodel.ErrNotFound) {
+		t.Fatalf("want ErrNotFound for unknown login, got %v", err)
+	}
+	if _, err := backend.Users.FindByID(ctx, created.ID+1000); !errors.Is(err, authmodel.ErrNotFound) {
+		t.Fatalf("want ErrNotFound for unknown id, got %v", err)
+	}
+
+	balance, err := backend.Balance.GetBalance(ctx, created.ID)
+	if err != nil || !balance.Current.IsZero() || !balance.Withdrawn.IsZero() {
+		t.Fatalf("new user must have an empty account, got %+v, %v", balance, err)
+	}
+}
+
+func testOrderConflicts(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	alice := mustCreateUser(t, backend, "alice")
+	bob := mustCreateUser(t, backend, "bob")
+
+	mustCreateOrder(t, backend, alice.ID, "79927398713")
+	if err := backend.Orders.Create(ctx, alice.ID, "79927398713"); !errors.Is(err, ordersmodel.ErrOrderAlreadyUploaded) {
+		t.Fatalf("want ErrOrderAlreadyUploaded, got %v", err)
+	}
+	if err := backend.Orders.Create(ctx, bob.ID, "79927398713"); !errors.Is(err, ordersmodel.ErrOrderAlreadyUploadedByAnother) {
+		t.Fatalf("want ErrOrderAlreadyUploadedByAnother, got %v", err)
+	}
+	mustCreateOrder(t, backend, alice.ID, "12345678903")
+
+	orders, err := backend.Orders.ListByUser(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(orders) != 2 || orders[0].Number != "12345678903" || orders[1].Number != "79927398713" {
+		t.Fatalf("want alice's orders newest first, got %+v", orders)
+	}
+	for _, order := range orders {
+		if order.Status != ordersmodel.StatusNew || order.UserID != alice.ID || order.Accrual != nil {
+			t.Fatalf("unexpected new order: %+v", order)
+		}
+	}
+	if orders, err := backend.Orders.ListByUser(ctx, bob.ID); err != nil || len(orders) != 0 {
+		t.Fatalf("bob must have no orders, got %+v, %v", orders, err)
+	}
+}
+
+func testPendingOrders(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	alice := mustCreateUser(t, backend, "alice")
+
+	mustCreateOrder(t, backend, alice.ID, "1")
+	mustCreateOrder(t, backend, alice.ID, "2")
+	mustCreateOrder(t, backend, alice.ID, "3")
+	mustCreateOrder(t, backend, alice.ID, "4")
+	mustUpdate(t, backend, "2", ordersmodel.StatusProcessing, nil)
+	mustUpdate(t, backend, "3", ordersmodel.StatusInvalid, nil)
+	mustUpdate(t, backend, "4", ordersmodel.StatusProcessed, decimalPtr("10"))
+
+	pending, err := backend.Orders.ListPending(ctx)
+	if err != nil {
+		t.Fatalf("ListPending: %v", err)
+	}
+	if len(pending) != 2 || pending[0].Number != "1" || pending[1].Number != "2" {
+		t.Fatalf("want NEW and PROCESSING orders oldest first, got %+v", pending)
+	}
+	if pending[0].UserID != alice.ID || pending[1].Status != ordersmodel.StatusProcessing {
+		t.Fatalf("unexpected pending orders: %+v", pending)
+	}
+}
+
+func testIdempotentAccrual(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	alice := mustCreateUser(t, backend, "alice")
+	mustCreateOrder(t, backend, alice.ID, "79927398713")
+
+	if err := backend.Orders.UpdateFromAccrual(ctx, "00000000000", processed("1"), ordersmodel.Rewards{}); err != nil {
+		t.Fatalf("unknown order must be ignored, got %v", err)
+	}
+	for range 3 {
+		mustUpdate(t, backend, "79927398713", ordersmodel.StatusProcessed, decimalPtr("500.5"))
+	}
+
+	assertBalance(t, backend, alice.ID, "500.5", "0")
+	orders, err := backend.Orders.ListByUser(ctx, alice.ID)
+	if err != nil || len(orders) != 1 {
+		t.Fatalf("ListByUser = %+v, %v", orders, err)
+	}
+	if orders[0].Status != ordersmodel.StatusProcessed || orders[0].Accrual == nil || !orders[0].Accrual.Equal(decimal.RequireFromString("500.5")) {
+		t.Fatalf("unexpected processed order: %+v", orders[0])
+	}
+}
+
+func testConcurrentAccrual(t *testing.T, backend Backend) {
+	alice := mustCreateUser(t, backend, "alice")
+	mustCreateOrder(t, backend, alice.ID, "79927398713")
+
+	parallel(t, 10, func(int) error {
+		return backend.Orders.UpdateFromAccrual(context.Background(), "79927398713", processed("100"), ordersmodel.Rewards{})
+	})
+
+	assertBalance(t, backend, alice.ID, "100", "0")
+}
+
+func testRequeue(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	alice := mustCreateUser(t, backend, "alice")
+	mustCreateOrder(t, backend, alice.ID, "1")
+	mustCreateOrder(t, backend, alice.ID, "2")
+	mustUpdate(t, backend, "1", ordersmodel.StatusInvalid, nil)
+	mustUpdate(t, backend, "2", ordersmodel.StatusProcessed, decimalPtr("10"))
+	// Повторный PROCESSED не должен сбрасывать признак зачисления: иначе заказ можно вернуть в очередь
+	// и зачислить ещё раз.
+	mustUpdate(t, backend, "2", ordersmodel.StatusProcessed, decimalPtr("10"))
+
+	order, err := backend.Orders.Requeue(ctx, "1")
+	if err != nil || order.Status != ordersmodel.StatusNew || order.UserID != alice.ID {
+		t.Fatalf("Requeue = %+v, %v", order, err)
+	}
+	if _, err := backend.Orders.Requeue(ctx, "2"); !errors.Is(err, ordersmodel.ErrOrderAlreadyCredited) {
+		t.Fatalf("want ErrOrderAlreadyCredited, got %v", err)
+	}
+	if _, err := backend.Orders.Requeue(ctx, "3"); !errors.Is(err, ordersmodel.ErrOrderNotFound) {
+		t.Fatalf("want ErrOrderNotFound, got %v", err)
+	}
+}
+
+func testStatusHistory(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	alice := mustCreateUser(t, backend, "alice")
+	mustCreateOrder(t, backend, alice.ID, "1")
+	mustCreateOrder(t, backend, alice.ID, "2")
+
+	// История хранит только смены статуса: повторный опрос с тем же статусом записи не добавляет,
+	// в записи перехода остаётся ответ, который его вызвал.
+	processing := `{"order":"1","status":"PROCESSING"}`
+	for _, raw := range []string{processing, `{"order":"1","status":"PROCESSING","poll":2}`} {
+		repeated := ordersmodel.StatusUpdate{Status: ordersmodel.StatusProcessing, Source: ordersmodel.SourceWorker, Response: []byte(raw)}
+		if err := backend.Orders.UpdateFromAccrual(ctx, "1", repeated, ordersmodel.Rewards{}); err != nil {
+			t.Fatalf("UpdateFromAccrual(PROCESSING): %v", err)
+		}
+	}
+	history, err := backend.Orders.ListHistory(ctx, "1")
+	if err != nil || len(history) != 2 || history[1].To != ordersmodel.StatusProcessing || !jsonEqual(t, history[1].Response, processing) {
+		t.Fatalf("repeated PROCESSING must keep a single transition record, got %+v, %v", history, err)
+	}
+
+	response := `{"order":"1","status":"PROCESSED","accrual":10}`
+	update := ordersmodel.StatusUpdate{
+		Status:   ordersmodel.StatusProcessed,
+		Accrual:  decimalPtr("10"),
+		Source:   ordersmodel.SourceWebhook,
+		Response: []byte(response),
+	}
+	if err := backend.Orders.UpdateFromAccrual(ctx, "1", update, ordersmodel.Rewards{}); err != nil {
+		t.Fatalf("UpdateFromAccrual: %v", err)
+	}
+	illegal := ordersmodel.StatusUpdate{Status: ordersmodel.StatusInvalid, Source: ordersmodel.SourceWorker}
+	if err := backend.Orders.UpdateFromAccrual(ctx, "1", illegal, ordersmodel.Rewards{}); !errors.Is(err, ordersmodel.ErrIllegalTransition) {
+		t.Fatalf("want ErrIllegalTransition for PROCESSED -> INVALID, got %v", err)
+	}
+	duplicate := ordersmodel.StatusUpdate{Status: ordersmodel.StatusProcessed, Accrual: decimalPtr("10.00"), Source: ordersmodel.SourceWebhook}
+	if err := backend.Orders.UpdateFromAccrual(ctx, "1", duplicate, ordersmodel.Rewards{}); err != nil {
+		t.Fatalf("duplicate result must be a no-op, got %v", err)
+	}
+	duplicate.Accrual = decimalPtr("99")
+	if err := backend.Orders.UpdateFromAccrual(ctx, "1", duplicate, ordersmodel.Rewards{}); !errors.Is(err, ordersmodel.ErrAccrualConflict) {
+		t.Fatalf("want ErrAccrualConflict for a different amount, got %v", err)
+	}
+	assertBalance(t, backend, alice.ID, "10", "0")
+
+	order, err := backend.Orders.Get(ctx, "1")
+	if err != nil || order.Status != ordersmodel.StatusProcessed || order.UserID != alice.ID ||
+		order.Accrual == nil || !order.Accrual.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("Get = %+v, %v", order, err)
+	}
+	if _, err := backend.Orders.Get(ctx, "3"); !errors.Is(err, ordersmodel.ErrOrderNotFound) {
+		t.Fatalf("want ErrOrderNotFound, got %v", err)
+	}
+
+	history, err = backend.Orders.ListHistory(ctx, "1")
+	if err != nil {
+		t.Fatalf("ListHistory: %v", err)
+	}
+	want := []struct {
+		from, to ordersmodel.Status
+		source   ordersmodel.Source
+	}{
+		{"", ordersmodel.StatusNew, ordersmodel.SourceUser},
+		{ordersmodel.StatusNew, ordersmodel.StatusProcessing, ordersmodel.SourceWorker},
+		{ordersmodel.StatusProcessing, ordersmodel.StatusProcessed, ordersmodel.SourceWebhook},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("want %d history records, got %+v", len(want), history)
+	}
+	for i, change := range history {
+		if change.Number != "1" || change.From != want[i].from || change.To != want[i].to || change.Source != want[i].source {
+			t.Fatalf("history[%d] = %+v, want %+v", i, change, want[i])
+		}
+		if i > 0 && change.ChangedAt.Before(history[i-1].ChangedAt) {
+			t.Fatalf("history must be ordered by time, got %+v", history)
+		}
+	}
+	last := history[2]
+	if last.Accrual == nil || !last.Accrual.Equal(decimal.NewFromInt(10)) || !jsonEqual(t, last.Response, response) {
+		t.Fatalf("unexpected processed history record: %+v (response %s)", last, last.Response)
+	}
+
+	mustUpdate(t, backend, "2", ordersmodel.StatusInvalid, nil)
+	if _, err := backend.Orders.Requeue(ctx, "2"); err != nil {
+		t.Fatalf("Requeue: %v", err)
+	}
+	history, err = backend.Orders.ListHistory(ctx, "2")
+	if err != nil || len(history) != 3 {
+		t.Fatalf("ListHistory = %+v, %v", history, err)
+	}
+	if requeued := history[2]; requeued.From != ordersmodel.StatusInvalid || requeued.To != ordersmodel.StatusNew ||
+		requeued.Source != ordersmodel.SourceAdmin || len(requeued.Response) != 0 {
+		t.Fatalf("unexpected requeue history record: %+v", requeued)
+	}
+}
+
+func testWithdrawals(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	alice := mustCreateUser(t, backend, "alice")
+	credit(t, backend, alice.ID, "100")
+	at := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	if err := backend.Accounts.Withdraw(ctx, alice.ID, "2377225624", decimal.Zero, at); !errors.Is(err, withdrawalsmodel.ErrInvalidWithdrawSum) {
+		t.Fatalf("want ErrInvalidWithdrawSum, got %v", err)
+	}
+	if err := backend.Accounts.Withdraw(ctx, alice.ID, "2377225624", decimal.NewFromInt(101), at); !errors.Is(err, withdrawalsmodel.ErrInsufficientFunds) {
+		t.Fatalf("want ErrInsufficientFunds, got %v", err)
+	}
+	if err := backend.Accounts.Withdraw(ctx, alice.ID, "2377225624", decimal.NewFromInt(30), at); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	// Повторное списание по тому же заказу не меняет баланс.
+	if err := backend.Accounts.Withdraw(ctx, alice.ID, "2377225624", decimal.NewFromInt(30), at); err != nil {
+		t.Fatalf("repeated Withdraw: %v", err)
+	}
+	if err := backend.Accounts.Withdraw(ctx, alice.ID, "49927398716", decimal.NewFromInt(20), at.Add(time.Hour)); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+
+	assertBalance(t, backend, alice.ID, "50", "50")
+	withdrawals, err := backend.Withdrawals.ListByUser(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(withdrawals) != 2 || withdrawals[0].OrderNumber != "49927398716" || withdrawals[1].OrderNumber != "2377225624" {
+		t.Fatalf("want withdrawals newest first, got %+v", withdrawals)
+	}
+	if !withdrawals[1].Sum.Equal(decimal.NewFromInt(30)) || !withdrawals[1].ProcessedAt.Equal(at) || withdrawals[1].UserID != alice.ID {
+		t.Fatalf("unexpected withdrawal: %+v", withdrawals[1])
+	}
+}
+
+func testConcurrentWithdrawals(t *testing.T, backend Backend) {
+	alice := mustCreateUser(t, backend, "alice")
+	credit(t, backend, alice.ID, "100")
+
+	var (
+		mu        sync.Mutex
+		succeeded int
+	)
+	parallel(t, 20, func(i int) error {
+		err := backend.Accounts.Withdraw(context.Background(), alice.ID, fmt.Sprintf("w-%d", i), decimal.NewFromInt(10), time.Now())
+		if errors.Is(err, withdrawalsmodel.ErrInsufficientFunds) {
+			return nil
+		}
+		if err == nil {
+			mu.Lock()
+			succeeded++
+			mu.Unlock()
+		}
+		return err
+	})
+
+	if succeeded != 10 {
+		t.Fatalf("want exactly 10 successful withdrawals, got %d", succeeded)
+	}
+	assertBalance(t, backend, alice.ID, "0", "100")
+}
+
+func mustCreateUser(t *testing.T, backend Backend, login string) authmodel.User {
+	t.Helper()
+	user, err := backend.Users.Create(context.Background(), login, []byte("hash"), 0)
+	if err != nil {
+		t.Fatalf("create user %q: %v", login, err)
+	}
+	return user
+}
+
+func mustCreateOrder(t *testing.T, backend Backend, userID int64, number string) {
+	t.Helper()
+	if err := backend.Orders.Create(context.Background(), userID, number); err != nil {
+		t.Fatalf("create order %q: %v", number, err)
+	}
+	// Разное время загрузки делает порядок заказов однозначным для любой реализации.
+	time.Sleep(2 * time.Millisecond)
+}
+
+func mustUpdate(t *testing.T, backend Backend, number string, status ordersmodel.Status, accrual *decimal.Decimal) {
+	t.Helper()
+	update := ordersmodel.StatusUpdate{Status: status, Accrual: accrual, Source: ordersmodel.SourceWorker}
+	if err := backend.Orders.UpdateFromAccrual(context.Background(), number, update, ordersmodel.Rewards{}); err != nil {
+		t.Fatalf("update order %q: %v", number, err)
+	}
+}
+
+// credit пополняет счёт через начисление по отдельному заказу.
+func credit(t *testing.T, backend Backend, userID int64, amount string) {
+	t.Helper()
+	number := fmt.Sprintf("credit-%d", userID)
+	mustCreateOrder(t, backend, userID, number)
+	mustUpdate(t, backend, number, ordersmodel.StatusProcessed, decimalPtr(amount))
+}
+
+func assertBalance(t *testing.T, backend Backend, userID int64, current, withdrawn string) {
+	t.Helper()
+	balance, err := backend.Balance.GetBalance(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if !balance.Current.Equal(decimal.RequireFromString(current)) || !balance.Withdrawn.Equal(decimal.RequireFromString(withdrawn)) {
+		t.Fatalf("want balance %s/%s, got %s/%s", current, withdrawn, balance.Current, balance.Withdrawn)
+	}
+}
+
+// parallel выполняет fn в n горутинах одновременно и проверяет, что ни одна не вернула ошибку.
+func parallel(t *testing.T, n int, fn func(i int) error) {
+	t.Helper()
+	var (
+		start = make(chan struct{})
+		wg    sync.WaitGroup
+		errs  = make(chan error, n)
+	)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs <- fn(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("parallel call failed: %v", err)
+		}
+	}
+}
+
+// processed — обновление статуса до PROCESSED с начислением amount от воркера.
+func processed(amount string) ordersmodel.StatusUpdate {
+	return ordersmodel.StatusUpdate{Status: ordersmodel.StatusProcessed, Accrual: decimalPtr(amount), Source: ordersmodel.SourceWorker}
+}
+
+// jsonEqual сравнивает JSON без учёта форматирования: PostgreSQL хранит JSONB в нормализованном виде.
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("invalid expected JSON %s: %v", want, err)
+	}
+	return reflect.DeepEqual(gotValue, wantValue)
+}
+
+func decimalPtr(value string) *decimal.Decimal {
+	parsed := decimal.RequireFromString(value)
+	return &parsed
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	balancemodel "loyalty/internal/domain/balance/model"
+	balancerepo "loyalty/internal/domain/balance/repository"
+	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
+	withdrawalsrepo "loyalty/internal/domain/withdrawal/repository"
+
+	"github.com/shopspring/decimal"
+)
+
+// AccountRepository — in-memory реализация balancerepo.BalanceRepository и withdrawalsrepo.AccountRepository.
+type AccountRepository struct {
+	store *Store
+}
+
+// NewAccountRepository создаёт репозиторий счетов поверх store.
+func NewAccountRepository(store *Store) *AccountRepository {
+	return &AccountRepository{store: store}
+}
+
+// GetBalance возвращает баланс пользователя (счёт создаётся при первом обращении).
+func (repository *AccountRepository) GetBalance(ctx context.Context, userID int64) (balancemodel.Balance, error) {
+	if err := ctx.Err(); err != nil {
+		return balancemodel.Balance{}, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	acc := store.accountLocked(userID)
+	return balancemodel.Balance{Current: acc.current, Withdrawn: acc.withdrawn}, nil
+}
+
+// Withdraw атомарно списывает сумму и записывает списание. Повторное списание по тому же заказу
+// игнорируется, нехватка баллов — withdrawalsmodel.ErrInsufficientFunds.
+func (repository *AccountRepository) Withdraw(
+	ctx context.Context,
+	userID int64,
+	orderNumber string,
+	sum decimal.Decimal,
+	now time.Time,
+) error {
+	if sum.LessThanOrEqual(decimal.Zero) {
+		return withdrawalsmodel.ErrInvalidWithdrawSum
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if _, exists := store.withdrawnFor[orderNumber]; exists {
+		return nil
+	}
+	acc := store.accountLocked(userID)
+	if acc.current.LessThan(sum) {
+		return withdrawalsmodel.ErrInsufficientFunds
+	}
+	acc.current = acc.current.Sub(sum)
+	acc.withdrawn = acc.withdrawn.Add(sum)
+	store.withdrawnFor[orderNumber] = struct{}{}
+	store.withdrawals = append(store.withdrawals, withdrawalsmodel.Withdrawal{
+		UserID:      userID,
+		OrderNumber: orderNumber,
+		Sum:         sum,
+		ProcessedAt: now,
+	})
+	return nil
+}
+
+// WithdrawalsRepository — in-memory реализация withdrawalsrepo.WithdrawalsRepository.
+type WithdrawalsRepository struct {
+	store *Store
+}
+
+// NewWithdrawalsRepository создаёт репозиторий списаний поверх store.
+func NewWithdrawalsRepository(store *Store) *WithdrawalsRepository {
+	return &WithdrawalsRepository{store: store}
+}
+
+// ListByUser возвращает списания пользователя (от новых к старым).
+func (repository *WithdrawalsRepository) ListByUser(ctx context.Context, userID int64) ([]withdrawalsmodel.Withdrawal, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var out []withdrawalsmodel.Withdrawal
+	for _, withdrawal := range store.withdrawals {
+		if withdrawal.UserID == userID {
+			out = append(out, withdrawal)
+		}
+	}
+	// Стабильная сортировка: при равном времени более позднее списание идёт первым.
+	slices.Reverse(out)
+	slices.SortStableFunc(out, func(a, b withdrawalsmodel.Withdrawal) int {
+		return b.ProcessedAt.Compare(a.ProcessedAt)
+	})
+	return out, nil
+}
+
+var _ balancerepo.BalanceRepository = (*AccountRepository)(nil)
+var _ withdrawalsrepo.AccountRepository = (*AccountRepository)(nil)
+var _ withdrawalsrepo.WithdrawalsRepository = (*WithdrawalsRepository)(nil)
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	authmodel "loyalty/internal/domain/auth/model"
+	authrepo "loyalty/internal/domain/auth/repository"
+)
+
+// UserRepository — in-memory реализация authrepo.UserRepository.
+type UserRepository struct {
+	store *Store
+}
+
+// NewUserRepository создаёт репозиторий пользователей поверх store.
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{store: store}
+}
+
+// Create создаёт пользователя и его накопительный счёт; занятый логин — authmodel.ErrLoginTaken.
+// referrerID игнорируется: реферальная программа в памяти не работает.
+func (repository *UserRepository) Create(ctx context.Context, login string, passwordHash []byte, _ int64) (authmodel.User, error) {
+	if err := ctx.Err(); err != nil {
+		return authmodel.User{}, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if _, taken := store.userByLogin[login]; taken {
+		return authmodel.User{}, authmodel.ErrLoginTaken
+	}
+	store.lastUserID++
+	user := authmodel.User{ID: store.lastUserID, Login: login, PasswordHash: slices.Clone(passwordHash)}
+	store.users[user.ID] = user
+	store.userByLogin[login] = user.ID
+	store.accountLocked(user.ID)
+	return cloneUser(user), nil
+}
+
+// FindByLogin возвращает пользователя по логину или authmodel.ErrNotFound.
+func (repository *UserRepository) FindByLogin(ctx context.Context, login string) (authmodel.User, error) {
+	if err := ctx.Err(); err != nil {
+		return authmodel.User{}, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	id, ok := store.userByLogin[login]
+	if !ok {
+		return authmodel.User{}, authmodel.ErrNotFound
+	}
+	return cloneUser(store.users[id]), nil
+}
+
+// FindByID возвращает пользователя по идентификатору или authmodel.ErrNotFound.
+func (repository *UserRepository) FindByID(ctx context.Context, id int64) (authmodel.User, error) {
+	if err := ctx.Err(); err != nil {
+		return authmodel.User{}, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	user, ok := store.users[id]
+	if !ok {
+		return authmodel.User{}, authmodel.ErrNotFound
+	}
+	return cloneUser(user), nil
+}
+
+// cloneUser копирует хеш пароля, чтобы вызывающий код не мог изменить сохранённое значение.
+func cloneUser(user authmodel.User) authmodel.User {
+	user.PasswordHash = slices.Clone(user.PasswordHash)
+	return user
+}
+
+var _ authrepo.UserRepository = (*UserRepository)(nil)
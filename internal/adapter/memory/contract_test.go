@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"testing"
+
+	"loyalty/internal/adapter/contracttest"
+)
+
+func TestContract(t *testing.T) {
+	contracttest.Run(t, func(*testing.T) contracttest.Backend {
+		store := NewStore()
+		accounts := NewAccountRepository(store)
+		return contracttest.Backend{
+			Users:       NewUserRepository(store),
+			Orders:      NewOrdersRepository(store),
+			Balance:     accounts,
+			Accounts:    accounts,
+			Withdrawals: NewWithdrawalsRepository(store),
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	ordersmodel "loyalty/internal/domain/order/model"
+	ordersrepo "loyalty/internal/domain/order/repository"
+
+	"github.com/shopspring/decimal"
+)
+
+// OrdersRepository — in-memory реализация ordersrepo.OrdersRepository.
+type OrdersRepository struct {
+	store *Store
+}
+
+// NewOrdersRepository создаёт репозиторий заказов поверх store.
+func NewOrdersRepository(store *Store) *OrdersRepository {
+	return &OrdersRepository{store: store}
+}
+
+// Create создаёт заказ со статусом NEW. Повторная загрузка тем же пользователем —
+// ErrOrderAlreadyUploaded, другим — ErrOrderAlreadyUploadedByAnother.
+func (repository *OrdersRepository) Create(ctx context.Context, userID int64, number string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if existing, ok := store.orders[number]; ok {
+		if existing.UserID == userID {
+			return ordersmodel.ErrOrderAlreadyUploaded
+		}
+		return ordersmodel.ErrOrderAlreadyUploadedByAnother
+	}
+	store.lastOrderNo++
+	uploadedAt := store.now()
+	store.orders[number] = &order{
+		Order: ordersmodel.Order{
+			Number:     number,
+			UserID:     userID,
+			Status:     ordersmodel.StatusNew,
+			UploadedAt: uploadedAt,
+		},
+		seq: store.lastOrderNo,
+		history: []ordersmodel.StatusChange{{
+			Number:    number,
+			To:        ordersmodel.StatusNew,
+			Source:    ordersmodel.SourceUser,
+			ChangedAt: uploadedAt,
+		}},
+	}
+	return nil
+}
+
+// Get возвращает заказ по номеру.
+func (repository *OrdersRepository) Get(ctx context.Context, number string) (ordersmodel.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return ordersmodel.Order{}, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	existing, ok := store.orders[number]
+	if !ok {
+		return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+	}
+	return cloneOrder(existing.Order), nil
+}
+
+// ListByUser возвращает заказы пользователя по времени загрузки (от новых к старым).
+func (repository *OrdersRepository) ListByUser(ctx context.Context, userID int64) ([]ordersmodel.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	selected := store.selectOrdersLocked(func(o *order) bool { return o.UserID == userID })
+	slices.Reverse(selected)
+	return selected, nil
+}
+
+// ListPending возвращает заказы в статусах NEW/PROCESSING (от старых к новым).
+func (repository *OrdersRepository) ListPending(ctx context.Context) ([]ordersmodel.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	selected := store.selectOrdersLocked(func(o *order) bool {
+		return o.Status == ordersmodel.StatusNew || o.Status == ordersmodel.StatusProcessing
+	})
+	for i := range selected {
+		selected[i].Accrual = nil
+	}
+	return selected, nil
+}
+
+// ListHistory возвращает историю статусов заказа в порядке переходов.
+func (repository *OrdersRepository) ListHistory(ctx context.Context, number string) ([]ordersmodel.StatusChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	existing, ok := store.orders[number]
+	if !ok {
+		return nil, nil
+	}
+	out := make([]ordersmodel.StatusChange, 0, len(existing.history))
+	for _, change := range existing.history {
+		change.Accrual = cloneDecimal(change.Accrual)
+		change.Response = slices.Clone(change.Response)
+		out = append(out, change)
+	}
+	return out, nil
+}
+
+// Requeue переводит заказ в статус NEW, если начисление по нему ещё не зачислено.
+func (repository *OrdersRepository) Requeue(ctx context.Context, number string) (ordersmodel.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return ordersmodel.Order{}, err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	existing, ok := store.orders[number]
+	if !ok {
+		return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+	}
+	if existing.accrualApplied {
+		return ordersmodel.Order{}, ordersmodel.ErrOrderAlreadyCredited
+	}
+	if !existing.Status.CanTransitionTo(ordersmodel.StatusNew) {
+		return ordersmodel.Order{}, fmt.Errorf("%w: %s -> %s", ordersmodel.ErrIllegalTransition, existing.Status, ordersmodel.StatusNew)
+	}
+	store.recordStatusChangeLocked(existing, ordersmodel.StatusUpdate{
+		Status:  ordersmodel.StatusNew,
+		Accrual: existing.Accrual,
+		Source:  ordersmodel.SourceAdmin,
+	})
+	existing.Status = ordersmodel.StatusNew
+	return cloneOrder(existing.Order), nil
+}
+
+// UpdateFromAccrual обновляет заказ и идемпотентно зачисляет начисление и бонусы по акциям.
+// Уровни пользователей и реферальные связи в памяти не хранятся, поэтому начисление
+// зачисляется с множителем 1, а вознаграждение пригласившему не применяется.
+func (repository *OrdersRepository) UpdateFromAccrual(
+	ctx context.Context,
+	number string,
+	update ordersmodel.StatusUpdate,
+	rewards ordersmodel.Rewards,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	store := repository.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	existing, ok := store.orders[number]
+	if !ok {
+		return nil
+	}
+	status, accrual := update.Status, update.Accrual
+	if existing.Status == ordersmodel.StatusProcessed && existing.accrualApplied && status == ordersmodel.StatusProcessed {
+		if !ordersmodel.SameAccrual(existing.Accrual, accrual) {
+			return fmt.Errorf("%w: order %s", ordersmodel.ErrAccrualConflict, number)
+		}
+		return nil
+	}
+	if !existing.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s -> %s", ordersmodel.ErrIllegalTransition, existing.Status, status)
+	}
+
+	shouldApplyAccrual := status == ordersmodel.StatusProcessed && !existing.accrualApplied
+	store.recordStatusChangeLocked(existing, update)
+	existing.Status = status
+	existing.Accrual = cloneDecimal(accrual)
+	if shouldApplyAccrual {
+		existing.accrualApplied = true
+		existing.processedAt = store.now()
+	}
+
+	if shouldApplyAccrual && accrual != nil && accrual.GreaterThan(decimal.Zero) {
+		acc := store.accountLocked(existing.UserID)
+		acc.current = acc.current.Add(accrual.Round(4))
+		for _, bonus := range rewards.Promotions {
+			if bonus.Amount.LessThanOrEqual(decimal.Zero) {
+				continue
+			}
+			key := bonusKey{orderNumber: number, ruleID: bonus.RuleID}
+			if _, applied := store.bonuses[key]; applied {
+				continue
+			}
+			store.bonuses[key] = struct{}{}
+			acc.current = acc.current.Add(bonus.Amount)
+		}
+	}
+	return nil
+}
+
+// recordStatusChangeLocked добавляет переход заказа в историю; повтор того же статуса не записывается.
+// Вызывается под store.mu до смены статуса.
+func (store *Store) recordStatusChangeLocked(o *order, update ordersmodel.StatusUpdate) {
+	if o.Status == update.Status {
+		return
+	}
+	o.history = append(o.history, ordersmodel.StatusChange{
+		Number:    o.Number,
+		From:      o.Status,
+		To:        update.Status,
+		Accrual:   cloneDecimal(update.Accrual),
+		Source:    update.Source,
+		Response:  slices.Clone(update.Response),
+		ChangedAt: store.now(),
+	})
+}
+
+// selectOrdersLocked возвращает копии подходящих заказов в порядке загрузки. Вызывается под store.mu.
+func (store *Store) selectOrdersLocked(match func(o *order) bool) []ordersmodel.Order {
+	var matched []*order
+	for _, o := range store.orders {
+		if match(o) {
+			matched = append(matched, o)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *order) int {
+		if c := a.UploadedAt.Compare(b.UploadedAt); c != 0 {
+			return c
+		}
+		return int(a.seq - b.seq)
+	})
+
+	var out []ordersmodel.Order
+	for _, o := range matched {
+		out = append(out, cloneOrder(o.Order))
+	}
+	return out
+}
+
+func cloneOrder(o ordersmodel.Order) ordersmodel.Order {
+	o.Accrual = cloneDecimal(o.Accrual)
+	return o
+}
+
+func cloneDecimal(value *decimal.Decimal) *decimal.Decimal {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+var _ ordersrepo.OrdersRepository = (*OrdersRepository)(nil)
@@ -0,0 +1,82 @@
+// Package memory — хранилище данных в памяти процесса (STORAGE=memory) для тестов и демо.
+// Репозитории повторяют семантику PostgreSQL-реализаций: конфликты при загрузке заказов,
+// идемпотентное зачисление начислений и атомарные списания. Данные теряются при перезапуске.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	authmodel "loyalty/internal/domain/auth/model"
+	ordersmodel "loyalty/internal/domain/order/model"
+	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// account — накопительный счёт пользователя.
+type account struct {
+	current   decimal.Decimal
+	withdrawn decimal.Decimal
+}
+
+// order — заказ с полями, не попадающими в доменную модель.
+type order struct {
+	ordersmodel.Order
+	// seq — порядок загрузки; разрешает равенство uploaded_at при сортировке.
+	seq            int64
+	accrualApplied bool
+	processedAt    time.Time
+	// history — история статусов заказа (от старых записей к новым).
+	history []ordersmodel.StatusChange
+}
+
+// bonusKey — ключ идемпотентности бонуса по акции (как UNIQUE (order_number, rule_id)).
+type bonusKey struct {
+	orderNumber string
+	ruleID      int64
+}
+
+// Store — общее состояние всех репозиториев. Одна блокировка на всё хранилище делает каждую
+// операцию атомарной, как транзакция в PostgreSQL.
+type Store struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	lastUserID  int64
+	lastOrderNo int64
+
+	users        map[int64]authmodel.User
+	userByLogin  map[string]int64
+	accounts     map[int64]*account
+	orders       map[string]*order
+	withdrawals  []withdrawalsmodel.Withdrawal
+	withdrawnFor map[string]struct{}
+	bonuses      map[bonusKey]struct{}
+}
+
+// NewStore создаёт пустое хранилище.
+func NewStore() *Store {
+	return &Store{
+		now:          time.Now,
+		users:        make(map[int64]authmodel.User),
+		userByLogin:  make(map[string]int64),
+		accounts:     make(map[int64]*account),
+		orders:       make(map[string]*order),
+		withdrawnFor: make(map[string]struct{}),
+		bonuses:      make(map[bonusKey]struct{}),
+	}
+}
+
+// Close ничего не освобождает; нужен, чтобы хранилище закрывалось так же, как пул соединений с БД.
+func (store *Store) Close() error { return nil }
+
+// accountLocked возвращает счёт пользователя, создавая его при необходимости. Вызывается под store.mu.
+func (store *Store) accountLocked(userID int64) *account {
+	acc, ok := store.accounts[userID]
+	if !ok {
+		acc = &account{}
+		store.accounts[userID] = acc
+	}
+	return acc
+}
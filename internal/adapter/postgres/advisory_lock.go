@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// LeaderLockKey — ключ advisory lock, которым реплики сервиса выбирают лидера для задач-синглтонов.
+const LeaderLockKey int64 = 0x6c6f79616c7479 // "loyalty"
+
+// errLockNotHeld возвращается Check, если блокировка не взята.
+var errLockNotHeld = errors.New("advisory lock is not held")
+
+// AdvisoryLock — сессионная advisory lock PostgreSQL на выделенном соединении (реализует leader.Lock).
+// Блокировка живёт, пока живо соединение: при его потере (в том числе при аварийном завершении процесса)
+// PostgreSQL освобождает её сам.
+type AdvisoryLock struct {
+	dsn string
+	key int64
+
+	mu   sync.Mutex
+	conn *pgx.Conn
+}
+
+// NewAdvisoryLock создаёт блокировку с ключом key в базе dsn; соединение открывается при первой попытке.
+func NewAdvisoryLock(dsn string, key int64) *AdvisoryLock {
+	return &AdvisoryLock{dsn: dsn, key: key}
+}
+
+// TryAcquire выполняет pg_try_advisory_lock на выделенном соединении, при необходимости открывая его.
+func (lock *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	if lock.conn == nil {
+		connectCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+		conn, err := pgx.Connect(connectCtx, lock.dsn)
+		cancel()
+		if err != nil {
+			return false, fmt.Errorf("connect: %w", err)
+		}
+		lock.conn = conn
+	}
+
+	var held bool
+	if err := lock.conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, lock.key).Scan(&held); err != nil {
+		lock.closeLocked(ctx)
+		return false, fmt.Errorf("try advisory lock: %w", err)
+	}
+	if !held {
+		// Соединение без блокировки не нужно: следующая попытка откроет новое.
+		lock.closeLocked(ctx)
+	}
+	return held, nil
+}
+
+// Check проверяет, что соединение, удерживающее блокировку, живо; при ошибке (в том числе по дедлайну ctx)
+// соединение закрывается, и вместе с сессией PostgreSQL освобождает блокировку.
+func (lock *AdvisoryLock) Check(ctx context.Context) error {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	if lock.conn == nil {
+		return errLockNotHeld
+	}
+	if err := lock.conn.Ping(ctx); err != nil {
+		lock.closeLocked(ctx)
+		return fmt.Errorf("ping lock connection: %w", err)
+	}
+	return nil
+}
+
+// Release освобождает блокировку и закрывает соединение.
+func (lock *AdvisoryLock) Release(ctx context.Context) error {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	if lock.conn == nil {
+		return nil
+	}
+	defer lock.closeLocked(ctx)
+	if _, err := lock.conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, lock.key); err != nil {
+		return fmt.Errorf("advisory unlock: %w", err)
+	}
+	return nil
+}
+
+func (lock *AdvisoryLock) closeLocked(ctx context.Context) {
+	closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), connectTimeout)
+	defer cancel()
+	_ = lock.conn.Close(closeCtx)
+	lock.conn = nil
+}
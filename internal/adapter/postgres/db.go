@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"time"
 
-	// Регистрируем драйвер pgx для database/sql.
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/rs/zerolog/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/rs/zerolog"
 )
 
 const (
@@ -35,14 +35,17 @@ func DefaultPoolConfig() PoolConfig {
 }
 
 // Open открывает соединение с PostgreSQL по DSN, применяет настройки pool и проверяет доступность.
+// Каждый SQL-запрос трассируется отдельным спаном (см. queryTracer).
 func Open(ctx context.Context, dsn string, poolCfg PoolConfig) (*sql.DB, error) {
 	if dsn == "" {
 		return nil, errors.New("DATABASE_URI is empty")
 	}
-	db, err := sql.Open("pgx", dsn)
+	connConfig, err := pgx.ParseConfig(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("open db: %w", err)
+		return nil, fmt.Errorf("parse dsn: %w", err)
 	}
+	connConfig.Tracer = queryTracer{}
+	db := stdlib.OpenDB(*connConfig)
 
 	db.SetMaxOpenConns(poolCfg.MaxOpenConns)
 	db.SetMaxIdleConns(poolCfg.MaxIdleConns)
@@ -55,7 +58,7 @@ func Open(ctx context.Context, dsn string, poolCfg PoolConfig) (*sql.DB, error)
 		_ = db.Close()
 		return nil, fmt.Errorf("ping db: %w", err)
 	}
-	log.Info().
+	zerolog.Ctx(ctx).Info().
 		Int("max_open_conns", poolCfg.MaxOpenConns).
 		Int("max_idle_conns", poolCfg.MaxIdleConns).
 		Dur("conn_max_lifetime", poolCfg.ConnMaxLifetime).
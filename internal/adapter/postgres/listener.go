@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// OrdersNewChannel — канал NOTIFY, в который триггер orders_new_notify пишет номер
+// нового (или возвращённого в очередь) заказа.
+const OrdersNewChannel = "orders_new"
+
+const (
+	listenerMinBackoff = time.Second
+	listenerMaxBackoff = 30 * time.Second
+)
+
+// Listener держит выделенное соединение pgx с LISTEN на канале PostgreSQL.
+// Соединение не берётся из пула sql.DB: оно занято ожиданием уведомлений всё время работы.
+type Listener struct {
+	dsn        string
+	channel    string
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// NewListener создаёт слушателя канала channel для базы dsn.
+func NewListener(dsn, channel string) *Listener {
+	return &Listener{
+		dsn:        dsn,
+		channel:    channel,
+		minBackoff: listenerMinBackoff,
+		maxBackoff: listenerMaxBackoff,
+	}
+}
+
+// Listen блокируется до отмены ctx и вызывает notify на каждое уведомление канала.
+// После потери соединения переподключается с экспоненциальной паузой (1s..30s); после каждого
+// подключения вызывает notify, чтобы подписчик подхватил события, пропущенные без соединения.
+func (listener *Listener) Listen(ctx context.Context, notify func()) {
+	log := zerolog.Ctx(ctx).With().Str("channel", listener.channel).Logger()
+	backoff := listener.minBackoff
+	for {
+		connected, err := listener.listen(ctx, notify)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = listener.minBackoff
+		}
+		log.Warn().Err(err).Dur("retry_in", backoff).Msg("database listener disconnected")
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, listener.maxBackoff)
+	}
+}
+
+// listen подключается, подписывается на канал и ждёт уведомлений до ошибки соединения или отмены ctx.
+// connected сообщает, была ли подписка успешно оформлена.
+func (listener *Listener) listen(ctx context.Context, notify func()) (connected bool, err error) {
+	connectCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	conn, err := pgx.Connect(connectCtx, listener.dsn)
+	cancel()
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), connectTimeout)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{listener.channel}.Sanitize()); err != nil {
+		return false, fmt.Errorf("listen: %w", err)
+	}
+	zerolog.Ctx(ctx).Info().Str("channel", listener.channel).Msg("database listener connected")
+	notify()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return true, ctx.Err()
+			}
+			return true, fmt.Errorf("wait for notification: %w", err)
+		}
+		notify()
+	}
+}
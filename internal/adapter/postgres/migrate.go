@@ -1,10 +1,12 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
 
 	"github.com/golang-migrate/migrate/v4"
 	migratepg "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -14,8 +16,44 @@ import (
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
-// ApplyMigrations применяет миграции к базе данных.
+// ApplyMigrations применяет все ещё не применённые миграции к базе данных.
+// Миграции закрывают db по завершении — передавайте отдельное подключение.
 func ApplyMigrations(db *sql.DB) error {
+	return withMigrator(db, func(migrations *migrate.Migrate) error {
+		if err := migrations.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("migrate up: %w", err)
+		}
+		return nil
+	})
+}
+
+// RollbackMigrations откатывает steps последних применённых миграций.
+// Миграции закрывают db по завершении — передавайте отдельное подключение.
+func RollbackMigrations(db *sql.DB, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("migrate down: steps must be positive, got %d", steps)
+	}
+	return withMigrator(db, func(migrations *migrate.Migrate) error {
+		if err := migrations.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("migrate down: %w", err)
+		}
+		return nil
+	})
+}
+
+// ForceMigrationVersion записывает версию схемы и снимает флаг dirty без выполнения миграций
+// (после ручного исправления упавшей миграции). Версия -1 означает «миграции не применялись».
+// Миграции закрывают db по завершении — передавайте отдельное подключение.
+func ForceMigrationVersion(db *sql.DB, version int) error {
+	return withMigrator(db, func(migrations *migrate.Migrate) error {
+		if err := migrations.Force(version); err != nil {
+			return fmt.Errorf("migrate force: %w", err)
+		}
+		return nil
+	})
+}
+
+func withMigrator(db *sql.DB, run func(migrations *migrate.Migrate) error) error {
 	src, err := iofs.New(migrationsFS, "migrations")
 	if err != nil {
 		return fmt.Errorf("migrations source: %w", err)
@@ -34,11 +72,44 @@ func ApplyMigrations(db *sql.DB) error {
 		_, _ = migrations.Close()
 	}()
 
-	if err := migrations.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			return nil
+	return run(migrations)
+}
+
+// LatestMigrationVersion возвращает версию последней встроенной миграции.
+func LatestMigrationVersion() (uint, error) {
+	src, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return 0, fmt.Errorf("migrations source: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("first migration: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("next migration: %w", err)
+		}
+		version = next
+	}
+}
+
+// MigrationVersion возвращает текущую версию схемы из таблицы golang-migrate и флаг незавершённой миграции.
+func MigrationVersion(ctx context.Context, db *sql.DB) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	if err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
 		}
-		return fmt.Errorf("migrate up: %w", err)
+		return 0, false, fmt.Errorf("select schema version: %w", err)
 	}
-	return nil
+	return uint(version), dirty, nil
 }
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_tiers;
+
+DROP INDEX IF EXISTS idx_orders_user_processed_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS credited;
+ALTER TABLE orders DROP COLUMN IF EXISTS processed_at;
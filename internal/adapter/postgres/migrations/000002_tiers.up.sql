@@ -0,0 +1,12 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS processed_at TIMESTAMPTZ;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS credited NUMERIC(20,4);
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_processed_at ON orders(user_id, processed_at DESC);
+
+CREATE TABLE IF NOT EXISTS user_tiers (
+  user_id         BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  tier            TEXT NOT NULL,
+  multiplier      NUMERIC(10,4) NOT NULL DEFAULT 1,
+  rolling_accrual NUMERIC(20,4) NOT NULL DEFAULT 0,
+  recalculated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS promotion_bonuses;
+DROP TABLE IF EXISTS promotion_rules;
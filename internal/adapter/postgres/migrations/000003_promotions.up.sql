@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS promotion_rules (
+  id           BIGSERIAL PRIMARY KEY,
+  name         TEXT NOT NULL,
+  active       BOOLEAN NOT NULL DEFAULT TRUE,
+  valid_from   TIMESTAMPTZ,
+  valid_to     TIMESTAMPTZ,
+  conditions   JSONB NOT NULL DEFAULT '{}',
+  effect_type  TEXT NOT NULL,
+  effect_value NUMERIC(20,4) NOT NULL,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at   TIMESTAMPTZ
+);
+
+-- Правила акций удаляются мягко (deleted_at): бонус всегда ссылается на своё правило, и UNIQUE
+-- (order_number, rule_id) не теряет силу из-за NULL в rule_id после удаления правила.
+CREATE TABLE IF NOT EXISTS promotion_bonuses (
+  id           BIGSERIAL PRIMARY KEY,
+  rule_id      BIGINT NOT NULL REFERENCES promotion_rules(id) ON DELETE RESTRICT,
+  rule_name    TEXT NOT NULL,
+  user_id      BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  order_number TEXT NOT NULL REFERENCES orders(number) ON DELETE CASCADE,
+  amount       NUMERIC(20,4) NOT NULL,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (order_number, rule_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_bonuses_user_created_at ON promotion_bonuses(user_id, created_at DESC);
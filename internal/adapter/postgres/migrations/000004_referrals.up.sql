@@ -0,0 +1,25 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS referral_code TEXT NOT NULL
+  DEFAULT upper(substr(md5(random()::text || clock_timestamp()::text), 1, 10));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_referral_code ON users(referral_code);
+
+CREATE TABLE IF NOT EXISTS referrals (
+  referee_id  BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  referrer_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (referrer_id <> referee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer_created_at ON referrals(referrer_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS referral_bonuses (
+  id           BIGSERIAL PRIMARY KEY,
+  referrer_id  BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  referee_id   BIGINT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+  order_number TEXT NOT NULL REFERENCES orders(number) ON DELETE CASCADE,
+  amount       NUMERIC(20,4) NOT NULL,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_referral_bonuses_referrer_created_at ON referral_bonuses(referrer_id, created_at DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS transfers (
+  id           BIGSERIAL PRIMARY KEY,
+  sender_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  recipient_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  sum          NUMERIC(20,4) NOT NULL CHECK (sum > 0),
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (sender_id <> recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfers_sender_created_at ON transfers(sender_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transfers_recipient_created_at ON transfers(recipient_id, created_at DESC);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+  id         BIGSERIAL PRIMARY KEY,
+  user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  amount     NUMERIC(20,4) NOT NULL CHECK (amount <> 0),
+  reason     TEXT NOT NULL CHECK (btrim(reason) <> ''),
+  actor      TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_user_created_at ON balance_adjustments(user_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS orders_new_notify ON orders;
+DROP FUNCTION IF EXISTS notify_orders_new();
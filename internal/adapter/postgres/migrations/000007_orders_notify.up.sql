@@ -0,0 +1,13 @@
+CREATE OR REPLACE FUNCTION notify_orders_new() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('orders_new', NEW.number);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS orders_new_notify ON orders;
+CREATE TRIGGER orders_new_notify
+  AFTER INSERT OR UPDATE OF status ON orders
+  FOR EACH ROW
+  WHEN (NEW.status = 'NEW')
+  EXECUTE FUNCTION notify_orders_new();
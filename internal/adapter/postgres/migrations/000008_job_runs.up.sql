@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+  id          BIGSERIAL PRIMARY KEY,
+  job         TEXT NOT NULL,
+  status      TEXT NOT NULL,
+  error       TEXT NOT NULL DEFAULT '',
+  started_at  TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_started_at ON job_runs(job, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(started_at DESC);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS order_status_history (
+  id           BIGSERIAL PRIMARY KEY,
+  order_number TEXT NOT NULL REFERENCES orders(number) ON DELETE CASCADE,
+  from_status  TEXT,
+  to_status    TEXT NOT NULL,
+  accrual      NUMERIC(20,4),
+  source       TEXT NOT NULL,
+  response     JSONB,
+  changed_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_changed_at ON order_status_history(order_number, changed_at, id);
+
+-- Заказы, загруженные до появления истории: загрузка и (для обработанных) последний известный статус.
+INSERT INTO order_status_history(order_number, from_status, to_status, source, changed_at)
+SELECT number, NULL, 'NEW', 'user', uploaded_at FROM orders;
+
+INSERT INTO order_status_history(order_number, from_status, to_status, accrual, source, changed_at)
+SELECT number, 'NEW', status, accrual, 'worker', COALESCE(processed_at, uploaded_at)
+  FROM orders
+ WHERE status <> 'NEW';
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+
+	adjustmentmodel "loyalty/internal/domain/adjustment/model"
+	adjustmentrepo "loyalty/internal/domain/adjustment/repository"
+	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// LoyaltyAdjustmentRepository — PostgreSQL-реализация adjustmentrepo.AdjustmentRepository.
+type LoyaltyAdjustmentRepository struct {
+	db *sql.DB
+}
+
+// NewLoyaltyAdjustmentRepository создаёт репозиторий ручных корректировок на PostgreSQL.
+func NewLoyaltyAdjustmentRepository(db *sql.DB) *LoyaltyAdjustmentRepository {
+	return &LoyaltyAdjustmentRepository{db: db}
+}
+
+// Apply блокирует счёт пользователя, проверяет, что баланс не уходит в минус,
+// и в одной транзакции меняет баланс и записывает корректировку.
+func (repository *LoyaltyAdjustmentRepository) Apply(
+	ctx context.Context,
+	adjustment adjustmentmodel.Adjustment,
+) (adjustmentmodel.Adjustment, error) {
+	transaction, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return adjustmentmodel.Adjustment{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = transaction.Rollback() }()
+
+	current, err := repository.lockAccount(ctx, transaction, adjustment.UserID)
+	if err != nil {
+		return adjustmentmodel.Adjustment{}, err
+	}
+	if current.Add(adjustment.Amount).IsNegative() {
+		return adjustmentmodel.Adjustment{}, withdrawalsmodel.ErrInsufficientFunds
+	}
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	if err := transaction.QueryRowContext(
+		queryCtx,
+		`WITH adjusted AS (
+		   UPDATE accounts SET current = current + $2 WHERE user_id = $1
+		 )
+		 INSERT INTO balance_adjustments(user_id, amount, reason, actor, created_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id`,
+		adjustment.UserID,
+		adjustment.Amount,
+		adjustment.Reason,
+		adjustment.Actor,
+		adjustment.CreatedAt,
+	).Scan(&adjustment.ID); err != nil {
+		return adjustmentmodel.Adjustment{}, fmt.Errorf("apply adjustment: %w", err)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return adjustmentmodel.Adjustment{}, fmt.Errorf("commit: %w", err)
+	}
+	return adjustment, nil
+}
+
+// lockAccount создаёт счёт при необходимости, блокирует его и возвращает текущий баланс.
+func (repository *LoyaltyAdjustmentRepository) lockAccount(
+	ctx context.Context,
+	transaction *sql.Tx,
+	userID int64,
+) (decimal.Decimal, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	if _, err := transaction.ExecContext(
+		queryCtx,
+		`INSERT INTO accounts(user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`,
+		userID,
+	); err != nil {
+		return decimal.Zero, fmt.Errorf("init account: %w", err)
+	}
+
+	var current decimal.Decimal
+	if err := transaction.QueryRowContext(
+		queryCtx,
+		`SELECT current FROM accounts WHERE user_id = $1 FOR UPDATE`,
+		userID,
+	).Scan(&current); err != nil {
+		return decimal.Zero, fmt.Errorf("lock account: %w", err)
+	}
+	return current, nil
+}
+
+var _ adjustmentrepo.AdjustmentRepository = (*LoyaltyAdjustmentRepository)(nil)
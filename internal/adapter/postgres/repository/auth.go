@@ -23,18 +23,49 @@ func NewAuthUserRepository(db *sql.DB) *AuthUserRepository {
 	return &AuthUserRepository{db: db}
 }
 
-// Create создаёт пользователя и инициализирует его накопительный счёт.
-func (repository *AuthUserRepository) Create(ctx context.Context, login string, passwordHash []byte) (authmodel.User, error) {
+// Ограничения уникальности users, нарушения которых при регистрации различаются.
+const (
+	usersLoginConstraint        = "users_login_key"
+	usersReferralCodeConstraint = "idx_users_referral_code"
+)
+
+// createUserAttempts — сколько раз регистрация повторяется при совпадении случайного реферального кода.
+const createUserAttempts = 3
+
+// Create создаёт пользователя, инициализирует его накопительный счёт и, если referrerID задан,
+// сохраняет приглашение — одним запросом, чтобы пользователь не остался без приглашения.
+// Занятый логин — authmodel.ErrLoginTaken; при совпадении сгенерированного реферального кода
+// запрос повторяется с новым кодом.
+func (repository *AuthUserRepository) Create(ctx context.Context, login string, passwordHash []byte, referrerID int64) (authmodel.User, error) {
+	for attempt := 1; ; attempt++ {
+		id, err := repository.create(ctx, login, passwordHash, referrerID)
+		switch {
+		case err == nil:
+			return authmodel.User{ID: id, Login: login, PasswordHash: passwordHash}, nil
+		case isUniqueViolation(err, usersLoginConstraint):
+			return authmodel.User{}, authmodel.ErrLoginTaken
+		case isUniqueViolation(err, usersReferralCodeConstraint) && attempt < createUserAttempts:
+			continue
+		default:
+			return authmodel.User{}, fmt.Errorf("create user: %w", err)
+		}
+	}
+}
+
+func (repository *AuthUserRepository) create(ctx context.Context, login string, passwordHash []byte, referrerID int64) (int64, error) {
 	var id int64
 	queryCtx, cancel := util.WithQueryTimeout(ctx)
 	defer cancel()
 
-	if err := repository.db.QueryRowContext(
+	err := repository.db.QueryRowContext(
 		queryCtx,
 		`WITH created AS (
 		   INSERT INTO users(login, password_hash)
 		   VALUES ($1, $2)
 		   RETURNING id
+		 ), referral AS (
+		   INSERT INTO referrals(referrer_id, referee_id)
+		   SELECT $3::bigint, id FROM created WHERE $3::bigint <> 0
 		 )
 		 INSERT INTO accounts(user_id)
 		 SELECT id FROM created
@@ -42,13 +73,9 @@ func (repository *AuthUserRepository) Create(ctx context.Context, login string,
 		 RETURNING user_id`,
 		login,
 		passwordHash,
-	).Scan(&id); err != nil {
-		if isUniqueViolation(err) {
-			return authmodel.User{}, authmodel.ErrLoginTaken
-		}
-		return authmodel.User{}, fmt.Errorf("create user: %w", err)
-	}
-	return authmodel.User{ID: id, Login: login, PasswordHash: passwordHash}, nil
+		referrerID,
+	).Scan(&id)
+	return id, err
 }
 
 // FindByLogin возвращает пользователя по логину или authmodel.ErrNotFound.
@@ -72,10 +99,30 @@ func (repository *AuthUserRepository) FindByLogin(ctx context.Context, login str
 	return user, nil
 }
 
-func isUniqueViolation(err error) bool {
+// FindByID возвращает пользователя по идентификатору или authmodel.ErrNotFound.
+func (repository *AuthUserRepository) FindByID(ctx context.Context, id int64) (authmodel.User, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var user authmodel.User
+	if err := repository.db.QueryRowContext(
+		queryCtx,
+		`SELECT id, login, password_hash FROM users WHERE id = $1`,
+		id,
+	).Scan(&user.ID, &user.Login, &user.PasswordHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return authmodel.User{}, authmodel.ErrNotFound
+		}
+		return authmodel.User{}, fmt.Errorf("select user: %w", err)
+	}
+	return user, nil
+}
+
+// isUniqueViolation сообщает, нарушено ли ограничение уникальности constraint.
+func isUniqueViolation(err error, constraint string) bool {
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
-		return pgErr.Code == "23505"
+		return pgErr.Code == "23505" && pgErr.ConstraintName == constraint
 	}
 	return false
 }
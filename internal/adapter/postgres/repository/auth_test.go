@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	authmodel "loyalty/internal/domain/auth/model"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsUniqueViolation_MatchesConstraint(t *testing.T) {
+	login := fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "23505", ConstraintName: usersLoginConstraint})
+	referralCode := &pgconn.PgError{Code: "23505", ConstraintName: usersReferralCodeConstraint}
+
+	if !isUniqueViolation(login, usersLoginConstraint) {
+		t.Fatal("login violation must match the login constraint")
+	}
+	if isUniqueViolation(referralCode, usersLoginConstraint) {
+		t.Fatal("referral code violation must not be reported as a taken login")
+	}
+	if !isUniqueViolation(referralCode, usersReferralCodeConstraint) {
+		t.Fatal("referral code violation must match the referral code constraint")
+	}
+	if isUniqueViolation(&pgconn.PgError{Code: "23503", ConstraintName: usersLoginConstraint}, usersLoginConstraint) {
+		t.Fatal("only unique violations must match")
+	}
+	if isUniqueViolation(errors.New("boom"), usersLoginConstraint) {
+		t.Fatal("non-PostgreSQL errors must not match")
+	}
+}
+
+func TestAuthUserRepository_Create_ReferralCodeCollisionIsNotLoginTaken(t *testing.T) {
+	db := openTestDB(t)
+	truncateTestDB(t, db)
+	ctx := context.Background()
+
+	// Фиксированный код по умолчанию гарантирует совпадение кодов у второго пользователя.
+	if _, err := db.ExecContext(ctx, `ALTER TABLE users ALTER COLUMN referral_code SET DEFAULT 'COLLISION'`); err != nil {
+		t.Fatalf("fix referral code default: %v", err)
+	}
+	restoreDefault := func() {
+		_, _ = db.ExecContext(context.Background(),
+			`ALTER TABLE users ALTER COLUMN referral_code SET DEFAULT upper(substr(md5(random()::text || clock_timestamp()::text), 1, 10))`)
+	}
+	t.Cleanup(restoreDefault)
+
+	users := NewAuthUserRepository(db)
+	if _, err := users.Create(ctx, "alice", []byte("hash"), 0); err != nil {
+		t.Fatalf("create alice: %v", err)
+	}
+	_, err := users.Create(ctx, "bob", []byte("hash"), 0)
+	if err == nil || errors.Is(err, authmodel.ErrLoginTaken) {
+		t.Fatalf("want internal error for a referral code collision, got %v", err)
+	}
+
+	restoreDefault()
+	if _, err := users.Create(ctx, "alice", []byte("hash"), 0); !errors.Is(err, authmodel.ErrLoginTaken) {
+		t.Fatalf("want ErrLoginTaken for a taken login, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"loyalty/internal/adapter/contracttest"
+	"loyalty/internal/adapter/postgres"
+)
+
+// testDatabaseEnv — DSN тестовой базы; без неё контрактные тесты PostgreSQL пропускаются.
+// База очищается перед каждым подтестом, поэтому указывать рабочую базу нельзя.
+const testDatabaseEnv = "TEST_DATABASE_URI"
+
+// openTestDB открывает тестовую базу с миграциями или пропускает тест, если база не задана.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv(testDatabaseEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+	db, err := postgres.OpenWithMigrations(context.Background(), dsn, postgres.DefaultPoolConfig())
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// truncateTestDB очищает тестовую базу перед подтестом.
+func truncateTestDB(t *testing.T, db *sql.DB) {
+	t.Helper()
+	if _, err := db.ExecContext(context.Background(), `TRUNCATE users RESTART IDENTITY CASCADE`); err != nil {
+		t.Fatalf("truncate test database: %v", err)
+	}
+}
+
+func TestContract(t *testing.T) {
+	db := openTestDB(t)
+
+	contracttest.Run(t, func(t *testing.T) contracttest.Backend {
+		truncateTestDB(t, db)
+		accounts := NewLoyaltyAccountRepository(db)
+		return contracttest.Backend{
+			Users:       NewAuthUserRepository(db),
+			Orders:      NewLoyaltyOrdersRepository(db, nil, 0),
+			Balance:     accounts,
+			Accounts:    accounts,
+			Withdrawals: NewLoyaltyWithdrawalsRepository(db),
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+	"time"
+
+	jobrunmodel "loyalty/internal/domain/jobrun/model"
+	jobrunrepo "loyalty/internal/domain/jobrun/repository"
+)
+
+// SchedulerJobRunRepository — PostgreSQL-реализация jobrunrepo.JobRunRepository.
+type SchedulerJobRunRepository struct {
+	db *sql.DB
+}
+
+// NewSchedulerJobRunRepository создаёт репозиторий журнала запусков фоновых задач на PostgreSQL.
+func NewSchedulerJobRunRepository(db *sql.DB) *SchedulerJobRunRepository {
+	return &SchedulerJobRunRepository{db: db}
+}
+
+// Start записывает начало запуска задачи.
+func (repository *SchedulerJobRunRepository) Start(ctx context.Context, job string, startedAt time.Time) (int64, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	if err := repository.db.QueryRowContext(
+		queryCtx,
+		`INSERT INTO job_runs(job, status, started_at) VALUES ($1, $2, $3) RETURNING id`,
+		job,
+		jobrunmodel.StatusRunning,
+		startedAt,
+	).Scan(&id); err != nil {
+		return 0, fmt.Errorf("insert job run: %w", err)
+	}
+	return id, nil
+}
+
+// Finish записывает итог запуска.
+func (repository *SchedulerJobRunRepository) Finish(
+	ctx context.Context,
+	id int64,
+	finishedAt time.Time,
+	status jobrunmodel.Status,
+	errText string,
+) error {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	if _, err := repository.db.ExecContext(
+		queryCtx,
+		`UPDATE job_runs SET status = $2, error = $3, finished_at = $4 WHERE id = $1`,
+		id,
+		status,
+		errText,
+		finishedAt,
+	); err != nil {
+		return fmt.Errorf("update job run: %w", err)
+	}
+	return nil
+}
+
+// List возвращает последние запуски (новые первыми), при непустом filter.Job — только этой задачи.
+func (repository *SchedulerJobRunRepository) List(ctx context.Context, filter jobrunmodel.Filter) ([]jobrunmodel.Run, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := repository.db.QueryContext(
+		queryCtx,
+		`SELECT id, job, status, error, started_at, finished_at
+		   FROM job_runs
+		  WHERE $1 = '' OR job = $1
+		  ORDER BY started_at DESC, id DESC
+		  LIMIT $2`,
+		filter.Job,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select job runs: %w", err)
+	}
+	defer rows.Close()
+
+	var out []jobrunmodel.Run
+	for rows.Next() {
+		var (
+			run        jobrunmodel.Run
+			finishedAt sql.NullTime
+		)
+		if err := rows.Scan(&run.ID, &run.Job, &run.Status, &run.Error, &run.StartedAt, &finishedAt); err != nil {
+			return nil, fmt.Errorf("scan job run: %w", err)
+		}
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Time
+		}
+		out = append(out, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate job runs: %w", err)
+	}
+	return out, nil
+}
+
+// DeleteBefore удаляет запуски, начатые раньше before.
+func (repository *SchedulerJobRunRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := repository.db.ExecContext(queryCtx, `DELETE FROM job_runs WHERE started_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete job runs: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete job runs: %w", err)
+	}
+	return deleted, nil
+}
+
+var _ jobrunrepo.JobRunRepository = (*SchedulerJobRunRepository)(nil)
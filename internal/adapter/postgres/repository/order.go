@@ -10,22 +10,32 @@ import (
 
 	ordersmodel "loyalty/internal/domain/order/model"
 	ordersrepo "loyalty/internal/domain/order/repository"
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	referralmodel "loyalty/internal/domain/referral/model"
+	tiermodel "loyalty/internal/domain/tier/model"
+	"loyalty/internal/tracing"
 
 	"github.com/shopspring/decimal"
 )
 
 // LoyaltyOrdersRepository — PostgreSQL-реализация ordersrepo.OrdersRepository.
 type LoyaltyOrdersRepository struct {
-	db *sql.DB
+	db         *sql.DB
+	tierRules  tiermodel.Rules
+	tierWindow time.Duration
 }
 
-// NewLoyaltyOrdersRepository создаёт репозиторий заказов на PostgreSQL.
-func NewLoyaltyOrdersRepository(db *sql.DB) *LoyaltyOrdersRepository {
-	return &LoyaltyOrdersRepository{db: db}
+// NewLoyaltyOrdersRepository создаёт репозиторий заказов на PostgreSQL. Правила уровней и длина
+// скользящего окна нужны для множителя начислений; без правил начисления зачисляются с множителем 1.
+func NewLoyaltyOrdersRepository(db *sql.DB, tierRules tiermodel.Rules, tierWindow time.Duration) *LoyaltyOrdersRepository {
+	return &LoyaltyOrdersRepository{db: db, tierRules: tierRules, tierWindow: tierWindow}
 }
 
-// Create создаёт заказ со статусом NEW или возвращает ошибки
-func (repository *LoyaltyOrdersRepository) Create(ctx context.Context, userID int64, number string) error {
+// Create создаёт заказ со статусом NEW (и первую запись его истории статусов) или возвращает ошибки
+func (repository *LoyaltyOrdersRepository) Create(ctx context.Context, userID int64, number string) (err error) {
+	ctx, span := tracing.Start(ctx, "OrdersRepository.Create", tracing.UserID(userID), tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
 	queryCtx, cancel := util.WithQueryTimeout(ctx)
 	defer cancel()
 
@@ -33,12 +43,19 @@ func (repository *LoyaltyOrdersRepository) Create(ctx context.Context, userID in
 	var inserted bool
 	if err := repository.db.QueryRowContext(
 		queryCtx,
-		`INSERT INTO orders(number, user_id, status) VALUES ($1, $2, $3)
-		 ON CONFLICT (number) DO UPDATE SET number = EXCLUDED.number
-		 RETURNING user_id, (xmax = 0) AS inserted`,
+		`WITH upserted AS (
+		   INSERT INTO orders(number, user_id, status) VALUES ($1, $2, $3)
+		   ON CONFLICT (number) DO UPDATE SET number = EXCLUDED.number
+		   RETURNING user_id, (xmax = 0) AS inserted
+		 ), history AS (
+		   INSERT INTO order_status_history(order_number, to_status, source)
+		   SELECT $1, $3, $4 FROM upserted WHERE inserted
+		 )
+		 SELECT user_id, inserted FROM upserted`,
 		number,
 		userID,
 		string(ordersmodel.StatusNew),
+		string(ordersmodel.SourceUser),
 	).Scan(&existingUserID, &inserted); err != nil {
 		return fmt.Errorf("insert order: %w", err)
 	}
@@ -52,8 +69,42 @@ func (repository *LoyaltyOrdersRepository) Create(ctx context.Context, userID in
 	return ordersmodel.ErrOrderAlreadyUploadedByAnother
 }
 
+// Get возвращает заказ по номеру.
+func (repository *LoyaltyOrdersRepository) Get(ctx context.Context, number string) (_ ordersmodel.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersRepository.Get", tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	order := ordersmodel.Order{Number: number}
+	var (
+		status  string
+		accrual decimal.NullDecimal
+	)
+	err = repository.db.QueryRowContext(
+		queryCtx,
+		`SELECT user_id, status, accrual, uploaded_at FROM orders WHERE number = $1`,
+		number,
+	).Scan(&order.UserID, &status, &accrual, &order.UploadedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+		}
+		return ordersmodel.Order{}, fmt.Errorf("select order: %w", err)
+	}
+	order.Status = ordersmodel.Status(status)
+	if accrual.Valid {
+		order.Accrual = &accrual.Decimal
+	}
+	return order, nil
+}
+
 // ListByUser возвращает заказы пользователя по времени загрузки (от новых к старым).
-func (repository *LoyaltyOrdersRepository) ListByUser(ctx context.Context, userID int64) ([]ordersmodel.Order, error) {
+func (repository *LoyaltyOrdersRepository) ListByUser(ctx context.Context, userID int64) (_ []ordersmodel.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersRepository.ListByUser", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
 	queryCtx, cancel := util.WithQueryTimeout(ctx)
 	defer cancel()
 
@@ -102,7 +153,10 @@ func (repository *LoyaltyOrdersRepository) ListByUser(ctx context.Context, userI
 }
 
 // ListPending возвращает все заказы в статусах NEW/PROCESSING для фоновой обработки.
-func (repository *LoyaltyOrdersRepository) ListPending(ctx context.Context) ([]ordersmodel.Order, error) {
+func (repository *LoyaltyOrdersRepository) ListPending(ctx context.Context) (_ []ordersmodel.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersRepository.ListPending")
+	defer func() { tracing.End(span, err) }()
+
 	queryCtx, cancel := util.WithQueryTimeout(ctx)
 	defer cancel()
 
@@ -146,34 +200,184 @@ func (repository *LoyaltyOrdersRepository) ListPending(ctx context.Context) ([]o
 	return out, nil
 }
 
+// ListHistory возвращает историю статусов заказа в порядке переходов.
+func (repository *LoyaltyOrdersRepository) ListHistory(ctx context.Context, number string) (_ []ordersmodel.StatusChange, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersRepository.ListHistory", tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := repository.db.QueryContext(
+		queryCtx,
+		`SELECT from_status, to_status, accrual, source, response, changed_at
+		   FROM order_status_history
+		  WHERE order_number = $1
+		  ORDER BY changed_at ASC, id ASC`,
+		number,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select order status history: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var out []ordersmodel.StatusChange
+	for rows.Next() {
+		var (
+			from     sql.NullString
+			to       string
+			accrual  decimal.NullDecimal
+			source   string
+			response []byte
+		)
+		change := ordersmodel.StatusChange{Number: number}
+		if err := rows.Scan(&from, &to, &accrual, &source, &response, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan order status change: %w", err)
+		}
+		change.From = ordersmodel.Status(from.String)
+		change.To = ordersmodel.Status(to)
+		change.Source = ordersmodel.Source(source)
+		change.Response = response
+		if accrual.Valid {
+			change.Accrual = &accrual.Decimal
+		}
+		out = append(out, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate order status history: %w", err)
+	}
+	return out, nil
+}
+
+// Requeue переводит заказ в статус NEW, если начисление по нему ещё не зачислено (accrual_applied).
+func (repository *LoyaltyOrdersRepository) Requeue(ctx context.Context, number string) (_ ordersmodel.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersRepository.Requeue", tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
+	transaction, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ordersmodel.Order{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = transaction.Rollback() }()
+
+	locked, err := repository.lockOrder(ctx, transaction, number)
+	if err != nil {
+		return ordersmodel.Order{}, err
+	}
+	if locked.userID == 0 {
+		return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+	}
+	if locked.accrualApplied {
+		return ordersmodel.Order{}, ordersmodel.ErrOrderAlreadyCredited
+	}
+	if !locked.status.CanTransitionTo(ordersmodel.StatusNew) {
+		return ordersmodel.Order{}, fmt.Errorf("%w: %s -> %s", ordersmodel.ErrIllegalTransition, locked.status, ordersmodel.StatusNew)
+	}
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	order := ordersmodel.Order{Number: number, UserID: locked.userID, Status: ordersmodel.StatusNew}
+	var accrual decimal.NullDecimal
+	if err := transaction.QueryRowContext(
+		queryCtx,
+		`UPDATE orders SET status = $2 WHERE number = $1 RETURNING accrual, uploaded_at`,
+		number,
+		string(ordersmodel.StatusNew),
+	).Scan(&accrual, &order.UploadedAt); err != nil {
+		return ordersmodel.Order{}, fmt.Errorf("requeue order: %w", err)
+	}
+	if accrual.Valid {
+		order.Accrual = &accrual.Decimal
+	}
+
+	update := ordersmodel.StatusUpdate{Status: ordersmodel.StatusNew, Accrual: order.Accrual, Source: ordersmodel.SourceAdmin}
+	if err := repository.recordStatusChange(ctx, transaction, number, locked.status, update); err != nil {
+		return ordersmodel.Order{}, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return ordersmodel.Order{}, fmt.Errorf("commit: %w", err)
+	}
+	return order, nil
+}
+
 // UpdateFromAccrual обновляет заказ и (идемпотентно) зачисляет начисление на счёт.
+// Допустимость перехода проверяется под блокировкой строки заказа, смена статуса записывается
+// в order_status_history.
+// Зачисляемая сумма умножается на множитель уровня пользователя, рассчитанного в той же транзакции
+// по скользящему окну до зачисления заказа, и сохраняется в orders.credited. Бонусы по акциям (promotion_bonuses) и вознаграждение
+// пригласившего (referral_bonuses) зачисляются на счета в той же транзакции.
 func (repository *LoyaltyOrdersRepository) UpdateFromAccrual(
 	ctx context.Context,
 	number string,
-	status ordersmodel.Status,
-	accrual *decimal.Decimal,
-) error {
+	update ordersmodel.StatusUpdate,
+	rewards ordersmodel.Rewards,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "OrdersRepository.UpdateFromAccrual", tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
 	transaction, err := repository.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer func() { _ = transaction.Rollback() }()
 
-	userID, alreadyApplied, err := repository.lockOrder(ctx, transaction, number)
+	locked, err := repository.lockOrder(ctx, transaction, number)
 	if err != nil {
 		return err
 	}
-	if userID == 0 {
+	if locked.userID == 0 {
 		return nil
 	}
+	userID, status, accrual := locked.userID, update.Status, update.Accrual
+	span.SetAttributes(tracing.UserID(userID))
+	if locked.status == ordersmodel.StatusProcessed && locked.accrualApplied && status == ordersmodel.StatusProcessed {
+		// Повторный результат по зачисленному заказу: сумму, историю и счёт не трогаем
+		if !ordersmodel.SameAccrual(locked.accrual, accrual) {
+			return fmt.Errorf("%w: order %s", ordersmodel.ErrAccrualConflict, number)
+		}
+		return nil
+	}
+	if !locked.status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s -> %s", ordersmodel.ErrIllegalTransition, locked.status, status)
+	}
 
-	shouldApplyAccrual := status == ordersmodel.StatusProcessed && !alreadyApplied
+	shouldApplyAccrual := status == ordersmodel.StatusProcessed && !locked.accrualApplied
+	if shouldApplyAccrual && rewards.Referral != nil {
+		if err := repository.lockAccounts(ctx, transaction, userID, rewards.Referral.ReferrerID); err != nil {
+			return err
+		}
+	}
+	multiplier := decimal.NewFromInt(1)
+	if shouldApplyAccrual {
+		// Уровень считается до отметки о зачислении, чтобы сам заказ не входил в окно.
+		tier, err := currentTier(ctx, transaction, repository.tierRules, repository.tierWindow, userID)
+		if err != nil {
+			return err
+		}
+		multiplier = tier.Multiplier
+	}
 	if err := repository.updateOrderStatus(ctx, transaction, number, status, accrual, shouldApplyAccrual); err != nil {
 		return err
 	}
+	if err := repository.recordStatusChange(ctx, transaction, number, locked.status, update); err != nil {
+		return err
+	}
 
 	if shouldApplyAccrual && accrual != nil && accrual.GreaterThan(decimal.Zero) {
-		if err := repository.applyAccrualToAccount(ctx, transaction, userID, *accrual); err != nil {
+		if err := repository.applyAccrualToAccount(ctx, transaction, userID, number, accrual.Mul(multiplier).Round(4)); err != nil {
+			return err
+		}
+		if err := repository.applyBonuses(ctx, transaction, userID, number, rewards.Promotions); err != nil {
+			return err
+		}
+	}
+
+	if shouldApplyAccrual && rewards.Referral != nil {
+		if err := repository.applyReferralReward(ctx, transaction, userID, number, *rewards.Referral); err != nil {
 			return err
 		}
 	}
@@ -184,29 +388,88 @@ func (repository *LoyaltyOrdersRepository) UpdateFromAccrual(
 	return nil
 }
 
+// lockedOrder — состояние заказа, заблокированного в транзакции (userID 0 — заказа нет).
+type lockedOrder struct {
+	userID         int64
+	status         ordersmodel.Status
+	accrual        *decimal.Decimal
+	accrualApplied bool
+}
+
 func (repository *LoyaltyOrdersRepository) lockOrder(
 	ctx context.Context,
 	transaction *sql.Tx,
 	number string,
-) (userID int64, alreadyApplied bool, err error) {
+) (lockedOrder, error) {
 	queryCtx, cancel := util.WithQueryTimeout(ctx)
 	defer cancel()
 
-	err = transaction.QueryRowContext(
+	var (
+		locked  lockedOrder
+		status  string
+		accrual decimal.NullDecimal
+	)
+	err := transaction.QueryRowContext(
 		queryCtx,
-		`SELECT user_id, accrual_applied FROM orders WHERE number = $1 FOR UPDATE`,
+		`SELECT user_id, status, accrual, accrual_applied FROM orders WHERE number = $1 FOR UPDATE`,
 		number,
-	).Scan(&userID, &alreadyApplied)
+	).Scan(&locked.userID, &status, &accrual, &locked.accrualApplied)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return 0, false, nil
+			return lockedOrder{}, nil
 		}
-		return 0, false, fmt.Errorf("lock order: %w", err)
+		return lockedOrder{}, fmt.Errorf("lock order: %w", err)
+	}
+	locked.status = ordersmodel.Status(status)
+	if accrual.Valid {
+		locked.accrual = &accrual.Decimal
 	}
-	return userID, alreadyApplied, nil
+	return locked, nil
 }
 
+// recordStatusChange записывает переход из from в историю статусов; повтор того же статуса не записывается.
+func (repository *LoyaltyOrdersRepository) recordStatusChange(
+	ctx context.Context,
+	transaction *sql.Tx,
+	number string,
+	from ordersmodel.Status,
+	update ordersmodel.StatusUpdate,
+) error {
+	if from == update.Status {
+		return nil
+	}
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var accrualVal, responseVal any
+	if update.Accrual != nil {
+		accrualVal = *update.Accrual
+	}
+	if len(update.Response) > 0 {
+		responseVal = string(update.Response)
+	}
+
+	_, err := transaction.ExecContext(
+		queryCtx,
+		`INSERT INTO order_status_history(order_number, from_status, to_status, accrual, source, response)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		number,
+		string(from),
+		string(update.Status),
+		accrualVal,
+		string(update.Source),
+		responseVal,
+	)
+	if err != nil {
+		return fmt.Errorf("insert order status change: %w", err)
+	}
+	return nil
+}
+
+// updateOrderStatus сохраняет статус и начисление заказа. Признак зачисления (accrual_applied) только
+// выставляется: повторный результат с тем же статусом не должен его сбрасывать.
 func (repository *LoyaltyOrdersRepository) updateOrderStatus(
 	ctx context.Context,
 	transaction *sql.Tx,
@@ -228,7 +491,8 @@ func (repository *LoyaltyOrdersRepository) updateOrderStatus(
 		`UPDATE orders
 		    SET status = $2,
 		        accrual = $3,
-		        accrual_applied = $4
+		        accrual_applied = accrual_applied OR $4,
+		        processed_at = CASE WHEN $4 THEN now() ELSE processed_at END
 		  WHERE number = $1`,
 		number,
 		string(status),
@@ -241,15 +505,26 @@ func (repository *LoyaltyOrdersRepository) updateOrderStatus(
 	return nil
 }
 
-func (repository *LoyaltyOrdersRepository) applyAccrualToAccount(ctx context.Context, transaction *sql.Tx, userID int64, accrual decimal.Decimal) error {
+// applyAccrualToAccount зачисляет на счёт сумму с учётом множителя уровня и сохраняет её в orders.credited.
+func (repository *LoyaltyOrdersRepository) applyAccrualToAccount(
+	ctx context.Context,
+	transaction *sql.Tx,
+	userID int64,
+	number string,
+	credited decimal.Decimal,
+) error {
 	queryCtx, cancel := util.WithQueryTimeout(ctx)
 	defer cancel()
 
 	_, err := transaction.ExecContext(
 		queryCtx,
-		`UPDATE accounts SET current = current + $2 WHERE user_id = $1`,
+		`WITH credited AS (
+		   UPDATE orders SET credited = $3 WHERE number = $2
+		 )
+		 UPDATE accounts SET current = current + $3 WHERE user_id = $1`,
 		userID,
-		accrual,
+		number,
+		credited,
 	)
 	if err != nil {
 		return fmt.Errorf("apply accrual: %w", err)
@@ -257,4 +532,114 @@ func (repository *LoyaltyOrdersRepository) applyAccrualToAccount(ctx context.Con
 	return nil
 }
 
+// applyBonuses записывает бонусы по акциям отдельными записями и зачисляет их на счёт.
+// Повторная запись бонуса по тому же правилу для заказа игнорируется.
+func (repository *LoyaltyOrdersRepository) applyBonuses(
+	ctx context.Context,
+	transaction *sql.Tx,
+	userID int64,
+	number string,
+	bonuses []promotionmodel.Bonus,
+) error {
+	for _, bonus := range bonuses {
+		if bonus.Amount.LessThanOrEqual(decimal.Zero) {
+			continue
+		}
+
+		queryCtx, cancel := util.WithQueryTimeout(ctx)
+		_, err := transaction.ExecContext(
+			queryCtx,
+			`WITH inserted AS (
+			   INSERT INTO promotion_bonuses(rule_id, rule_name, user_id, order_number, amount, created_at)
+			   VALUES ($2, $3, $1, $4, $5, $6)
+			   ON CONFLICT (order_number, rule_id) DO NOTHING
+			   RETURNING amount
+			 )
+			 UPDATE accounts
+			    SET current = current + inserted.amount
+			   FROM inserted
+			  WHERE accounts.user_id = $1`,
+			userID,
+			bonus.RuleID,
+			bonus.RuleName,
+			number,
+			bonus.Amount,
+			bonus.CreatedAt,
+		)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("apply promotion bonus: %w", err)
+		}
+	}
+	return nil
+}
+
+// lockAccounts блокирует счета приглашённого и пригласившего до любых изменений счетов в порядке user_id,
+// как при переводах (LoyaltyTransferRepository.lockAccounts): иначе начисление по заказу и параллельный
+// перевод между этими пользователями могут взаимно заблокироваться.
+func (repository *LoyaltyOrdersRepository) lockAccounts(ctx context.Context, transaction *sql.Tx, userID, referrerID int64) error {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	if _, err := transaction.ExecContext(
+		queryCtx,
+		`SELECT 1
+		   FROM accounts
+		  WHERE user_id IN ($1, $2)
+		  ORDER BY user_id
+		    FOR UPDATE`,
+		userID,
+		referrerID,
+	); err != nil {
+		return fmt.Errorf("lock accounts: %w", err)
+	}
+	return nil
+}
+
+// applyReferralReward записывает вознаграждение пригласившего и зачисляет его на счёт.
+// Счёт пригласившего заблокирован до проверки лимита (lockAccounts), чтобы параллельные начисления
+// его не превысили; повторное вознаграждение за того же приглашённого игнорируется (UNIQUE referee_id).
+func (repository *LoyaltyOrdersRepository) applyReferralReward(
+	ctx context.Context,
+	transaction *sql.Tx,
+	userID int64,
+	number string,
+	reward referralmodel.Reward,
+) error {
+	if reward.RefereeID != userID || reward.ReferrerID == userID || reward.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil
+	}
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := transaction.ExecContext(
+		queryCtx,
+		`WITH inserted AS (
+		   INSERT INTO referral_bonuses(referrer_id, referee_id, order_number, amount, created_at)
+		   SELECT r.referrer_id, r.referee_id, $3, $4, $5
+		     FROM referrals r
+		    WHERE r.referee_id = $2
+		      AND r.referrer_id = $1
+		      AND ($6 <= 0 OR (SELECT COUNT(*) FROM referral_bonuses b WHERE b.referrer_id = $1) < $6)
+		   ON CONFLICT (referee_id) DO NOTHING
+		   RETURNING amount
+		 )
+		 UPDATE accounts
+		    SET current = current + inserted.amount
+		   FROM inserted
+		  WHERE accounts.user_id = $1`,
+		reward.ReferrerID,
+		userID,
+		number,
+		reward.Amount,
+		reward.CreatedAt,
+		reward.MaxRewards,
+	)
+	if err != nil {
+		return fmt.Errorf("apply referral reward: %w", err)
+	}
+	return nil
+}
+
 var _ ordersrepo.OrdersRepository = (*LoyaltyOrdersRepository)(nil)
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+	"time"
+
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	promotionrepo "loyalty/internal/domain/promotion/repository"
+	tiermodel "loyalty/internal/domain/tier/model"
+
+	"github.com/shopspring/decimal"
+)
+
+const promotionRuleColumns = `id, name, active, valid_from, valid_to, conditions, effect_type, effect_value, created_at`
+
+// LoyaltyPromotionRepository — PostgreSQL-реализация promotionrepo.RuleRepository.
+type LoyaltyPromotionRepository struct {
+	db         *sql.DB
+	tierRules  tiermodel.Rules
+	tierWindow time.Duration
+}
+
+// NewLoyaltyPromotionRepository создаёт репозиторий правил акций на PostgreSQL. Правила уровней
+// и длина скользящего окна нужны для условия по уровню автора заказа.
+func NewLoyaltyPromotionRepository(db *sql.DB, tierRules tiermodel.Rules, tierWindow time.Duration) *LoyaltyPromotionRepository {
+	return &LoyaltyPromotionRepository{db: db, tierRules: tierRules, tierWindow: tierWindow}
+}
+
+// Create сохраняет новое правило.
+func (repository *LoyaltyPromotionRepository) Create(ctx context.Context, rule promotionmodel.Rule) (promotionmodel.Rule, error) {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return promotionmodel.Rule{}, fmt.Errorf("marshal conditions: %w", err)
+	}
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	created, err := scanPromotionRule(repository.db.QueryRowContext(
+		queryCtx,
+		`INSERT INTO promotion_rules(name, active, valid_from, valid_to, conditions, effect_type, effect_value)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING `+promotionRuleColumns,
+		rule.Name,
+		rule.Active,
+		rule.ValidFrom,
+		rule.ValidTo,
+		conditions,
+		string(rule.Effect.Type),
+		rule.Effect.Value,
+	))
+	if err != nil {
+		return promotionmodel.Rule{}, fmt.Errorf("insert promotion rule: %w", err)
+	}
+	return created, nil
+}
+
+// Get возвращает правило по ID или promotionmodel.ErrRuleNotFound.
+func (repository *LoyaltyPromotionRepository) Get(ctx context.Context, id int64) (promotionmodel.Rule, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rule, err := scanPromotionRule(repository.db.QueryRowContext(
+		queryCtx,
+		`SELECT `+promotionRuleColumns+` FROM promotion_rules WHERE id = $1 AND deleted_at IS NULL`,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return promotionmodel.Rule{}, promotionmodel.ErrRuleNotFound
+		}
+		return promotionmodel.Rule{}, fmt.Errorf("select promotion rule: %w", err)
+	}
+	return rule, nil
+}
+
+// List возвращает все правила (от новых к старым).
+func (repository *LoyaltyPromotionRepository) List(ctx context.Context) ([]promotionmodel.Rule, error) {
+	return repository.list(ctx, `SELECT `+promotionRuleColumns+` FROM promotion_rules WHERE deleted_at IS NULL ORDER BY id DESC`)
+}
+
+// ListActive возвращает активные правила в порядке создания.
+func (repository *LoyaltyPromotionRepository) ListActive(ctx context.Context) ([]promotionmodel.Rule, error) {
+	return repository.list(ctx, `SELECT `+promotionRuleColumns+` FROM promotion_rules WHERE active AND deleted_at IS NULL ORDER BY id ASC`)
+}
+
+// Update обновляет правило или возвращает promotionmodel.ErrRuleNotFound.
+func (repository *LoyaltyPromotionRepository) Update(ctx context.Context, rule promotionmodel.Rule) (promotionmodel.Rule, error) {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return promotionmodel.Rule{}, fmt.Errorf("marshal conditions: %w", err)
+	}
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	updated, err := scanPromotionRule(repository.db.QueryRowContext(
+		queryCtx,
+		`UPDATE promotion_rules
+		    SET name = $2,
+		        active = $3,
+		        valid_from = $4,
+		        valid_to = $5,
+		        conditions = $6,
+		        effect_type = $7,
+		        effect_value = $8
+		  WHERE id = $1 AND deleted_at IS NULL
+		 RETURNING `+promotionRuleColumns,
+		rule.ID,
+		rule.Name,
+		rule.Active,
+		rule.ValidFrom,
+		rule.ValidTo,
+		conditions,
+		string(rule.Effect.Type),
+		rule.Effect.Value,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return promotionmodel.Rule{}, promotionmodel.ErrRuleNotFound
+		}
+		return promotionmodel.Rule{}, fmt.Errorf("update promotion rule: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete удаляет правило мягко (deleted_at): оно пропадает из списков и больше не применяется, а начисленные
+// по нему бонусы продолжают на него ссылаться, и повторный бонус по тому же правилу для заказа не начисляется.
+func (repository *LoyaltyPromotionRepository) Delete(ctx context.Context, id int64) error {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := repository.db.ExecContext(
+		queryCtx,
+		`UPDATE promotion_rules SET active = FALSE, deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("delete promotion rule: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete promotion rule: %w", err)
+	}
+	if affected == 0 {
+		return promotionmodel.ErrRuleNotFound
+	}
+	return nil
+}
+
+// OrderFacts возвращает данные о заказе для проверки условий правил.
+// Первым считается самый ранний загруженный заказ пользователя, уровень рассчитывается
+// по скользящему окну на момент проверки. Если заказ не найден, UserID = 0.
+func (repository *LoyaltyPromotionRepository) OrderFacts(ctx context.Context, orderNumber string) (promotionmodel.Facts, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	facts := promotionmodel.Facts{OrderNumber: orderNumber}
+	err := repository.db.QueryRowContext(
+		queryCtx,
+		`SELECT o.user_id,
+		        o.uploaded_at,
+		        NOT EXISTS (
+		          SELECT 1 FROM orders p
+		           WHERE p.user_id = o.user_id
+		             AND (p.uploaded_at, p.number) < (o.uploaded_at, o.number)
+		        ) AS first_order
+		   FROM orders o
+		  WHERE o.number = $1`,
+		orderNumber,
+	).Scan(&facts.UserID, &facts.UploadedAt, &facts.FirstOrder)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return facts, nil
+		}
+		return promotionmodel.Facts{}, fmt.Errorf("select order facts: %w", err)
+	}
+
+	tier, err := currentTier(ctx, repository.db, repository.tierRules, repository.tierWindow, facts.UserID)
+	if err != nil {
+		return promotionmodel.Facts{}, err
+	}
+	facts.Tier = string(tier.Level)
+	return facts, nil
+}
+
+func (repository *LoyaltyPromotionRepository) list(ctx context.Context, query string) ([]promotionmodel.Rule, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := repository.db.QueryContext(queryCtx, query)
+	if err != nil {
+		return nil, fmt.Errorf("select promotion rules: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var out []promotionmodel.Rule
+	for rows.Next() {
+		rule, err := scanPromotionRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan promotion rule: %w", err)
+		}
+		out = append(out, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate promotion rules: %w", err)
+	}
+	return out, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPromotionRule(row rowScanner) (promotionmodel.Rule, error) {
+	var (
+		rule        promotionmodel.Rule
+		validFrom   sql.NullTime
+		validTo     sql.NullTime
+		conditions  []byte
+		effectType  string
+		effectValue decimal.Decimal
+		createdAt   time.Time
+	)
+	if err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Active,
+		&validFrom,
+		&validTo,
+		&conditions,
+		&effectType,
+		&effectValue,
+		&createdAt,
+	); err != nil {
+		return promotionmodel.Rule{}, err
+	}
+	if err := json.Unmarshal(conditions, &rule.Conditions); err != nil {
+		return promotionmodel.Rule{}, fmt.Errorf("unmarshal conditions: %w", err)
+	}
+	if validFrom.Valid {
+		rule.ValidFrom = &validFrom.Time
+	}
+	if validTo.Valid {
+		rule.ValidTo = &validTo.Time
+	}
+	rule.Effect = promotionmodel.Effect{Type: promotionmodel.EffectType(effectType), Value: effectValue}
+	rule.CreatedAt = createdAt
+	return rule, nil
+}
+
+var _ promotionrepo.RuleRepository = (*LoyaltyPromotionRepository)(nil)
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+
+	authmodel "loyalty/internal/domain/auth/model"
+	ordersmodel "loyalty/internal/domain/order/model"
+	referralmodel "loyalty/internal/domain/referral/model"
+	referralrepo "loyalty/internal/domain/referral/repository"
+)
+
+// LoyaltyReferralRepository — PostgreSQL-реализация referralrepo.ReferralRepository.
+type LoyaltyReferralRepository struct {
+	db *sql.DB
+}
+
+// NewLoyaltyReferralRepository создаёт репозиторий реферальной программы на PostgreSQL.
+func NewLoyaltyReferralRepository(db *sql.DB) *LoyaltyReferralRepository {
+	return &LoyaltyReferralRepository{db: db}
+}
+
+// Code возвращает реферальный код пользователя (генерируется при создании пользователя).
+func (repository *LoyaltyReferralRepository) Code(ctx context.Context, userID int64) (string, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var code string
+	if err := repository.db.QueryRowContext(
+		queryCtx,
+		`SELECT referral_code FROM users WHERE id = $1`,
+		userID,
+	).Scan(&code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", authmodel.ErrNotFound
+		}
+		return "", fmt.Errorf("select referral code: %w", err)
+	}
+	return code, nil
+}
+
+// FindReferrer возвращает ID владельца кода или referralmodel.ErrInvalidReferralCode.
+func (repository *LoyaltyReferralRepository) FindReferrer(ctx context.Context, code string) (int64, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	if err := repository.db.QueryRowContext(
+		queryCtx,
+		`SELECT id FROM users WHERE referral_code = $1`,
+		code,
+	).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, referralmodel.ErrInvalidReferralCode
+		}
+		return 0, fmt.Errorf("select referrer: %w", err)
+	}
+	return id, nil
+}
+
+// Candidate возвращает данные о приглашении автора заказа. Заказ считается первым обработанным,
+// если у автора нет других заказов с уже зачисленным начислением.
+func (repository *LoyaltyReferralRepository) Candidate(ctx context.Context, orderNumber string) (referralmodel.Candidate, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var candidate referralmodel.Candidate
+	var referrerID sql.NullInt64
+	err := repository.db.QueryRowContext(
+		queryCtx,
+		`SELECT o.user_id,
+		        r.referrer_id,
+		        NOT EXISTS (
+		          SELECT 1 FROM orders p
+		           WHERE p.user_id = o.user_id
+		             AND p.number <> o.number
+		             AND p.status = $2
+		             AND p.accrual_applied
+		        ),
+		        EXISTS (SELECT 1 FROM referral_bonuses b WHERE b.referee_id = o.user_id),
+		        (SELECT COUNT(*) FROM referral_bonuses b WHERE b.referrer_id = r.referrer_id)
+		   FROM orders o
+		   LEFT JOIN referrals r ON r.referee_id = o.user_id
+		  WHERE o.number = $1`,
+		orderNumber,
+		string(ordersmodel.StatusProcessed),
+	).Scan(
+		&candidate.RefereeID,
+		&referrerID,
+		&candidate.FirstProcessed,
+		&candidate.Rewarded,
+		&candidate.ReferrerRewards,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return referralmodel.Candidate{}, nil
+		}
+		return referralmodel.Candidate{}, fmt.Errorf("select referral candidate: %w", err)
+	}
+	candidate.ReferrerID = referrerID.Int64
+	return candidate, nil
+}
+
+// ListReferrals возвращает приглашённых пользователем (новые первыми).
+func (repository *LoyaltyReferralRepository) ListReferrals(ctx context.Context, referrerID int64) ([]referralmodel.Referral, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := repository.db.QueryContext(
+		queryCtx,
+		`SELECT r.referee_id, u.login, r.created_at,
+		        EXISTS (SELECT 1 FROM referral_bonuses b WHERE b.referee_id = r.referee_id)
+		   FROM referrals r
+		   JOIN users u ON u.id = r.referee_id
+		  WHERE r.referrer_id = $1
+		  ORDER BY r.created_at DESC`,
+		referrerID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select referrals: %w", err)
+	}
+	defer rows.Close()
+
+	var out []referralmodel.Referral
+	for rows.Next() {
+		var referral referralmodel.Referral
+		if err := rows.Scan(&referral.RefereeID, &referral.RefereeLogin, &referral.CreatedAt, &referral.Rewarded); err != nil {
+			return nil, fmt.Errorf("scan referral: %w", err)
+		}
+		out = append(out, referral)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate referrals: %w", err)
+	}
+	return out, nil
+}
+
+// ListBonuses возвращает вознаграждения пользователя за приглашения (новые первыми).
+func (repository *LoyaltyReferralRepository) ListBonuses(ctx context.Context, referrerID int64) ([]referralmodel.Bonus, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := repository.db.QueryContext(
+		queryCtx,
+		`SELECT u.login, b.order_number, b.amount, b.created_at
+		   FROM referral_bonuses b
+		   JOIN users u ON u.id = b.referee_id
+		  WHERE b.referrer_id = $1
+		  ORDER BY b.created_at DESC`,
+		referrerID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select referral bonuses: %w", err)
+	}
+	defer rows.Close()
+
+	var out []referralmodel.Bonus
+	for rows.Next() {
+		var bonus referralmodel.Bonus
+		if err := rows.Scan(&bonus.RefereeLogin, &bonus.OrderNumber, &bonus.Amount, &bonus.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan referral bonus: %w", err)
+		}
+		out = append(out, bonus)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate referral bonuses: %w", err)
+	}
+	return out, nil
+}
+
+var _ referralrepo.ReferralRepository = (*LoyaltyReferralRepository)(nil)
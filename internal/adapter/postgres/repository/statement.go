@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+
+	statementmodel "loyalty/internal/domain/statement/model"
+	statementrepo "loyalty/internal/domain/statement/repository"
+)
+
+// ledgerQuery — все операции по счёту пользователя ($1) со знаковой суммой и остатком после каждой.
+// Порядок (at, kind, reference, description) детерминирован, поэтому остаток стабилен между страницами.
+const ledgerQuery = `
+WITH ledger AS (
+  SELECT 'ACCRUAL' AS kind, o.number AS reference, '' AS description,
+         COALESCE(o.credited, o.accrual) AS amount, COALESCE(o.processed_at, o.uploaded_at) AS at
+    FROM orders o
+   WHERE o.user_id = $1 AND o.accrual_applied AND COALESCE(o.credited, o.accrual) > 0
+  UNION ALL
+  SELECT 'PROMOTION_BONUS', b.order_number, b.rule_name, b.amount, b.created_at
+    FROM promotion_bonuses b
+   WHERE b.user_id = $1
+  UNION ALL
+  SELECT 'REFERRAL_BONUS', b.order_number, u.login, b.amount, b.created_at
+    FROM referral_bonuses b
+    JOIN users u ON u.id = b.referee_id
+   WHERE b.referrer_id = $1
+  UNION ALL
+  SELECT 'TRANSFER_IN', '', u.login, t.sum, t.created_at
+    FROM transfers t
+    JOIN users u ON u.id = t.sender_id
+   WHERE t.recipient_id = $1
+  UNION ALL
+  SELECT 'TRANSFER_OUT', '', u.login, -t.sum, t.created_at
+    FROM transfers t
+    JOIN users u ON u.id = t.recipient_id
+   WHERE t.sender_id = $1
+  UNION ALL
+  SELECT 'WITHDRAWAL', w.order_number, '', -w.sum, w.processed_at
+    FROM withdrawals w
+   WHERE w.user_id = $1
+  UNION ALL
+  SELECT 'ADJUSTMENT', '', a.reason, a.amount, a.created_at
+    FROM balance_adjustments a
+   WHERE a.user_id = $1
+), running AS (
+  SELECT kind, reference, description, amount, at,
+         SUM(amount) OVER (
+           ORDER BY at, kind, reference, description
+           ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW
+         ) AS balance,
+         ($2::timestamptz IS NULL OR at >= $2) AND ($3::timestamptz IS NULL OR at < $3) AS in_range
+    FROM ledger
+)`
+
+// LoyaltyStatementRepository — PostgreSQL-реализация statementrepo.StatementRepository.
+type LoyaltyStatementRepository struct {
+	db *sql.DB
+}
+
+// NewLoyaltyStatementRepository создаёт репозиторий выписок на PostgreSQL.
+func NewLoyaltyStatementRepository(db *sql.DB) *LoyaltyStatementRepository {
+	return &LoyaltyStatementRepository{db: db}
+}
+
+// Statement читает страницу операций и итоги в одном снимке (REPEATABLE READ, только чтение).
+func (repository *LoyaltyStatementRepository) Statement(
+	ctx context.Context,
+	query statementmodel.Query,
+) ([]statementmodel.Entry, statementmodel.Summary, error) {
+	transaction, err := repository.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, statementmodel.Summary{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = transaction.Rollback() }()
+
+	summary, err := repository.summary(ctx, transaction, query)
+	if err != nil {
+		return nil, statementmodel.Summary{}, err
+	}
+	entries, err := repository.entries(ctx, transaction, query)
+	if err != nil {
+		return nil, statementmodel.Summary{}, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, statementmodel.Summary{}, fmt.Errorf("commit: %w", err)
+	}
+	return entries, summary, nil
+}
+
+func (repository *LoyaltyStatementRepository) summary(
+	ctx context.Context,
+	transaction *sql.Tx,
+	query statementmodel.Query,
+) (statementmodel.Summary, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var summary statementmodel.Summary
+	if err := transaction.QueryRowContext(
+		queryCtx,
+		ledgerQuery+`
+		SELECT COALESCE(SUM(amount) FILTER (WHERE $2::timestamptz IS NOT NULL AND at < $2), 0),
+		       COALESCE(SUM(amount) FILTER (WHERE $3::timestamptz IS NULL OR at < $3), 0),
+		       COALESCE(SUM(amount) FILTER (WHERE in_range AND amount > 0), 0),
+		       COALESCE(-SUM(amount) FILTER (WHERE in_range AND amount < 0), 0),
+		       COUNT(*) FILTER (WHERE in_range)
+		  FROM running`,
+		query.UserID,
+		query.From,
+		query.To,
+	).Scan(
+		&summary.OpeningBalance,
+		&summary.ClosingBalance,
+		&summary.TotalCredit,
+		&summary.TotalDebit,
+		&summary.Count,
+	); err != nil {
+		return statementmodel.Summary{}, fmt.Errorf("select statement summary: %w", err)
+	}
+	return summary, nil
+}
+
+func (repository *LoyaltyStatementRepository) entries(
+	ctx context.Context,
+	transaction *sql.Tx,
+	query statementmodel.Query,
+) ([]statementmodel.Entry, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var limit any
+	if query.PageSize > 0 {
+		limit = query.PageSize
+	}
+
+	rows, err := transaction.QueryContext(
+		queryCtx,
+		ledgerQuery+`
+		SELECT kind, reference, description, amount, balance, at
+		  FROM running
+		 WHERE in_range
+		 ORDER BY at, kind, reference, description
+		 LIMIT $4 OFFSET $5`,
+		query.UserID,
+		query.From,
+		query.To,
+		limit,
+		query.Offset(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select statement entries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []statementmodel.Entry
+	for rows.Next() {
+		var (
+			entry statementmodel.Entry
+			kind  string
+		)
+		if err := rows.Scan(&kind, &entry.Reference, &entry.Description, &entry.Amount, &entry.Balance, &entry.At); err != nil {
+			return nil, fmt.Errorf("scan statement entry: %w", err)
+		}
+		entry.Type = statementmodel.EntryType(kind)
+		out = append(out, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate statement entries: %w", err)
+	}
+	return out, nil
+}
+
+// mismatchesQuery — счета, баланс которых не равен сумме всех операций выписки (тот же набор операций,
+// что в ledgerQuery, но по всем пользователям).
+const mismatchesQuery = `
+WITH ledger AS (
+  SELECT o.user_id, COALESCE(o.credited, o.accrual) AS amount
+    FROM orders o
+   WHERE o.accrual_applied AND COALESCE(o.credited, o.accrual) > 0
+  UNION ALL
+  SELECT b.user_id, b.amount FROM promotion_bonuses b
+  UNION ALL
+  SELECT b.referrer_id, b.amount FROM referral_bonuses b
+  UNION ALL
+  SELECT t.recipient_id, t.sum FROM transfers t
+  UNION ALL
+  SELECT t.sender_id, -t.sum FROM transfers t
+  UNION ALL
+  SELECT w.user_id, -w.sum FROM withdrawals w
+  UNION ALL
+  SELECT a.user_id, a.amount FROM balance_adjustments a
+), totals AS (
+  SELECT user_id, SUM(amount) AS amount FROM ledger GROUP BY user_id
+)
+SELECT COALESCE(a.user_id, t.user_id), COALESCE(t.amount, 0), COALESCE(a.current, 0)
+  FROM accounts a
+  FULL JOIN totals t ON t.user_id = a.user_id
+ WHERE COALESCE(t.amount, 0) <> COALESCE(a.current, 0)
+ ORDER BY 1`
+
+// Mismatches сверяет балансы всех счетов с суммами операций в одном снимке (REPEATABLE READ, только чтение).
+func (repository *LoyaltyStatementRepository) Mismatches(ctx context.Context) ([]statementmodel.Mismatch, error) {
+	transaction, err := repository.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = transaction.Rollback() }()
+
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := transaction.QueryContext(queryCtx, mismatchesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("select balance mismatches: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []statementmodel.Mismatch
+	for rows.Next() {
+		var mismatch statementmodel.Mismatch
+		if err := rows.Scan(&mismatch.UserID, &mismatch.Ledger, &mismatch.Current); err != nil {
+			return nil, fmt.Errorf("scan balance mismatch: %w", err)
+		}
+		out = append(out, mismatch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate balance mismatches: %w", err)
+	}
+	return out, nil
+}
+
+var _ statementrepo.StatementRepository = (*LoyaltyStatementRepository)(nil)
+var _ statementrepo.ReconciliationRepository = (*LoyaltyStatementRepository)(nil)
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+	"time"
+
+	ordersmodel "loyalty/internal/domain/order/model"
+	tiermodel "loyalty/internal/domain/tier/model"
+	tierrepo "loyalty/internal/domain/tier/repository"
+
+	"github.com/shopspring/decimal"
+)
+
+// LoyaltyTierRepository — PostgreSQL-реализация tierrepo.TierRepository.
+type LoyaltyTierRepository struct {
+	db *sql.DB
+}
+
+// NewLoyaltyTierRepository создаёт репозиторий уровней пользователей на PostgreSQL.
+func NewLoyaltyTierRepository(db *sql.DB) *LoyaltyTierRepository {
+	return &LoyaltyTierRepository{db: db}
+}
+
+// RollingAccrual возвращает сумму начислений (без учёта множителя уровня) по заказам,
+// начисление по которым было зачислено начиная с since.
+func (repository *LoyaltyTierRepository) RollingAccrual(ctx context.Context, userID int64, since time.Time) (decimal.Decimal, error) {
+	return rollingAccrual(ctx, repository.db, userID, since)
+}
+
+// rowQuerier — общий для *sql.DB и *sql.Tx запрос одной строки.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// rollingAccrual считает сумму начислений за скользящее окно; querier — база или транзакция зачисления.
+func rollingAccrual(ctx context.Context, querier rowQuerier, userID int64, since time.Time) (decimal.Decimal, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var total decimal.Decimal
+	if err := querier.QueryRowContext(
+		queryCtx,
+		`SELECT COALESCE(SUM(accrual), 0)
+		   FROM orders
+		  WHERE user_id = $1
+		    AND status = $2
+		    AND accrual_applied
+		    AND processed_at >= $3`,
+		userID,
+		string(ordersmodel.StatusProcessed),
+		since,
+	).Scan(&total); err != nil {
+		return decimal.Zero, fmt.Errorf("select rolling accrual: %w", err)
+	}
+	return total, nil
+}
+
+// currentTier рассчитывает уровень пользователя по скользящему окну на текущий момент.
+// Сохранённый уровень (user_tiers) не используется: он обновляется только при зачислении
+// и не понижается по мере сдвига окна.
+func currentTier(ctx context.Context, querier rowQuerier, rules tiermodel.Rules, window time.Duration, userID int64) (tiermodel.Rule, error) {
+	if len(rules) == 0 {
+		return tiermodel.BaseRule(), nil
+	}
+	total, err := rollingAccrual(ctx, querier, userID, time.Now().Add(-window))
+	if err != nil {
+		return tiermodel.Rule{}, err
+	}
+	current, _ := rules.Resolve(total)
+	return current, nil
+}
+
+// Save сохраняет рассчитанный уровень пользователя.
+func (repository *LoyaltyTierRepository) Save(ctx context.Context, tier tiermodel.UserTier) error {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := repository.db.ExecContext(
+		queryCtx,
+		`INSERT INTO user_tiers(user_id, tier, multiplier, rolling_accrual, recalculated_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (user_id) DO UPDATE
+		   SET tier = EXCLUDED.tier,
+		       multiplier = EXCLUDED.multiplier,
+		       rolling_accrual = EXCLUDED.rolling_accrual,
+		       recalculated_at = EXCLUDED.recalculated_at`,
+		tier.UserID,
+		string(tier.Level),
+		tier.Multiplier,
+		tier.RollingAccrual,
+		tier.RecalculatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert user tier: %w", err)
+	}
+	return nil
+}
+
+var _ tierrepo.TierRepository = (*LoyaltyTierRepository)(nil)
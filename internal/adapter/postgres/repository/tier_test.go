@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ordersmodel "loyalty/internal/domain/order/model"
+	tiermodel "loyalty/internal/domain/tier/model"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTier_ExpiredOrdersDoNotKeepMultiplierOrPromotionTier(t *testing.T) {
+	db := openTestDB(t)
+	truncateTestDB(t, db)
+	ctx := context.Background()
+
+	rules, err := tiermodel.NewRules([]tiermodel.Rule{
+		{Level: tiermodel.LevelGold, Threshold: decimal.NewFromInt(1000), Multiplier: decimal.NewFromInt(2)},
+	})
+	if err != nil {
+		t.Fatalf("NewRules: %v", err)
+	}
+	window := 30 * 24 * time.Hour
+	orders := NewLoyaltyOrdersRepository(db, rules, window)
+	promotions := NewLoyaltyPromotionRepository(db, rules, window)
+	tiers := NewLoyaltyTierRepository(db)
+
+	user, err := NewAuthUserRepository(db).Create(ctx, "alice", []byte("hash"), 0)
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	// GOLD был заработан давно: заказы вышли из окна, а сохранённый уровень остался прежним.
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO orders(number, user_id, status, accrual, accrual_applied, processed_at, uploaded_at)
+		 VALUES ('old', $1, $2, 5000, TRUE, now() - interval '60 days', now() - interval '60 days')`,
+		user.ID, string(ordersmodel.StatusProcessed),
+	); err != nil {
+		t.Fatalf("insert old order: %v", err)
+	}
+	if err := tiers.Save(ctx, tiermodel.UserTier{
+		UserID:         user.ID,
+		Level:          tiermodel.LevelGold,
+		Multiplier:     decimal.NewFromInt(2),
+		RollingAccrual: decimal.NewFromInt(5000),
+		RecalculatedAt: time.Now().Add(-60 * 24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("save stale tier: %v", err)
+	}
+
+	credit := func(number string) decimal.Decimal {
+		t.Helper()
+		if err := orders.Create(ctx, user.ID, number); err != nil {
+			t.Fatalf("create order %s: %v", number, err)
+		}
+		accrual := decimal.NewFromInt(100)
+		update := ordersmodel.StatusUpdate{Status: ordersmodel.StatusProcessed, Accrual: &accrual, Source: ordersmodel.SourceWorker}
+		if err := orders.UpdateFromAccrual(ctx, number, update, ordersmodel.Rewards{}); err != nil {
+			t.Fatalf("update order %s: %v", number, err)
+		}
+		var credited decimal.Decimal
+		if err := db.QueryRowContext(ctx, `SELECT credited FROM orders WHERE number = $1`, number).Scan(&credited); err != nil {
+			t.Fatalf("select credited %s: %v", number, err)
+		}
+		return credited
+	}
+	factsTier := func(number string) string {
+		t.Helper()
+		facts, err := promotions.OrderFacts(ctx, number)
+		if err != nil {
+			t.Fatalf("order facts %s: %v", number, err)
+		}
+		return facts.Tier
+	}
+
+	if err := orders.Create(ctx, user.ID, "expired"); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if got := factsTier("expired"); got != string(tiermodel.LevelBase) {
+		t.Fatalf("promotion tier with expired orders = %s, want %s", got, tiermodel.LevelBase)
+	}
+	if got := credit("fresh"); !got.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("credited with expired orders = %s, want 100", got)
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE orders SET processed_at = now() - interval '1 day' WHERE number = 'old'`); err != nil {
+		t.Fatalf("move old order into window: %v", err)
+	}
+	if got := factsTier("expired"); got != string(tiermodel.LevelGold) {
+		t.Fatalf("promotion tier within window = %s, want %s", got, tiermodel.LevelGold)
+	}
+	if got := credit("within"); !got.Equal(decimal.NewFromInt(200)) {
+		t.Fatalf("credited within window = %s, want 200", got)
+	}
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+
+	transfermodel "loyalty/internal/domain/transfer/model"
+	transferrepo "loyalty/internal/domain/transfer/repository"
+	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// LoyaltyTransferRepository — PostgreSQL-реализация transferrepo.TransferRepository.
+type LoyaltyTransferRepository struct {
+	db *sql.DB
+}
+
+// NewLoyaltyTransferRepository создаёт репозиторий переводов на PostgreSQL.
+func NewLoyaltyTransferRepository(db *sql.DB) *LoyaltyTransferRepository {
+	return &LoyaltyTransferRepository{db: db}
+}
+
+// Transfer переводит баллы в одной транзакции. Оба счёта блокируются одним запросом
+// в порядке возрастания user_id, чтобы встречные переводы не приводили к взаимной блокировке.
+func (repository *LoyaltyTransferRepository) Transfer(
+	ctx context.Context,
+	request transfermodel.Request,
+) (transfermodel.Transfer, error) {
+	transaction, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return transfermodel.Transfer{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = transaction.Rollback() }()
+
+	recipientID, err := repository.findRecipient(ctx, transaction, request.RecipientLogin)
+	if err != nil {
+		return transfermodel.Transfer{}, err
+	}
+	if recipientID == request.SenderID {
+		return transfermodel.Transfer{}, transfermodel.ErrSelfTransfer
+	}
+
+	senderCurrent, err := repository.lockAccounts(ctx, transaction, request.SenderID, recipientID)
+	if err != nil {
+		return transfermodel.Transfer{}, err
+	}
+
+	spent, count, err := repository.sentSince(ctx, transaction, request)
+	if err != nil {
+		return transfermodel.Transfer{}, err
+	}
+	if !request.Limits.Allows(spent, count, request.Sum) {
+		return transfermodel.Transfer{}, transfermodel.ErrDailyLimitExceeded
+	}
+	if senderCurrent.LessThan(request.Sum) {
+		return transfermodel.Transfer{}, withdrawalsmodel.ErrInsufficientFunds
+	}
+
+	transfer, err := repository.executeTransfer(ctx, transaction, request, recipientID)
+	if err != nil {
+		return transfermodel.Transfer{}, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return transfermodel.Transfer{}, fmt.Errorf("commit: %w", err)
+	}
+	return transfer, nil
+}
+
+func (repository *LoyaltyTransferRepository) findRecipient(ctx context.Context, transaction *sql.Tx, login string) (int64, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	if err := transaction.QueryRowContext(
+		queryCtx,
+		`SELECT id FROM users WHERE login = $1`,
+		login,
+	).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, transfermodel.ErrRecipientNotFound
+		}
+		return 0, fmt.Errorf("select recipient: %w", err)
+	}
+	return id, nil
+}
+
+// lockAccounts блокирует счета отправителя и получателя (по возрастанию user_id)
+// и возвращает текущий баланс отправителя.
+func (repository *LoyaltyTransferRepository) lockAccounts(
+	ctx context.Context,
+	transaction *sql.Tx,
+	senderID, recipientID int64,
+) (decimal.Decimal, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	if _, err := transaction.ExecContext(
+		queryCtx,
+		`INSERT INTO accounts(user_id)
+		 VALUES ($1), ($2)
+		 ON CONFLICT (user_id) DO NOTHING`,
+		min(senderID, recipientID),
+		max(senderID, recipientID),
+	); err != nil {
+		return decimal.Zero, fmt.Errorf("init accounts: %w", err)
+	}
+
+	rows, err := transaction.QueryContext(
+		queryCtx,
+		`SELECT user_id, current
+		   FROM accounts
+		  WHERE user_id IN ($1, $2)
+		  ORDER BY user_id
+		    FOR UPDATE`,
+		senderID,
+		recipientID,
+	)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("lock accounts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	senderCurrent := decimal.Zero
+	for rows.Next() {
+		var (
+			userID  int64
+			current decimal.Decimal
+		)
+		if err := rows.Scan(&userID, &current); err != nil {
+			return decimal.Zero, fmt.Errorf("scan account: %w", err)
+		}
+		if userID == senderID {
+			senderCurrent = current
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return decimal.Zero, fmt.Errorf("iterate accounts: %w", err)
+	}
+	return senderCurrent, nil
+}
+
+// sentSince возвращает сумму и количество исходящих переводов отправителя с начала суток.
+func (repository *LoyaltyTransferRepository) sentSince(
+	ctx context.Context,
+	transaction *sql.Tx,
+	request transfermodel.Request,
+) (decimal.Decimal, int, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var (
+		spent decimal.Decimal
+		count int
+	)
+	if err := transaction.QueryRowContext(
+		queryCtx,
+		`SELECT COALESCE(SUM(sum), 0), COUNT(*)
+		   FROM transfers
+		  WHERE sender_id = $1 AND created_at >= $2`,
+		request.SenderID,
+		request.DayStart,
+	).Scan(&spent, &count); err != nil {
+		return decimal.Zero, 0, fmt.Errorf("select daily transfers: %w", err)
+	}
+	return spent, count, nil
+}
+
+func (repository *LoyaltyTransferRepository) executeTransfer(
+	ctx context.Context,
+	transaction *sql.Tx,
+	request transfermodel.Request,
+	recipientID int64,
+) (transfermodel.Transfer, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	transfer := transfermodel.Transfer{
+		SenderID:       request.SenderID,
+		RecipientID:    recipientID,
+		RecipientLogin: request.RecipientLogin,
+		Sum:            request.Sum,
+		CreatedAt:      request.Now,
+	}
+	if err := transaction.QueryRowContext(
+		queryCtx,
+		`WITH debited AS (
+		   UPDATE accounts SET current = current - $3 WHERE user_id = $1
+		 ), credited AS (
+		   UPDATE accounts SET current = current + $3 WHERE user_id = $2
+		 )
+		 INSERT INTO transfers(sender_id, recipient_id, sum, created_at)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id`,
+		request.SenderID,
+		recipientID,
+		request.Sum,
+		request.Now,
+	).Scan(&transfer.ID); err != nil {
+		return transfermodel.Transfer{}, fmt.Errorf("execute transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+// History возвращает списания и переводы пользователя (от новых к старым).
+func (repository *LoyaltyTransferRepository) History(ctx context.Context, userID int64) ([]transfermodel.Transaction, error) {
+	queryCtx, cancel := util.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := repository.db.QueryContext(
+		queryCtx,
+		`SELECT $2::text, w.order_number, '', w.sum, w.processed_at
+		   FROM withdrawals w
+		  WHERE w.user_id = $1
+		 UNION ALL
+		 SELECT $3::text, '', u.login, t.sum, t.created_at
+		   FROM transfers t
+		   JOIN users u ON u.id = t.recipient_id
+		  WHERE t.sender_id = $1
+		 UNION ALL
+		 SELECT $4::text, '', u.login, t.sum, t.created_at
+		   FROM transfers t
+		   JOIN users u ON u.id = t.sender_id
+		  WHERE t.recipient_id = $1
+		 ORDER BY 5 DESC`,
+		userID,
+		string(transfermodel.TransactionWithdrawal),
+		string(transfermodel.TransactionTransferOut),
+		string(transfermodel.TransactionTransferIn),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select transactions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []transfermodel.Transaction
+	for rows.Next() {
+		var (
+			item            transfermodel.Transaction
+			transactionType string
+		)
+		if err := rows.Scan(&transactionType, &item.Order, &item.Counterparty, &item.Sum, &item.ProcessedAt); err != nil {
+			return nil, fmt.Errorf("scan transaction: %w", err)
+		}
+		item.Type = transfermodel.TransactionType(transactionType)
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transactions: %w", err)
+	}
+	return out, nil
+}
+
+var _ transferrepo.TransferRepository = (*LoyaltyTransferRepository)(nil)
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+	"loyalty/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// queryTracer открывает спан на каждый SQL-запрос, выполненный через pgx.
+// Спан становится дочерним к спану репозитория/usecase из контекста запроса.
+type queryTracer struct{}
+
+// TraceQueryStart реализует pgx.QueryTracer.
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = tracing.Start(ctx, "postgres.query",
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBQueryText(data.SQL),
+	)
+	return ctx
+}
+
+// TraceQueryEnd реализует pgx.QueryTracer.
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	tracing.End(trace.SpanFromContext(ctx), data.Err)
+}
+
+var _ pgx.QueryTracer = queryTracer{}
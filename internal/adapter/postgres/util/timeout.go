@@ -2,22 +2,35 @@ package util
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 )
 
-// queryTimeout — дефолтный таймаут для SQL запросов (защита от зависших запросов).
-var queryTimeout = 3 * time.Second
+// defaultQueryTimeout — дефолтный таймаут для SQL запросов (защита от зависших запросов).
+const defaultQueryTimeout = 3 * time.Second
+
+// queryTimeout — текущий таймаут SQL запросов (наносекунды); меняется на лету при перезагрузке конфигурации.
+var queryTimeout atomic.Int64
+
+func init() {
+	queryTimeout.Store(int64(defaultQueryTimeout))
+}
 
 // SetQueryTimeout устанавливает глобальный таймаут для всех SQL запросов.
-// Должен вызываться при инициализации приложения (до создания репозиториев).
+// Безопасен для вызова во время работы: новое значение действует для следующих запросов.
 func SetQueryTimeout(timeout time.Duration) {
 	if timeout > 0 {
-		queryTimeout = timeout
+		queryTimeout.Store(int64(timeout))
 	}
 }
 
+// QueryTimeout возвращает текущий таймаут SQL запросов.
+func QueryTimeout() time.Duration {
+	return time.Duration(queryTimeout.Load())
+}
+
 // WithQueryTimeout создаёт контекст с deadline для выполнения SQL запроса.
 // Если родительский контекст уже имеет deadline, выбирается более ранний.
 func WithQueryTimeout(parent context.Context) (context.Context, context.CancelFunc) {
-	return context.WithTimeout(parent, queryTimeout)
+	return context.WithTimeout(parent, QueryTimeout())
 }
@@ -48,21 +48,21 @@ func TestWithQueryTimeout_RespectsParentDeadline(t *testing.T) {
 }
 
 func TestSetQueryTimeout_IgnoresZero(t *testing.T) {
-	original := queryTimeout
-	defer func() { queryTimeout = original }()
+	original := QueryTimeout()
+	defer SetQueryTimeout(original)
 
 	SetQueryTimeout(100 * time.Millisecond)
-	if queryTimeout != 100*time.Millisecond {
-		t.Fatalf("expected timeout to be set to 100ms, got %v", queryTimeout)
+	if QueryTimeout() != 100*time.Millisecond {
+		t.Fatalf("expected timeout to be set to 100ms, got %v", QueryTimeout())
 	}
 
 	SetQueryTimeout(0)
-	if queryTimeout != 100*time.Millisecond {
-		t.Fatalf("expected timeout to remain 100ms when setting to 0, got %v", queryTimeout)
+	if QueryTimeout() != 100*time.Millisecond {
+		t.Fatalf("expected timeout to remain 100ms when setting to 0, got %v", QueryTimeout())
 	}
 
 	SetQueryTimeout(-1 * time.Second)
-	if queryTimeout != 100*time.Millisecond {
-		t.Fatalf("expected timeout to remain 100ms when setting to negative, got %v", queryTimeout)
+	if QueryTimeout() != 100*time.Millisecond {
+		t.Fatalf("expected timeout to remain 100ms when setting to negative, got %v", QueryTimeout())
 	}
 }
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"loyalty/internal/adapter/postgres"
+	postgresrepo "loyalty/internal/adapter/postgres/repository"
+	"loyalty/internal/adapter/postgres/util"
+	"loyalty/internal/config"
+	cliadmin "loyalty/internal/controller/cli/admin"
+	adjustmentappsvc "loyalty/internal/domain/adjustment/service/adjustment"
+	adminuc "loyalty/internal/domain/admin/usecase/admin"
+	"loyalty/internal/domain/auth/service/user"
+	balanceappsvc "loyalty/internal/domain/balance/service/balance"
+	ordersappsvc "loyalty/internal/domain/order/service/orders"
+	ordervalidator "loyalty/internal/domain/order/service/validator"
+	statementappsvc "loyalty/internal/domain/statement/service/statement"
+	withdrawalsappsvc "loyalty/internal/domain/withdrawal/service/withdrawals"
+	"os"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+// splitAdminArgs отделяет аргументы действия admin от флагов конфигурации (после "--").
+func splitAdminArgs(args []string) (action []string, flags []string) {
+	separator := slices.Index(args, "--")
+	if separator < 0 {
+		return args, nil
+	}
+	return args[:separator], args[separator+1:]
+}
+
+// runAdmin выполняет подкоманду admin; результат выводится в out.
+func runAdmin(ctx context.Context, args []string, out io.Writer) error {
+	actionArgs, configArgs := splitAdminArgs(args)
+	request, err := cliadmin.ParseRequest(actionArgs)
+	if err != nil {
+		_, _ = fmt.Fprint(os.Stderr, cliadmin.Usage)
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	appConfig, err := config.LoadArgs(configArgs)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid configuration")
+		return err
+	}
+	initLogger(appConfig.LogLevel)
+	if appConfig.Storage != config.StoragePostgres {
+		err := fmt.Errorf("%w: admin requires postgres storage, got %q", ErrUsage, appConfig.Storage)
+		log.Error().Err(err).Msg("invalid configuration")
+		return err
+	}
+	util.SetQueryTimeout(appConfig.DBQueryTimeout)
+
+	db, err := postgres.Open(ctx, appConfig.DatabaseURI, cliPoolConfig)
+	if err != nil {
+		log.Error().Err(err).Msg("database init failed")
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	if err := cliadmin.NewHandler(newAdminUsecase(db), out).Handle(ctx, request); err != nil {
+		if errors.Is(err, cliadmin.ErrUsage) {
+			return fmt.Errorf("%w: %w", ErrUsage, err)
+		}
+		log.Error().Err(err).Str("action", request.Action).Msg("admin action failed")
+		return err
+	}
+	log.Info().
+		Str("action", request.Action).
+		Int64("user_id", request.User.ID).
+		Str("login", request.User.Login).
+		Str("order", request.Order).
+		Str("actor", request.Actor).
+		Msg("admin action finished")
+	return nil
+}
+
+// newAdminUsecase собирает usecase поддержки из тех же репозиториев и сервисов, что и API.
+// Бонусы по акциям, реферальные вознаграждения и множитель уровня применяет только воркер,
+// поэтому здесь они не нужны.
+func newAdminUsecase(db *sql.DB) *adminuc.Usecase {
+	accountRepo := postgresrepo.NewLoyaltyAccountRepository(db)
+	return adminuc.NewUsecase(
+		user.NewUserService(postgresrepo.NewAuthUserRepository(db)),
+		balanceappsvc.NewService(accountRepo),
+		ordersappsvc.NewService(postgresrepo.NewLoyaltyOrdersRepository(db, nil, 0), ordervalidator.NewValidator(), nil, nil),
+		withdrawalsappsvc.NewService(accountRepo, postgresrepo.NewLoyaltyWithdrawalsRepository(db)),
+		adjustmentappsvc.NewService(postgresrepo.NewLoyaltyAdjustmentRepository(db)),
+		statementappsvc.NewService(postgresrepo.NewLoyaltyStatementRepository(db)),
+	)
+}
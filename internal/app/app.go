@@ -1,31 +1,57 @@
 package app
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	accrualhttp "loyalty/internal/adapter/accrual/http"
 	accrualmock "loyalty/internal/adapter/accrual/mock"
+	accrualrouter "loyalty/internal/adapter/accrual/router"
 	"loyalty/internal/adapter/postgres"
 	postgresrepo "loyalty/internal/adapter/postgres/repository"
 	"loyalty/internal/adapter/postgres/util"
 	tokensvc "loyalty/internal/adapter/token/jwt"
 	"loyalty/internal/config"
+	"loyalty/internal/controller/httpapi/common/middleware/ratelimit"
 	accrualclient "loyalty/internal/domain/accrual/client"
+	accrualcallbackuc "loyalty/internal/domain/accrual/usecase/callback"
 	"loyalty/internal/domain/auth/service/auth"
 	"loyalty/internal/domain/auth/service/user"
 	authusecase "loyalty/internal/domain/auth/usecase/auth"
 	balanceappsvc "loyalty/internal/domain/balance/service/balance"
 	balanceuc "loyalty/internal/domain/balance/usecase/balance"
+	jobrunappsvc "loyalty/internal/domain/jobrun/service/jobrun"
+	jobrunuc "loyalty/internal/domain/jobrun/usecase/jobrun"
 	ordersappsvc "loyalty/internal/domain/order/service/orders"
 	ordervalidator "loyalty/internal/domain/order/service/validator"
 	orderusecase "loyalty/internal/domain/order/usecase/order"
+	promotionappsvc "loyalty/internal/domain/promotion/service/promotion"
+	promotionuc "loyalty/internal/domain/promotion/usecase/promotion"
+	referralmodel "loyalty/internal/domain/referral/model"
+	referralappsvc "loyalty/internal/domain/referral/service/referral"
+	referraluc "loyalty/internal/domain/referral/usecase/referral"
+	statementappsvc "loyalty/internal/domain/statement/service/statement"
+	statementuc "loyalty/internal/domain/statement/usecase/statement"
+	tiermodel "loyalty/internal/domain/tier/model"
+	tierappsvc "loyalty/internal/domain/tier/service/tier"
+	tieruc "loyalty/internal/domain/tier/usecase/tier"
+	transfermodel "loyalty/internal/domain/transfer/model"
+	transferappsvc "loyalty/internal/domain/transfer/service/transfer"
+	transferuc "loyalty/internal/domain/transfer/usecase/transfer"
 	withdrawalsappsvc "loyalty/internal/domain/withdrawal/service/withdrawals"
 	withdrawalusecase "loyalty/internal/domain/withdrawal/usecase/withdrawals"
+	"loyalty/internal/health"
+	"loyalty/internal/leader"
 	"loyalty/internal/logger"
+	"loyalty/internal/metrics"
+	"loyalty/internal/tracing"
 	accrualworker "loyalty/internal/worker/accrual"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -33,43 +59,117 @@ import (
 	"loyalty/internal/controller/httpapi"
 )
 
-// Run запускает приложение: инициализирует зависимости, поднимает HTTP-сервер,
-// запускает accrual воркер и корректно завершает их при отмене контекста.
-func Run(ctx context.Context) error {
-	appConfig := loadConfig()
+// runService запускает сервис в режиме mode: инициализирует зависимости, поднимает HTTP-сервер
+// (API или только служебные маршруты), запускает accrual воркер и планировщик фоновых задач
+// и корректно завершает их при отмене контекста.
+func runService(ctx context.Context, mode serviceMode, args []string) error {
+	appConfig, err := config.LoadArgs(args)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid configuration")
+		return err
+	}
+	if appConfig.PrintConfig {
+		return config.Print(os.Stdout, appConfig)
+	}
 	initLogger(appConfig.LogLevel)
 
-	db, errDb := initDb(ctx, appConfig)
-	if errDb != nil {
-		return errDb
+	shutdownTracing, errTracing := initTracing(ctx, appConfig)
+	if errTracing != nil {
+		return errTracing
 	}
-	defer func() { _ = db.Close() }()
+	defer shutdownTracing()
 
-	dependencies, worker := loadDependencies(appConfig, db)
-	server, errChannel := httpapi.StartServer(appConfig, dependencies)
+	store, errStorage := openStorage(ctx, appConfig)
+	if errStorage != nil {
+		return errStorage
+	}
 
-	workerCtx, workerCancel := context.WithCancel(ctx)
-	defer workerCancel()
-	go worker.Start(workerCtx)
+	dependencies, jobs, errDeps := loadDependencies(appConfig, store, mode.worker)
+	if errDeps != nil {
+		_ = store.Close()
+		return errDeps
+	}
+	var (
+		server     *http.Server
+		errChannel <-chan error
+	)
+	if mode.api {
+		server, errChannel = httpapi.StartServer(appConfig, dependencies)
+	} else {
+		server, errChannel = httpapi.StartServiceServer(appConfig, dependencies)
+	}
+	log.Info().Str("command", mode.name).Bool("api", mode.api).Bool("worker", mode.worker).Msg("service started")
 
-	select {
-	case <-ctx.Done():
-		workerCancel() // Останавливаем воркер
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			log.Error().Err(err).Msg("http server shutdown failed")
-		}
-		return nil
-	case err := <-errChannel:
-		workerCancel()
-		if errors.Is(err, http.ErrServerClosed) {
-			return nil
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	defer stopWorker()
+	// Воркер и планировщик не запускаются в режиме serve; закрытый канал означает, что дожидаться нечего.
+	// С выбором лидера их запускает и останавливает elector, а канал закрывается после освобождения блокировки.
+	var workerDone <-chan struct{}
+	if mode.worker {
+		workerDone = jobs.Start(workerCtx)
+	} else {
+		closed := make(chan struct{})
+		close(closed)
+		workerDone = closed
+		jobs = background{}
+	}
+
+	// SIGHUP перечитывает конфигурацию и применяет параметры, не требующие перезапуска.
+	reloader := newConfigReloader(appConfig, func() (config.Config, error) { return config.LoadArgs(args) },
+		dependencies.AuthRateLimiter, jobs.worker, jobs.scheduler)
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	// Порядок остановки: снять трафик, дождаться HTTP-запросов, дождаться воркера, закрыть БД.
+	for {
+		select {
+		case <-hangup:
+			log.Info().Msg("SIGHUP received, reloading configuration")
+			_ = reloader.reload()
+		case <-ctx.Done():
+			log.Info().Dur("timeout", appConfig.ShutdownTimeout).Msg("shutting down")
+			return runShutdown(appConfig.ShutdownTimeout,
+				stopTrafficStep(dependencies.Readiness, appConfig.ShutdownDrainDelay),
+				drainHTTPStep(server),
+				drainWorkerStep(stopWorker, workerDone),
+				closeDatabaseStep(store),
+			)
+		case err := <-errChannel:
+			shutdownErr := runShutdown(appConfig.ShutdownTimeout,
+				stopTrafficStep(dependencies.Readiness, 0),
+				drainWorkerStep(stopWorker, workerDone),
+				closeDatabaseStep(store),
+			)
+			if errors.Is(err, http.ErrServerClosed) {
+				return shutdownErr
+			}
+			return errors.Join(err, shutdownErr)
 		}
-		return err
 	}
 }
 
+// initTracing настраивает экспорт спанов; возвращённая функция сбрасывает незаписанные спаны.
+func initTracing(ctx context.Context, appConfig config.Config) (func(), error) {
+	shutdown, err := tracing.Init(ctx, appConfig.Tracing)
+	if err != nil {
+		log.Error().Err(err).Msg("tracing init failed")
+		return nil, err
+	}
+	log.Info().
+		Str("exporter", string(appConfig.Tracing.Exporter)).
+		Float64("sample_ratio", appConfig.Tracing.SampleRatio).
+		Msg("tracing configured")
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("tracing shutdown failed")
+		}
+	}, nil
+}
+
 func initDb(ctx context.Context, appConfig config.Config) (*sql.DB, error) {
 	util.SetQueryTimeout(appConfig.DBQueryTimeout)
 
@@ -79,7 +179,11 @@ func initDb(ctx context.Context, appConfig config.Config) (*sql.DB, error) {
 		ConnMaxLifetime: appConfig.DBConnMaxLifetime,
 		ConnMaxIdleTime: appConfig.DBConnMaxIdleTime,
 	}
-	db, err := postgres.OpenWithMigrations(ctx, appConfig.DatabaseURI, poolCfg)
+	open := postgres.Open
+	if appConfig.DBAutoMigrate {
+		open = postgres.OpenWithMigrations
+	}
+	db, err := open(ctx, appConfig.DatabaseURI, poolCfg)
 	if err != nil {
 		log.Error().Err(err).Msg("database init failed")
 		return nil, err
@@ -91,35 +195,88 @@ func initDb(ctx context.Context, appConfig config.Config) (*sql.DB, error) {
 		Dur("conn_max_idle_time", poolCfg.ConnMaxIdleTime).
 		Dur("query_timeout", appConfig.DBQueryTimeout).
 		Msg("database connection pool configured")
+
+	if err := metrics.RegisterDB(db, "loyalty"); err != nil {
+		log.Warn().Err(err).Msg("failed to register database pool metrics")
+	}
 	return db, nil
 }
 
-func loadDependencies(appConfig config.Config, db *sql.DB) (httpapi.Deps, *accrualworker.Worker) {
+// loadDependencies собирает зависимости сервиса и фоновые задачи. withWorker определяет, входит ли
+// heartbeat воркера в проверки готовности (в режиме serve фоновые задачи не запускаются). Elector
+// создаётся, если фоновые задачи запускаются и включён выбор лидера (LEADER_ELECTION).
+func loadDependencies(appConfig config.Config, store storage, withWorker bool) (httpapi.Deps, background, error) {
+	if store.memory != nil {
+		// Хранилище в памяти не разделяется между процессами, поэтому выбирать лидера не из кого.
+		return loadMemoryDependencies(appConfig, store.memory, withWorker)
+	}
+	tierRules, err := loadTierRules(appConfig)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid tier rules")
+		return httpapi.Deps{}, background{}, err
+	}
+	db := store.db
 	authRepo := postgresrepo.NewAuthUserRepository(db)
-	ordersRepo := postgresrepo.NewLoyaltyOrdersRepository(db)
+	ordersRepo := postgresrepo.NewLoyaltyOrdersRepository(db, tierRules, appConfig.TierWindow)
 	accountRepo := postgresrepo.NewLoyaltyAccountRepository(db)
 	withdrawalsRepo := postgresrepo.NewLoyaltyWithdrawalsRepository(db)
+	tierRepo := postgresrepo.NewLoyaltyTierRepository(db)
+	promotionRepo := postgresrepo.NewLoyaltyPromotionRepository(db, tierRules, appConfig.TierWindow)
+	referralRepo := postgresrepo.NewLoyaltyReferralRepository(db)
+	transferRepo := postgresrepo.NewLoyaltyTransferRepository(db)
+	statementRepo := postgresrepo.NewLoyaltyStatementRepository(db)
+	jobRunRepo := postgresrepo.NewSchedulerJobRunRepository(db)
 
 	tokenService := tokensvc.NewTokenService(appConfig.JWTSecret, appConfig.JWTTTL)
 	authService := auth.NewAuthService()
 	numberValidator := ordervalidator.NewValidator()
-	ordersService := ordersappsvc.NewService(ordersRepo, numberValidator)
+	promotionService := promotionappsvc.NewService(promotionRepo)
+	referralService := referralappsvc.NewService(referralRepo, referralmodel.Policy{
+		Bonus:      appConfig.ReferralBonus,
+		MaxRewards: appConfig.ReferralMaxRewards,
+	})
+	ordersService := ordersappsvc.NewService(ordersRepo, numberValidator, promotionService, referralService)
 	balanceService := balanceappsvc.NewService(accountRepo)
 	withdrawalsService := withdrawalsappsvc.NewService(accountRepo, withdrawalsRepo)
+	transferService := transferappsvc.NewService(transferRepo, transfermodel.Limits{
+		MaxSum:   appConfig.TransferDailyLimit,
+		MaxCount: appConfig.TransferDailyCount,
+	})
+	tierService := tierappsvc.NewService(tierRepo, tierRules, appConfig.TierWindow)
+	jobRunService := jobrunappsvc.NewService(jobRunRepo)
 
 	accrualClient := createAccrualClient(appConfig)
-	worker := accrualworker.NewWorker(ordersRepo, ordersService, accrualClient, accrualworker.DefaultConfig())
+	workerConfig := loadWorkerConfig(appConfig)
+	worker := accrualworker.NewWorker(ordersRepo, ordersService, accrualClient, tierService, createOrdersNotifier(appConfig), workerConfig)
+	jobScheduler, err := createScheduler(appConfig, worker, jobRunService, jobRunRepo, statementappsvc.NewReconciliationService(statementRepo))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to register scheduled jobs")
+		return httpapi.Deps{}, background{}, err
+	}
+	elector := createElector(appConfig, worker, jobScheduler, withWorker)
 
 	return httpapi.Deps{
-		AuthUsecase:           authusecase.NewUsecase(user.NewUserService(authRepo), authService, tokenService),
-		OrdersUsecase:         orderusecase.NewUsecase(ordersService),
-		BalanceUsecase:        balanceuc.NewUsecase(balanceService),
-		WithdrawalsUsecase:    withdrawalusecase.NewUsecase(withdrawalsService, numberValidator),
-		TokenService:          tokenService,
-		EnableHTTPBodyLogging: appConfig.EnableHTTPBodyLogging,
-		AuthRateLimitRPS:      appConfig.AuthRateLimitRPS,
-		AuthRateLimitBurst:    appConfig.AuthRateLimitBurst,
-	}, worker
+		AuthUsecase:                 authusecase.NewUsecase(user.NewUserService(authRepo), authService, tokenService, referralService),
+		OrdersUsecase:               orderusecase.NewUsecase(ordersService),
+		BalanceUsecase:              balanceuc.NewUsecase(balanceService),
+		WithdrawalsUsecase:          withdrawalusecase.NewUsecase(withdrawalsService, numberValidator),
+		TierUsecase:                 tieruc.NewUsecase(tierService),
+		PromotionUsecase:            promotionuc.NewUsecase(promotionService),
+		ReferralUsecase:             referraluc.NewUsecase(referralService),
+		TransferUsecase:             transferuc.NewUsecase(transferService),
+		StatementUsecase:            statementuc.NewUsecase(statementappsvc.NewService(statementRepo)),
+		JobRunUsecase:               jobrunuc.NewUsecase(jobRunService),
+		TokenService:                tokenService,
+		AccrualCallbackUsecase:      accrualcallbackuc.NewUsecase(ordersService, tierService),
+		Readiness:                   createReadinessProbe(db, heartbeatWorker(worker, withWorker), elector, workerConfig, accrualClient),
+		AdminToken:                  appConfig.AdminToken,
+		AccrualCallbackSecret:       appConfig.AccrualCallbackSecret,
+		AccrualCallbackReplayWindow: appConfig.AccrualCallbackReplayWindow,
+		EnableHTTPBodyLogging:       appConfig.EnableHTTPBodyLogging,
+		AuthRateLimitRPS:            appConfig.AuthRateLimitRPS,
+		AuthRateLimitBurst:          appConfig.AuthRateLimitBurst,
+		AuthRateLimiter:             ratelimit.NewLimiter(appConfig.AuthRateLimitRPS, appConfig.AuthRateLimitBurst),
+	}, background{worker: worker, scheduler: jobScheduler, elector: elector}, nil
 }
 
 func initLogger(logLevel string) {
@@ -128,22 +285,198 @@ func initLogger(logLevel string) {
 	}
 }
 
-func loadConfig() config.Config {
-	cfg, err := config.LoadConfig()
+// loadWorkerConfig собирает настройки воркера из конфигурации.
+// Нулевые значения (кроме задержки между запросами) берутся из accrualworker.DefaultConfig.
+func loadWorkerConfig(cfg config.Config) accrualworker.Config {
+	workerConfig := accrualworker.DefaultConfig()
+	if cfg.WorkerPollInterval > 0 {
+		workerConfig.PollInterval = cfg.WorkerPollInterval
+	}
+	if cfg.WorkerMaxConcurrency > 0 {
+		workerConfig.MaxConcurrency = cfg.WorkerMaxConcurrency
+	}
+	if cfg.WorkerQueryTimeout > 0 {
+		workerConfig.QueryTimeout = cfg.WorkerQueryTimeout
+	}
+	workerConfig.RequestDelay = cfg.WorkerRequestDelay
+	if cfg.WorkerRetryAfter > 0 {
+		workerConfig.RetryAfterMin = cfg.WorkerRetryAfter
+	}
+	if cfg.WorkerSweepInterval > 0 {
+		workerConfig.SweepInterval = cfg.WorkerSweepInterval
+	}
+	if budget := accrualBudget(cfg); budget > 0 {
+		workerConfig.AccrualTimeout = budget
+	}
+	return workerConfig
+}
+
+// accrualBudget — дедлайн запроса начисления по заказу: все попытки самой медленной системы accrual,
+// но не больше половины SHUTDOWN_TIMEOUT, чтобы при остановке воркер успел дообработать заказ до закрытия БД.
+func accrualBudget(cfg config.Config) time.Duration {
+	budget := accrualClientConfig(cfg, config.AccrualProvider{}).Budget()
+	for _, provider := range cfg.AccrualProviders {
+		budget = max(budget, accrualClientConfig(cfg, provider).Budget())
+	}
+	if cfg.ShutdownTimeout > 0 {
+		budget = min(budget, cfg.ShutdownTimeout/2)
+	}
+	return budget
+}
+
+// createOrdersNotifier создаёт слушателя уведомлений о новых заказах или nil, если уведомления выключены.
+func createOrdersNotifier(cfg config.Config) accrualworker.Notifier {
+	if !cfg.WorkerNotify {
+		return nil
+	}
+	return postgres.NewListener(cfg.DatabaseURI, postgres.OrdersNewChannel)
+}
+
+// loadTierRules преобразует правила уровней из конфигурации в доменные правила.
+func loadTierRules(cfg config.Config) (tiermodel.Rules, error) {
+	rules := make([]tiermodel.Rule, 0, len(cfg.TierRules))
+	for _, rule := range cfg.TierRules {
+		rules = append(rules, tiermodel.Rule{
+			Level:      tiermodel.Level(rule.Name),
+			Threshold:  rule.Threshold,
+			Multiplier: rule.Multiplier,
+		})
+	}
+	tierRules, err := tiermodel.NewRules(rules)
 	if err != nil {
-		log.Error().Err(err).Msg("invalid flags")
-		os.Exit(2)
+		return nil, fmt.Errorf("tier rules: %w", err)
 	}
-	return cfg
+	return tierRules, nil
 }
 
-// createAccrualClient создаёт клиент для системы accrual (HTTP или mock).
+// readinessCheckTimeout — таймаут одной проверки готовности.
+const readinessCheckTimeout = 2 * time.Second
+
+// heartbeatWorker возвращает воркер для проверки heartbeat или nil, если воркер в процессе не запускается.
+func heartbeatWorker(worker *accrualworker.Worker, withWorker bool) *accrualworker.Worker {
+	if !withWorker {
+		return nil
+	}
+	return worker
+}
+
+// createReadinessProbe собирает проверки для /readyz: БД и версия миграций критичны,
+// heartbeat воркера и состояние breaker системы accrual попадают в отчёт как предупреждения.
+// worker может быть nil (воркер в процессе не запускается) — тогда heartbeat не проверяется;
+// elector может быть nil (выбор лидера выключен), иначе heartbeat проверяется только на лидере;
+// db равен nil для хранилища в памяти — тогда не проверяются БД и миграции.
+func createReadinessProbe(
+	db *sql.DB,
+	worker *accrualworker.Worker,
+	elector *leader.Elector,
+	workerConfig accrualworker.Config,
+	accrualClient accrualclient.AccrualClient,
+) *health.Probe {
+	var checks []health.Check
+	if db != nil {
+		checks = append(checks, health.DatabaseCheck(db))
+		expected, err := postgres.LatestMigrationVersion()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to read embedded migrations, migration readiness check disabled")
+		} else {
+			checks = append(checks, health.MigrationCheck(func(ctx context.Context) (uint, bool, error) {
+				return postgres.MigrationVersion(ctx, db)
+			}, expected))
+		}
+	}
+
+	if worker != nil {
+		// Проход воркера может включать паузу после 429 от accrual, поэтому допускаем её сверх интервала опроса.
+		heartbeatMaxAge := 3*worker.TickInterval() + workerConfig.RetryAfterMin
+		heartbeat := health.HeartbeatCheck("accrual_worker", worker.Heartbeat, heartbeatMaxAge)
+		if elector != nil {
+			heartbeat = health.StandbyCheck(heartbeat, elector.IsLeader)
+		}
+		checks = append(checks, heartbeat)
+	}
+
+	for _, provider := range accrualProviders(accrualClient) {
+		if breaker, ok := provider.Client.(interface{ BreakerState() string }); ok {
+			checks = append(checks, health.BreakerCheck(accrualhttp.BreakerName(provider.Name)+"_breaker", breaker.BreakerState))
+		}
+	}
+
+	return health.NewProbe(readinessCheckTimeout, checks...)
+}
+
+// createAccrualClient создаёт клиент для системы accrual (HTTP или mock). Если заданы дополнительные
+// провайдеры (ACCRUAL_PROVIDERS), заказы распределяются между ними маршрутизирующим клиентом,
+// а основная система считает остальные заказы.
 func createAccrualClient(cfg config.Config) accrualclient.AccrualClient {
+	var fallback accrualclient.AccrualClient
 	if cfg.AccrualSystemAddress == "" {
+		fallback = createAccrualMock(cfg.AccrualMockScenario)
+	} else {
+		log.Info().Str("address", cfg.AccrualSystemAddress).Msg("using HTTP accrual client")
+		fallback = accrualhttp.NewClient(accrualclient.DefaultProvider, cfg.AccrualSystemAddress, accrualClientConfig(cfg, config.AccrualProvider{}))
+	}
+	if len(cfg.AccrualProviders) == 0 {
+		return fallback
+	}
+
+	providers := make([]accrualrouter.Provider, 0, len(cfg.AccrualProviders))
+	for _, provider := range cfg.AccrualProviders {
+		log.Info().Str("provider", provider.Name).Str("address", provider.Address).Msg("using HTTP accrual provider")
+		providers = append(providers, accrualrouter.Provider{
+			Name:   provider.Name,
+			Rule:   accrualrouter.Rule{Prefix: provider.Prefix, Length: provider.Length, Pattern: provider.Pattern},
+			Client: accrualhttp.NewClient(provider.Name, provider.Address, accrualClientConfig(cfg, provider)),
+		})
+	}
+	return accrualrouter.NewClient(fallback, providers...)
+}
+
+// createAccrualMock создаёт mock-клиент accrual по сценарию; без сценария (или если его не удалось
+// прочитать) mock отвечает воспроизводимыми случайными статусами.
+func createAccrualMock(scenarioPath string) accrualclient.AccrualClient {
+	if scenarioPath == "" {
 		log.Warn().Msg("accrual system address not configured, using mock client")
 		return accrualmock.NewClientWithDefaults()
 	}
+	scenario, err := accrualmock.LoadScenario(scenarioPath)
+	if err != nil {
+		log.Error().Err(err).Str("scenario", scenarioPath).Msg("failed to load accrual mock scenario, using random answers")
+		return accrualmock.NewClientWithDefaults()
+	}
+	log.Warn().Str("scenario", scenarioPath).Msg("accrual system address not configured, using mock client with scenario")
+	return accrualmock.NewClient(scenario)
+}
+
+// accrualProviders возвращает провайдеры маршрутизирующего клиента или единственный основной провайдер.
+func accrualProviders(accrualClient accrualclient.AccrualClient) []accrualrouter.Provider {
+	if router, ok := accrualClient.(*accrualrouter.Client); ok {
+		return router.Providers()
+	}
+	return []accrualrouter.Provider{{Name: accrualclient.DefaultProvider, Client: accrualClient}}
+}
+
+// accrualClientConfig собирает параметры HTTP-клиента провайдера: незаданные настройки провайдера
+// берутся из параметров основной системы (для основной системы provider пустой), нулевые —
+// из accrualhttp.DefaultConfig.
+func accrualClientConfig(cfg config.Config, provider config.AccrualProvider) accrualhttp.Config {
+	clientConfig := accrualhttp.DefaultConfig()
+	clientConfig.Timeout = cmp.Or(provider.Timeout, cfg.AccrualTimeout)
+	clientConfig.RateLimit.Initial = float64(cmp.Or(provider.RateLimit, cfg.AccrualRateLimit))
+	clientConfig.RateLimit.Max = float64(cmp.Or(provider.RateLimitMax, cfg.AccrualRateLimitMax))
+
+	clientConfig.Breaker.Failures = cmp.Or(uint32(cmp.Or(provider.BreakerFailures, cfg.AccrualBreakerFailures)), clientConfig.Breaker.Failures)
+	clientConfig.Breaker.OpenTimeout = cmp.Or(provider.BreakerOpenTimeout, cfg.AccrualBreakerOpenTimeout, clientConfig.Breaker.OpenTimeout)
+	clientConfig.Breaker.HalfOpenProbes = cmp.Or(uint32(cmp.Or(provider.BreakerHalfOpenProbes, cfg.AccrualBreakerHalfOpenProbes)), clientConfig.Breaker.HalfOpenProbes)
 
-	log.Info().Str("address", cfg.AccrualSystemAddress).Msg("using HTTP accrual client")
-	return accrualhttp.NewClient(cfg.AccrualSystemAddress, 5*time.Second)
+	clientConfig.Retry.Retries = cfg.AccrualRetryCount
+	if provider.RetryCount != nil {
+		clientConfig.Retry.Retries = *provider.RetryCount
+	}
+	clientConfig.Retry.Backoff = cmp.Or(provider.RetryBackoff, cfg.AccrualRetryBackoff, clientConfig.Retry.Backoff)
+	clientConfig.Retry.MaxBackoff = max(clientConfig.Retry.MaxBackoff, clientConfig.Retry.Backoff)
+	clientConfig.Retry.Statuses = cfg.AccrualRetryStatuses
+	if len(provider.RetryStatuses) > 0 {
+		clientConfig.Retry.Statuses = provider.RetryStatuses
+	}
+	return clientConfig
 }
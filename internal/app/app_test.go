@@ -3,7 +3,9 @@ package app
 import (
 	"context"
 	"loyalty/internal/config"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestInitDb_ReturnsErrorOnEmptyDatabaseURI(t *testing.T) {
@@ -13,3 +15,31 @@ func TestInitDb_ReturnsErrorOnEmptyDatabaseURI(t *testing.T) {
 		t.Fatalf("expected error, got nil")
 	}
 }
+
+func TestAccrualClientConfig_InheritsGlobalPolicy(t *testing.T) {
+	cfg := config.Config{
+		AccrualTimeout:         5 * time.Second,
+		AccrualBreakerFailures: 7,
+		AccrualRetryCount:      2,
+		AccrualRetryBackoff:    3 * time.Second,
+		AccrualRetryStatuses:   []int{503},
+	}
+	noRetries := 0
+
+	global := accrualClientConfig(cfg, config.AccrualProvider{})
+	if global.Breaker.Failures != 7 || global.Breaker.HalfOpenProbes != 3 || global.Retry.Retries != 2 ||
+		global.Retry.Backoff != 3*time.Second || global.Retry.MaxBackoff != 3*time.Second || !slices.Equal(global.Retry.Statuses, []int{503}) {
+		t.Fatalf("unexpected global config: %+v", global)
+	}
+
+	provider := accrualClientConfig(cfg, config.AccrualProvider{
+		Timeout:         time.Second,
+		BreakerFailures: 1,
+		RetryCount:      &noRetries,
+		RetryStatuses:   []int{500},
+	})
+	if provider.Timeout != time.Second || provider.Breaker.Failures != 1 || provider.Retry.Retries != 0 ||
+		provider.Retry.Backoff != 3*time.Second || !slices.Equal(provider.Retry.Statuses, []int{500}) {
+		t.Fatalf("unexpected provider config: %+v", provider)
+	}
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"loyalty/internal/adapter/postgres"
+	"loyalty/internal/config"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Подкоманды CLI.
+const (
+	commandServe   = "serve"
+	commandWorker  = "worker"
+	commandAll     = "all"
+	commandMigrate = "migrate"
+	commandAdmin   = "admin"
+)
+
+// Действия подкоманды migrate.
+const (
+	migrateUp      = "up"
+	migrateDown    = "down"
+	migrateVersion = "version"
+	migrateForce   = "force"
+)
+
+// ErrUsage возвращается при некорректном вызове CLI (неизвестная подкоманда или аргументы).
+var ErrUsage = errors.New("invalid usage")
+
+// usage — справка по подкомандам.
+const usage = `usage: loyalty [command] [flags]
+
+commands:
+  all                          API and accrual worker in one process (default)
+  serve                        API only
+  worker                       accrual worker only (serves /livez, /readyz, /metrics)
+  migrate up                   apply pending migrations
+  migrate down [N]             roll back N migrations (default 1)
+  migrate version              print current schema version
+  migrate force VERSION        set schema version and clear dirty flag without migrating
+  admin ACTION [flags] [-- config flags]
+                               support tasks: user, orders, withdrawals, requeue, adjust, dump
+
+flags: every setting has a flag, an env variable and a config file key (see README).
+`
+
+// serviceMode — набор компонентов, запускаемых в процессе.
+type serviceMode struct {
+	name   string
+	api    bool
+	worker bool
+}
+
+var serviceModes = map[string]serviceMode{
+	commandAll:    {name: commandAll, api: true, worker: true},
+	commandServe:  {name: commandServe, api: true},
+	commandWorker: {name: commandWorker, worker: true},
+}
+
+// Run разбирает подкоманду из args (аргументы командной строки без имени программы) и выполняет её.
+// Без подкоманды (или если первый аргумент — флаг) запускаются API и воркер, как команда all.
+func Run(ctx context.Context, args []string) error {
+	command, rest := parseCommand(args)
+	if mode, ok := serviceModes[command]; ok {
+		return runService(ctx, mode, rest)
+	}
+	switch command {
+	case commandMigrate:
+		return runMigrate(ctx, rest, os.Stdout)
+	case commandAdmin:
+		return runAdmin(ctx, rest, os.Stdout)
+	case "help", "-h", "-help", "--help":
+		_, err := fmt.Fprint(os.Stdout, usage)
+		return err
+	default:
+		_, _ = fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, command)
+	}
+}
+
+// parseCommand отделяет подкоманду от её флагов.
+func parseCommand(args []string) (string, []string) {
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && !isHelp(args[0])) {
+		return commandAll, args
+	}
+	return args[0], args[1:]
+}
+
+func isHelp(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+// migrateArgs — разобранные аргументы подкоманды migrate.
+type migrateArgs struct {
+	action  string
+	steps   int
+	version int
+	flags   []string
+}
+
+// parseMigrateArgs разбирает "ACTION [N|VERSION] [flags]".
+func parseMigrateArgs(args []string) (migrateArgs, error) {
+	if len(args) == 0 {
+		return migrateArgs{}, fmt.Errorf("%w: migrate requires an action (up|down|version|force)", ErrUsage)
+	}
+	parsed := migrateArgs{action: args[0], steps: 1, flags: args[1:]}
+
+	switch parsed.action {
+	case migrateUp, migrateVersion:
+	case migrateDown:
+		if len(parsed.flags) > 0 && !strings.HasPrefix(parsed.flags[0], "-") {
+			steps, err := strconv.Atoi(parsed.flags[0])
+			if err != nil || steps <= 0 {
+				return migrateArgs{}, fmt.Errorf("%w: migrate down expects a positive number of steps, got %q", ErrUsage, parsed.flags[0])
+			}
+			parsed.steps, parsed.flags = steps, parsed.flags[1:]
+		}
+	case migrateForce:
+		if len(parsed.flags) == 0 {
+			return migrateArgs{}, fmt.Errorf("%w: migrate force requires a version", ErrUsage)
+		}
+		version, err := strconv.Atoi(parsed.flags[0])
+		if err != nil || version < -1 {
+			return migrateArgs{}, fmt.Errorf("%w: migrate force expects a version >= -1, got %q", ErrUsage, parsed.flags[0])
+		}
+		parsed.version, parsed.flags = version, parsed.flags[1:]
+	default:
+		return migrateArgs{}, fmt.Errorf("%w: unknown migrate action %q", ErrUsage, parsed.action)
+	}
+	return parsed, nil
+}
+
+// cliPoolConfig — пул соединений для служебных подкоманд (migrate, admin).
+var cliPoolConfig = postgres.PoolConfig{
+	MaxOpenConns:    2,
+	MaxIdleConns:    1,
+	ConnMaxLifetime: 5 * time.Minute,
+	ConnMaxIdleTime: time.Minute,
+}
+
+// runMigrate выполняет подкоманду migrate; версия схемы выводится в out.
+func runMigrate(ctx context.Context, args []string, out io.Writer) error {
+	parsed, err := parseMigrateArgs(args)
+	if err != nil {
+		_, _ = fmt.Fprint(os.Stderr, usage)
+		return err
+	}
+	appConfig, err := config.LoadArgs(parsed.flags)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid configuration")
+		return err
+	}
+	initLogger(appConfig.LogLevel)
+
+	db, err := postgres.Open(ctx, appConfig.DatabaseURI, cliPoolConfig)
+	if err != nil {
+		log.Error().Err(err).Msg("database init failed")
+		return err
+	}
+
+	switch parsed.action {
+	case migrateUp:
+		err = postgres.ApplyMigrations(db)
+	case migrateDown:
+		err = postgres.RollbackMigrations(db, parsed.steps)
+	case migrateForce:
+		err = postgres.ForceMigrationVersion(db, parsed.version)
+	case migrateVersion:
+		defer func() { _ = db.Close() }()
+		return printMigrationVersion(ctx, db, out)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("action", parsed.action).Msg("migration failed")
+		return err
+	}
+	log.Info().Str("action", parsed.action).Msg("migration finished")
+
+	// Миграции закрывают своё подключение; версию читаем через новое.
+	db, err = postgres.Open(ctx, appConfig.DatabaseURI, cliPoolConfig)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	return printMigrationVersion(ctx, db, out)
+}
+
+func printMigrationVersion(ctx context.Context, db *sql.DB, out io.Writer) error {
+	version, dirty, err := postgres.MigrationVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	latest, err := postgres.LatestMigrationVersion()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "version: %d\ndirty: %t\nlatest: %d\n", version, dirty, latest)
+	return err
+}
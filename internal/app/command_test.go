@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCmd  string
+		wantRest []string
+	}{
+		{name: "no args", args: nil, wantCmd: commandAll, wantRest: nil},
+		{name: "legacy flags only", args: []string{"-a", ":8080"}, wantCmd: commandAll, wantRest: []string{"-a", ":8080"}},
+		{name: "serve with flags", args: []string{"serve", "-d", "postgres://x"}, wantCmd: commandServe, wantRest: []string{"-d", "postgres://x"}},
+		{name: "worker", args: []string{"worker"}, wantCmd: commandWorker, wantRest: []string{}},
+		{name: "help flag", args: []string{"-h"}, wantCmd: "-h", wantRest: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, rest := parseCommand(tt.args)
+			if cmd != tt.wantCmd || !reflect.DeepEqual(rest, tt.wantRest) {
+				t.Fatalf("parseCommand(%v) = %q %v, want %q %v", tt.args, cmd, rest, tt.wantCmd, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestServiceModes(t *testing.T) {
+	if mode := serviceModes[commandServe]; !mode.api || mode.worker {
+		t.Fatalf("serve must run API only: %+v", mode)
+	}
+	if mode := serviceModes[commandWorker]; mode.api || !mode.worker {
+		t.Fatalf("worker must run worker only: %+v", mode)
+	}
+	if mode := serviceModes[commandAll]; !mode.api || !mode.worker {
+		t.Fatalf("all must run API and worker: %+v", mode)
+	}
+}
+
+func TestParseMigrateArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    migrateArgs
+		wantErr bool
+	}{
+		{name: "up", args: []string{"up", "-d", "postgres://x"}, want: migrateArgs{action: migrateUp, steps: 1, flags: []string{"-d", "postgres://x"}}},
+		{name: "down default", args: []string{"down"}, want: migrateArgs{action: migrateDown, steps: 1, flags: []string{}}},
+		{name: "down steps", args: []string{"down", "2", "-d", "x"}, want: migrateArgs{action: migrateDown, steps: 2, flags: []string{"-d", "x"}}},
+		{name: "version", args: []string{"version"}, want: migrateArgs{action: migrateVersion, steps: 1, flags: []string{}}},
+		{name: "force", args: []string{"force", "4"}, want: migrateArgs{action: migrateForce, steps: 1, version: 4, flags: []string{}}},
+		{name: "force nil version", args: []string{"force", "-1"}, want: migrateArgs{action: migrateForce, steps: 1, version: -1, flags: []string{}}},
+		{name: "no action", args: nil, wantErr: true},
+		{name: "unknown action", args: []string{"sideways"}, wantErr: true},
+		{name: "down zero", args: []string{"down", "0"}, wantErr: true},
+		{name: "force without version", args: []string{"force"}, wantErr: true},
+		{name: "force bad version", args: []string{"force", "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMigrateArgs(tt.args)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUsage) {
+					t.Fatalf("expected ErrUsage, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseMigrateArgs(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	if err := Run(context.Background(), []string{"deploy"}); !errors.Is(err, ErrUsage) {
+		t.Fatalf("expected ErrUsage, got %v", err)
+	}
+}
+
+func TestSplitAdminArgs(t *testing.T) {
+	action, flags := splitAdminArgs([]string{"user", "-login", "alice", "--", "-d", "postgres://x"})
+	if !reflect.DeepEqual(action, []string{"user", "-login", "alice"}) || !reflect.DeepEqual(flags, []string{"-d", "postgres://x"}) {
+		t.Fatalf("unexpected split: %v %v", action, flags)
+	}
+
+	action, flags = splitAdminArgs([]string{"dump", "-user-id", "7"})
+	if len(action) != 3 || flags != nil {
+		t.Fatalf("expected no config flags without separator: %v %v", action, flags)
+	}
+}
+
+func TestRun_AdminInvalidAction(t *testing.T) {
+	if err := Run(context.Background(), []string{"admin", "adjust", "-login", "alice", "-amount", "10"}); !errors.Is(err, ErrUsage) {
+		t.Fatalf("expected ErrUsage for adjust without reason, got %v", err)
+	}
+}
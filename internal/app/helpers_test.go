@@ -1,9 +1,16 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"loyalty/internal/config"
+	accrualclient "loyalty/internal/domain/accrual/client"
+	tiermodel "loyalty/internal/domain/tier/model"
+	accrualworker "loyalty/internal/worker/accrual"
 	"testing"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Тесты для helper функций, которые можно протестировать без побочных эффектов
@@ -18,7 +25,10 @@ func TestLoadDependencies(t *testing.T) {
 	}
 
 	// Mock DB (nil допустимо для теста конструкторов)
-	deps, _ := loadDependencies(cfg, nil)
+	deps, _, err := loadDependencies(cfg, storage{}, true)
+	if err != nil {
+		t.Fatalf("loadDependencies() error = %v", err)
+	}
 
 	if deps.AuthUsecase == nil {
 		t.Error("loadDependencies() AuthUsecase is nil")
@@ -32,11 +42,53 @@ func TestLoadDependencies(t *testing.T) {
 	if deps.WithdrawalsUsecase == nil {
 		t.Error("loadDependencies() WithdrawalsUsecase is nil")
 	}
+	if deps.TierUsecase == nil {
+		t.Error("loadDependencies() TierUsecase is nil")
+	}
+	if deps.PromotionUsecase == nil {
+		t.Error("loadDependencies() PromotionUsecase is nil")
+	}
+	if deps.ReferralUsecase == nil {
+		t.Error("loadDependencies() ReferralUsecase is nil")
+	}
+	if deps.TransferUsecase == nil {
+		t.Error("loadDependencies() TransferUsecase is nil")
+	}
+	if deps.StatementUsecase == nil {
+		t.Error("loadDependencies() StatementUsecase is nil")
+	}
+	if deps.JobRunUsecase == nil {
+		t.Error("loadDependencies() JobRunUsecase is nil")
+	}
 	if deps.TokenService == nil {
 		t.Error("loadDependencies() TokenService is nil")
 	}
 }
 
+func TestLoadDependencies_InvalidTierRules(t *testing.T) {
+	cfg := config.Config{
+		JWTSecret: "test-secret",
+		JWTTTL:    time.Hour,
+		TierRules: []config.TierRule{{Name: "GOLD", Threshold: decimal.Zero, Multiplier: decimal.NewFromInt(1)}},
+	}
+
+	if _, _, err := loadDependencies(cfg, storage{}, true); !errors.Is(err, tiermodel.ErrInvalidRules) {
+		t.Fatalf("loadDependencies() error = %v, want %v", err, tiermodel.ErrInvalidRules)
+	}
+}
+
+func TestLoadDependencies_InvalidJobSchedule(t *testing.T) {
+	cfg := config.Config{
+		JWTSecret:                  "test-secret",
+		JWTTTL:                     time.Hour,
+		SchedulerReconcileSchedule: "not a schedule",
+	}
+
+	if _, _, err := loadDependencies(cfg, storage{}, true); err == nil {
+		t.Fatal("loadDependencies() must return an error for an invalid job schedule")
+	}
+}
+
 func TestCreateAccrualClient(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -66,5 +118,67 @@ func TestCreateAccrualClient(t *testing.T) {
 	}
 }
 
+func TestCreateAccrualClient_Providers(t *testing.T) {
+	client := createAccrualClient(config.Config{
+		AccrualSystemAddress: "http://accrual:8080",
+		AccrualTimeout:       time.Second,
+		AccrualRateLimit:     100,
+		AccrualRateLimitMax:  200,
+		AccrualProviders: []config.AccrualProvider{
+			{Name: "merchant2", Address: "http://accrual-2:8080", Prefix: "42"},
+		},
+	})
+
+	resolver, ok := client.(accrualclient.ProviderResolver)
+	if !ok {
+		t.Fatalf("want routing client, got %T", client)
+	}
+	if got := resolver.Provider("4212345"); got != "merchant2" {
+		t.Fatalf("want merchant2 for prefix 42, got %q", got)
+	}
+	if got := resolver.Provider("79927398713"); got != accrualclient.DefaultProvider {
+		t.Fatalf("want default provider, got %q", got)
+	}
+
+	report := createReadinessProbe(nil, nil, nil, accrualworker.DefaultConfig(), client).Ready(context.Background())
+	_, mainBreaker := report.Checks["accrual_breaker"]
+	_, merchantBreaker := report.Checks["accrual_merchant2_breaker"]
+	if !mainBreaker || !merchantBreaker {
+		t.Fatalf("want a breaker check per provider, got %+v", report.Checks)
+	}
+}
+
 // initLogger и loadConfig не тестируются напрямую,
 // т.к. они вызывают os.Exit(2) при ошибках
+
+func TestLoadWorkerConfig(t *testing.T) {
+	defaults := loadWorkerConfig(config.Config{})
+	if defaults.PollInterval != 5*time.Second || defaults.MaxConcurrency != 5 {
+		t.Fatalf("expected worker defaults, got %+v", defaults)
+	}
+
+	got := loadWorkerConfig(config.Config{
+		WorkerPollInterval:   time.Second,
+		WorkerMaxConcurrency: 12,
+		WorkerQueryTimeout:   2 * time.Second,
+		WorkerRequestDelay:   0,
+		WorkerRetryAfter:     30 * time.Second,
+	})
+	if got.PollInterval != time.Second || got.MaxConcurrency != 12 || got.QueryTimeout != 2*time.Second {
+		t.Fatalf("unexpected worker config: %+v", got)
+	}
+	if got.RequestDelay != 0 || got.RetryAfterMin != 30*time.Second {
+		t.Fatalf("unexpected worker config: %+v", got)
+	}
+
+	// Дедлайн запроса начисления покрывает все попытки, но укладывается в половину SHUTDOWN_TIMEOUT.
+	cfg := config.Config{AccrualTimeout: time.Second, AccrualRetryCount: 1, AccrualRetryBackoff: 500 * time.Millisecond}
+	if got := loadWorkerConfig(cfg).AccrualTimeout; got != 2500*time.Millisecond {
+		t.Fatalf("unexpected accrual timeout: %v", got)
+	}
+	cfg.AccrualProviders = []config.AccrualProvider{{Name: "slow", Timeout: 3 * time.Second}}
+	cfg.ShutdownTimeout = 4 * time.Second
+	if got := loadWorkerConfig(cfg).AccrualTimeout; got != 2*time.Second {
+		t.Fatalf("accrual timeout must fit into the shutdown budget, got %v", got)
+	}
+}
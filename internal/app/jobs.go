@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"loyalty/internal/adapter/postgres"
+	"loyalty/internal/config"
+	jobrunsvc "loyalty/internal/domain/jobrun/service"
+	statementsvc "loyalty/internal/domain/statement/service"
+	"loyalty/internal/leader"
+	"loyalty/internal/scheduler"
+	accrualworker "loyalty/internal/worker/accrual"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Имена фоновых задач планировщика (метка метрик и значение job в журнале запусков).
+const (
+	jobAccrualSweep          = "accrual_sweep"
+	jobBalanceReconciliation = "balance_reconciliation"
+	jobRunsCleanup           = "job_runs_cleanup"
+)
+
+// Таймауты одного запуска фоновых задач.
+const (
+	accrualSweepTimeout          = time.Minute
+	balanceReconciliationTimeout = 10 * time.Minute
+	jobRunsCleanupTimeout        = 5 * time.Minute
+)
+
+// maxLoggedMismatches ограничивает число расхождений балансов, выводимых в лог за одну сверку.
+const maxLoggedMismatches = 20
+
+// background — фоновые задачи процесса: воркер accrual и планировщик, который запускает его
+// страховочный опрос и периодические задачи. elector задан, если включён выбор лидера:
+// тогда воркер и планировщик работают синглтонами только на лидере.
+type background struct {
+	worker    *accrualworker.Worker
+	scheduler *scheduler.Scheduler
+	elector   *leader.Elector
+}
+
+// Start запускает фоновые задачи и возвращает канал, который закрывается после остановки всех задач.
+func (jobs background) Start(ctx context.Context) <-chan struct{} {
+	if jobs.elector != nil {
+		return jobs.elector.Start(ctx)
+	}
+	workerDone := jobs.worker.Start(ctx)
+	schedulerDone := jobs.scheduler.Start(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-workerDone
+		<-schedulerDone
+	}()
+	return done
+}
+
+// createElector создаёт выбор лидера на advisory lock PostgreSQL и регистрирует воркер accrual
+// и планировщик синглтонами. Возвращает nil, если выбор лидера выключен или фоновые задачи
+// в процессе не запускаются.
+func createElector(cfg config.Config, worker *accrualworker.Worker, jobScheduler *scheduler.Scheduler, withWorker bool) *leader.Elector {
+	if !cfg.LeaderElection || !withWorker {
+		return nil
+	}
+	elector := leader.NewElector(postgres.NewAdvisoryLock(cfg.DatabaseURI, postgres.LeaderLockKey), cfg.LeaderRenewInterval)
+	elector.Singleton("accrual_worker", worker.Start)
+	elector.Singleton("scheduler", jobScheduler.Start)
+	return elector
+}
+
+// createScheduler регистрирует фоновые задачи: страховочный опрос воркера accrual с интервалом
+// TickInterval (в журнал записываются только его неуспешные запуски), а также сверку балансов и очистку журнала запусков по расписаниям из конфигурации.
+// jobRuns и reconciliation могут быть nil (хранилище в памяти) — тогда запуски не записываются
+// в журнал, а сверка и очистка не регистрируются; пустое расписание тоже отключает задачу.
+func createScheduler(
+	cfg config.Config,
+	worker *accrualworker.Worker,
+	jobRuns jobrunsvc.JobRunService,
+	recorder scheduler.Recorder,
+	reconciliation statementsvc.ReconciliationService,
+) (*scheduler.Scheduler, error) {
+	jobScheduler := scheduler.New(recorder)
+	if err := jobScheduler.Register(scheduler.Job{
+		Name:     jobAccrualSweep,
+		Schedule: scheduler.EveryFunc(worker.TickInterval),
+		Timeout:  accrualSweepTimeout,
+		Journal:  scheduler.JournalFailures,
+		Run:      worker.Sweep,
+	}); err != nil {
+		return nil, fmt.Errorf("register %s: %w", jobAccrualSweep, err)
+	}
+	if reconciliation != nil && cfg.SchedulerReconcileSchedule != "" {
+		if err := registerScheduled(jobScheduler, jobBalanceReconciliation, cfg.SchedulerReconcileSchedule,
+			balanceReconciliationTimeout, reconcileBalances(reconciliation)); err != nil {
+			return nil, err
+		}
+	}
+	if jobRuns != nil && cfg.SchedulerCleanupSchedule != "" {
+		if err := registerScheduled(jobScheduler, jobRunsCleanup, cfg.SchedulerCleanupSchedule,
+			jobRunsCleanupTimeout, cleanupJobRuns(jobRuns, cfg.SchedulerRunRetention)); err != nil {
+			return nil, err
+		}
+	}
+	return jobScheduler, nil
+}
+
+// registerScheduled регистрирует задачу name с расписанием spec.
+func registerScheduled(jobScheduler *scheduler.Scheduler, name, spec string, timeout time.Duration, run func(ctx context.Context) error) error {
+	schedule, err := scheduler.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("parse %s schedule: %w", name, err)
+	}
+	if err := jobScheduler.Register(scheduler.Job{Name: name, Schedule: schedule, Timeout: timeout, Run: run}); err != nil {
+		return fmt.Errorf("register %s: %w", name, err)
+	}
+	return nil
+}
+
+// reconcileBalances возвращает задачу сверки: расхождения пишутся в лог, а запуск завершается ошибкой.
+func reconcileBalances(reconciliation statementsvc.ReconciliationService) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		mismatches, err := reconciliation.Reconcile(ctx)
+		for i, mismatch := range mismatches {
+			if i == maxLoggedMismatches {
+				zerolog.Ctx(ctx).Error().Int("more", len(mismatches)-i).Msg("more balance mismatches omitted")
+				break
+			}
+			zerolog.Ctx(ctx).Error().
+				Int64("user_id", mismatch.UserID).
+				Str("ledger", mismatch.Ledger.String()).
+				Str("current", mismatch.Current.String()).
+				Msg("balance does not match statement")
+		}
+		return err
+	}
+}
+
+// cleanupJobRuns возвращает задачу удаления записей журнала запусков старше retention.
+func cleanupJobRuns(jobRuns jobrunsvc.JobRunService, retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		deleted, err := jobRuns.Cleanup(ctx, retention)
+		if err != nil {
+			return err
+		}
+		zerolog.Ctx(ctx).Info().Int64("deleted", deleted).Dur("retention", retention).Msg("job runs cleaned up")
+		return nil
+	}
+}
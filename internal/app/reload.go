@@ -0,0 +1,110 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"loyalty/internal/adapter/postgres/util"
+	"loyalty/internal/config"
+	"loyalty/internal/controller/httpapi/common/middleware/ratelimit"
+	"loyalty/internal/logger"
+	"loyalty/internal/scheduler"
+	accrualworker "loyalty/internal/worker/accrual"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// errRestartRequired возвращается, если перечитанная конфигурация меняет параметры,
+// которые нельзя применить без перезапуска.
+var errRestartRequired = errors.New("config change requires restart")
+
+// reloadableKeys — параметры, которые применяются на лету по SIGHUP.
+var reloadableKeys = map[string]struct{}{
+	"log.level":              {},
+	"auth.rate_limit_rps":    {},
+	"auth.rate_limit_burst":  {},
+	"worker.poll_interval":   {},
+	"worker.max_concurrency": {},
+	"database.query_timeout": {},
+}
+
+// configReloader перечитывает конфигурацию и применяет изменения, не требующие перезапуска.
+// Изменения применяются целиком или не применяются вовсе: если среди них есть параметр,
+// требующий перезапуска, перезагрузка отклоняется и действующая конфигурация не меняется.
+type configReloader struct {
+	mu      sync.Mutex
+	current config.Config
+	load    func() (config.Config, error)
+	limiter *ratelimit.Limiter
+	worker  *accrualworker.Worker
+	// scheduler пересчитывает время опроса воркера после смены интервала.
+	scheduler *scheduler.Scheduler
+}
+
+// newConfigReloader создаёт reloader. limiter, worker и jobScheduler могут быть nil — соответствующие
+// параметры тогда только запоминаются.
+func newConfigReloader(
+	current config.Config,
+	load func() (config.Config, error),
+	limiter *ratelimit.Limiter,
+	worker *accrualworker.Worker,
+	jobScheduler *scheduler.Scheduler,
+) *configReloader {
+	return &configReloader{current: current, load: load, limiter: limiter, worker: worker, scheduler: jobScheduler}
+}
+
+// reload перечитывает конфигурацию и применяет допустимые изменения.
+func (reloader *configReloader) reload() error {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	next, err := reloader.load()
+	if err != nil {
+		log.Error().Err(err).Msg("config reload failed, keeping current configuration")
+		return err
+	}
+	// Случайный секрет генерируется при каждой загрузке; действующий остаётся в силе.
+	if next.JWTSecretGenerated && reloader.current.JWTSecretGenerated {
+		next.JWTSecret = reloader.current.JWTSecret
+	}
+
+	changes := config.Diff(reloader.current, next)
+	if len(changes) == 0 {
+		log.Info().Msg("config reloaded, nothing changed")
+		return nil
+	}
+
+	var restartOnly []string
+	for _, change := range changes {
+		if _, ok := reloadableKeys[change.Key]; !ok {
+			restartOnly = append(restartOnly, change.Key)
+		}
+	}
+	if len(restartOnly) > 0 {
+		log.Error().Strs("keys", restartOnly).Msg("config reload rejected: changes require restart")
+		return fmt.Errorf("%w: %s", errRestartRequired, strings.Join(restartOnly, ", "))
+	}
+
+	if err := logger.SetLevel(next.LogLevel); err != nil {
+		log.Error().Err(err).Msg("config reload failed, keeping current configuration")
+		return err
+	}
+	util.SetQueryTimeout(next.DBQueryTimeout)
+	if reloader.limiter != nil {
+		reloader.limiter.SetLimits(next.AuthRateLimitRPS, next.AuthRateLimitBurst)
+	}
+	if reloader.worker != nil {
+		workerConfig := loadWorkerConfig(next)
+		reloader.worker.Reconfigure(workerConfig.PollInterval, workerConfig.MaxConcurrency)
+	}
+	if reloader.scheduler != nil {
+		reloader.scheduler.Reschedule()
+	}
+
+	for _, change := range changes {
+		log.Info().Str("key", change.Key).Str("old", change.Old).Str("new", change.New).Msg("config value reloaded")
+	}
+	reloader.current = next
+	return nil
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"errors"
+	"loyalty/internal/adapter/postgres/util"
+	"loyalty/internal/config"
+	"loyalty/internal/controller/httpapi/common/middleware/ratelimit"
+	accrualworker "loyalty/internal/worker/accrual"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func reloadTestConfig() config.Config {
+	return config.Config{
+		DatabaseURI:          "postgres://localhost/loyalty",
+		JWTSecret:            "generated",
+		JWTSecretGenerated:   true,
+		DBQueryTimeout:       3 * time.Second,
+		AuthRateLimitRPS:     100,
+		AuthRateLimitBurst:   20,
+		LogLevel:             "info",
+		WorkerPollInterval:   5 * time.Second,
+		WorkerMaxConcurrency: 5,
+	}
+}
+
+func TestConfigReloader_AppliesRuntimeSettings(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	defer util.SetQueryTimeout(util.QueryTimeout())
+
+	current := reloadTestConfig()
+	next := current
+	next.JWTSecret = "generated-again"
+	next.LogLevel = "error"
+	next.AuthRateLimitRPS = 7
+	next.AuthRateLimitBurst = 3
+	next.WorkerPollInterval = time.Second
+	next.WorkerMaxConcurrency = 11
+	next.DBQueryTimeout = 1500 * time.Millisecond
+
+	limiter := ratelimit.NewLimiter(current.AuthRateLimitRPS, current.AuthRateLimitBurst)
+	worker := accrualworker.NewWorker(nil, nil, nil, nil, nil, loadWorkerConfig(current))
+	reloader := newConfigReloader(current, func() (config.Config, error) { return next, nil }, limiter, worker, nil)
+
+	if err := reloader.reload(); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if zerolog.GlobalLevel() != zerolog.ErrorLevel {
+		t.Fatalf("log level not applied: %v", zerolog.GlobalLevel())
+	}
+	if util.QueryTimeout() != 1500*time.Millisecond {
+		t.Fatalf("query timeout not applied: %v", util.QueryTimeout())
+	}
+	if rps, burst := limiter.Limits(); rps != 7 || burst != 3 {
+		t.Fatalf("rate limit not applied: rps=%d burst=%d", rps, burst)
+	}
+	if worker.PollInterval() != time.Second || worker.MaxConcurrency() != 11 {
+		t.Fatalf("worker settings not applied: poll=%v concurrency=%d", worker.PollInterval(), worker.MaxConcurrency())
+	}
+	if reloader.current.JWTSecret != "generated" {
+		t.Fatalf("generated JWT secret must be kept, got %q", reloader.current.JWTSecret)
+	}
+}
+
+func TestConfigReloader_RejectsRestartOnlyChanges(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+
+	current := reloadTestConfig()
+	next := current
+	next.LogLevel = "error"
+	next.AuthRateLimitRPS = 1
+	next.RunAddress = ":9999"
+
+	limiter := ratelimit.NewLimiter(current.AuthRateLimitRPS, current.AuthRateLimitBurst)
+	reloader := newConfigReloader(current, func() (config.Config, error) { return next, nil }, limiter, nil, nil)
+	level := zerolog.GlobalLevel()
+
+	err := reloader.reload()
+	if !errors.Is(err, errRestartRequired) {
+		t.Fatalf("expected errRestartRequired, got %v", err)
+	}
+	if rps, _ := limiter.Limits(); rps != 100 {
+		t.Fatalf("rejected reload must not change rate limit, got %d", rps)
+	}
+	if zerolog.GlobalLevel() != level {
+		t.Fatalf("rejected reload must not change log level")
+	}
+	if reloader.current.RunAddress != "" {
+		t.Fatalf("rejected reload must keep current config")
+	}
+}
+
+func TestConfigReloader_KeepsConfigOnLoadError(t *testing.T) {
+	current := reloadTestConfig()
+	loadErr := errors.New("bad file")
+	reloader := newConfigReloader(current, func() (config.Config, error) { return config.Config{}, loadErr }, nil, nil, nil)
+
+	if err := reloader.reload(); !errors.Is(err, loadErr) {
+		t.Fatalf("expected load error, got %v", err)
+	}
+	if reloader.current.DatabaseURI != current.DatabaseURI {
+		t.Fatalf("current config must be kept on load error")
+	}
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"loyalty/internal/health"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// shutdownStep — этап упорядоченной остановки приложения.
+type shutdownStep struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// httpServer — часть *http.Server, нужная для остановки.
+type httpServer interface {
+	Shutdown(ctx context.Context) error
+}
+
+// runShutdown выполняет этапы по порядку с общим дедлайном timeout.
+// Ошибка этапа логируется и не прерывает остановку: последующие этапы (закрытие БД) выполняются всегда.
+func runShutdown(timeout time.Duration, steps ...shutdownStep) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var errs []error
+	for _, step := range steps {
+		startedAt := time.Now()
+		err := step.run(ctx)
+		event := log.Info()
+		if err != nil {
+			event = log.Error().Err(err)
+			errs = append(errs, fmt.Errorf("%s: %w", step.name, err))
+		}
+		event.Str("step", step.name).Dur("elapsed", time.Since(startedAt)).Msg("shutdown step finished")
+	}
+	return errors.Join(errs...)
+}
+
+// stopTrafficStep переводит /readyz в «не готов» и ждёт delay, чтобы балансировщик убрал инстанс из ротации.
+func stopTrafficStep(probe *health.Probe, delay time.Duration) shutdownStep {
+	return shutdownStep{name: "stop_traffic", run: func(ctx context.Context) error {
+		probe.Shutdown()
+		if delay <= 0 {
+			return nil
+		}
+		log.Info().Dur("drain_delay", delay).Msg("readiness switched off, draining traffic")
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}}
+}
+
+// drainHTTPStep перестаёт принимать соединения и ждёт завершения активных запросов.
+func drainHTTPStep(server httpServer) shutdownStep {
+	return shutdownStep{name: "drain_http", run: server.Shutdown}
+}
+
+// drainWorkerStep останавливает воркер и ждёт дообработки заказов, уже отправленных в accrual.
+func drainWorkerStep(stop context.CancelFunc, done <-chan struct{}) shutdownStep {
+	return shutdownStep{name: "drain_worker", run: func(ctx context.Context) error {
+		stop()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("accrual worker not drained: %w", ctx.Err())
+		}
+	}}
+}
+
+// closeDatabaseStep закрывает пул соединений с БД; выполняется последним.
+func closeDatabaseStep(db io.Closer) shutdownStep {
+	return shutdownStep{name: "close_database", run: func(context.Context) error {
+		return db.Close()
+	}}
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"loyalty/internal/health"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// shutdownRecorder фиксирует порядок событий остановки.
+type shutdownRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (recorder *shutdownRecorder) record(event string) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.events = append(recorder.events, event)
+}
+
+func (recorder *shutdownRecorder) list() []string {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return append([]string(nil), recorder.events...)
+}
+
+type fakeServer struct {
+	recorder *shutdownRecorder
+	probe    *health.Probe
+}
+
+func (server *fakeServer) Shutdown(ctx context.Context) error {
+	if server.probe.Ready(ctx).Ready() {
+		server.recorder.record("http drained while ready")
+		return nil
+	}
+	server.recorder.record("http drained")
+	return nil
+}
+
+type fakeDB struct {
+	recorder *shutdownRecorder
+}
+
+func (db *fakeDB) Close() error {
+	db.recorder.record("db closed")
+	return nil
+}
+
+// fakeWorker имитирует воркер, который дообрабатывает заказ в течение busy после остановки.
+func fakeWorker(recorder *shutdownRecorder, busy time.Duration) (context.CancelFunc, <-chan struct{}) {
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		recorder.record("worker stopping")
+		time.Sleep(busy)
+		recorder.record("worker drained")
+	}()
+	return stop, done
+}
+
+func TestRunShutdown_Order(t *testing.T) {
+	recorder := &shutdownRecorder{}
+	probe := health.NewProbe(time.Second)
+	stopWorker, workerDone := fakeWorker(recorder, 10*time.Millisecond)
+
+	err := runShutdown(time.Second,
+		stopTrafficStep(probe, time.Millisecond),
+		drainHTTPStep(&fakeServer{recorder: recorder, probe: probe}),
+		drainWorkerStep(stopWorker, workerDone),
+		closeDatabaseStep(&fakeDB{recorder: recorder}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	want := []string{"http drained", "worker stopping", "worker drained", "db closed"}
+	if got := recorder.list(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected shutdown order:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestRunShutdown_WorkerDeadlineStillClosesDatabase(t *testing.T) {
+	recorder := &shutdownRecorder{}
+	stopWorker, workerDone := fakeWorker(recorder, time.Hour)
+
+	startedAt := time.Now()
+	err := runShutdown(20*time.Millisecond,
+		drainWorkerStep(stopWorker, workerDone),
+		closeDatabaseStep(&fakeDB{recorder: recorder}),
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if time.Since(startedAt) > time.Second {
+		t.Fatalf("shutdown did not respect the deadline")
+	}
+	if got := recorder.list(); !reflect.DeepEqual(got, []string{"worker stopping", "db closed"}) {
+		t.Fatalf("unexpected shutdown events: %v", got)
+	}
+}
+
+func TestRunShutdown_ContinuesAfterStepError(t *testing.T) {
+	recorder := &shutdownRecorder{}
+	stepErr := errors.New("boom")
+
+	err := runShutdown(time.Second,
+		shutdownStep{name: "failing", run: func(context.Context) error { return stepErr }},
+		closeDatabaseStep(&fakeDB{recorder: recorder}),
+	)
+	if !errors.Is(err, stepErr) {
+		t.Fatalf("expected step error, got %v", err)
+	}
+	if got := recorder.list(); !reflect.DeepEqual(got, []string{"db closed"}) {
+		t.Fatalf("expected database to be closed after failed step, got %v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"loyalty/internal/adapter/memory"
+	tokensvc "loyalty/internal/adapter/token/jwt"
+	"loyalty/internal/config"
+	"loyalty/internal/controller/httpapi"
+	"loyalty/internal/controller/httpapi/common/middleware/ratelimit"
+	accrualcallbackuc "loyalty/internal/domain/accrual/usecase/callback"
+	"loyalty/internal/domain/auth/service/auth"
+	"loyalty/internal/domain/auth/service/user"
+	authusecase "loyalty/internal/domain/auth/usecase/auth"
+	balanceappsvc "loyalty/internal/domain/balance/service/balance"
+	balanceuc "loyalty/internal/domain/balance/usecase/balance"
+	ordersappsvc "loyalty/internal/domain/order/service/orders"
+	ordervalidator "loyalty/internal/domain/order/service/validator"
+	orderusecase "loyalty/internal/domain/order/usecase/order"
+	withdrawalsappsvc "loyalty/internal/domain/withdrawal/service/withdrawals"
+	withdrawalusecase "loyalty/internal/domain/withdrawal/usecase/withdrawals"
+	accrualworker "loyalty/internal/worker/accrual"
+
+	"github.com/rs/zerolog/log"
+)
+
+// storage — открытое хранилище данных: пул соединений PostgreSQL или память процесса (STORAGE=memory).
+type storage struct {
+	// db — пул соединений; nil для хранилища в памяти.
+	db *sql.DB
+	// memory — хранилище в памяти; nil для PostgreSQL.
+	memory *memory.Store
+}
+
+// openStorage открывает хранилище, выбранное параметром storage.
+func openStorage(ctx context.Context, appConfig config.Config) (storage, error) {
+	if appConfig.Storage == config.StorageMemory {
+		log.Warn().Msg("using in-memory storage: data is not persisted and is not shared between processes")
+		return storage{memory: memory.NewStore()}, nil
+	}
+	db, err := initDb(ctx, appConfig)
+	if err != nil {
+		return storage{}, err
+	}
+	return storage{db: db}, nil
+}
+
+// Close закрывает пул соединений с БД (для хранилища в памяти ничего не делает).
+func (store storage) Close() error {
+	if store.db != nil {
+		return store.db.Close()
+	}
+	return nil
+}
+
+// loadMemoryDependencies собирает зависимости поверх хранилища в памяти. В памяти хранятся только
+// пользователи, заказы, счета и списания: маршруты уровней, акций, рефералов, переводов и выписок
+// не регистрируются (CoreRoutesOnly), начисления зачисляются без множителя уровня и без бонусов.
+// Планировщик запускает только опрос воркера и не ведёт журнал запусков.
+func loadMemoryDependencies(appConfig config.Config, store *memory.Store, withWorker bool) (httpapi.Deps, background, error) {
+	ordersRepo := memory.NewOrdersRepository(store)
+	accountRepo := memory.NewAccountRepository(store)
+
+	tokenService := tokensvc.NewTokenService(appConfig.JWTSecret, appConfig.JWTTTL)
+	numberValidator := ordervalidator.NewValidator()
+	ordersService := ordersappsvc.NewService(ordersRepo, numberValidator, nil, nil)
+	withdrawalsService := withdrawalsappsvc.NewService(accountRepo, memory.NewWithdrawalsRepository(store))
+
+	accrualClient := createAccrualClient(appConfig)
+	workerConfig := loadWorkerConfig(appConfig)
+	worker := accrualworker.NewWorker(ordersRepo, ordersService, accrualClient, nil, nil, workerConfig)
+	jobScheduler, err := createScheduler(appConfig, worker, nil, nil, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to register scheduled jobs")
+		return httpapi.Deps{}, background{}, err
+	}
+
+	return httpapi.Deps{
+		AuthUsecase:                 authusecase.NewUsecase(user.NewUserService(memory.NewUserRepository(store)), auth.NewAuthService(), tokenService, nil),
+		OrdersUsecase:               orderusecase.NewUsecase(ordersService),
+		BalanceUsecase:              balanceuc.NewUsecase(balanceappsvc.NewService(accountRepo)),
+		WithdrawalsUsecase:          withdrawalusecase.NewUsecase(withdrawalsService, numberValidator),
+		TokenService:                tokenService,
+		AccrualCallbackUsecase:      accrualcallbackuc.NewUsecase(ordersService, nil),
+		Readiness:                   createReadinessProbe(nil, heartbeatWorker(worker, withWorker), nil, workerConfig, accrualClient),
+		CoreRoutesOnly:              true,
+		AdminToken:                  appConfig.AdminToken,
+		AccrualCallbackSecret:       appConfig.AccrualCallbackSecret,
+		AccrualCallbackReplayWindow: appConfig.AccrualCallbackReplayWindow,
+		EnableHTTPBodyLogging:       appConfig.EnableHTTPBodyLogging,
+		AuthRateLimitRPS:            appConfig.AuthRateLimitRPS,
+		AuthRateLimitBurst:          appConfig.AuthRateLimitBurst,
+		AuthRateLimiter:             ratelimit.NewLimiter(appConfig.AuthRateLimitRPS, appConfig.AuthRateLimitBurst),
+	}, background{worker: worker, scheduler: jobScheduler}, nil
+}
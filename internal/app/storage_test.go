@@ -0,0 +1,112 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"loyalty/internal/adapter/memory"
+	"loyalty/internal/config"
+	"loyalty/internal/controller/httpapi"
+	accrualmiddleware "loyalty/internal/controller/httpapi/accrual/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMemoryStorage_ServesCoreAPIWithoutDatabase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Config{
+		Storage:            config.StorageMemory,
+		JWTSecret:          "test-secret",
+		JWTTTL:             time.Hour,
+		AuthRateLimitRPS:   100,
+		AuthRateLimitBurst: 10,
+
+		AccrualCallbackSecret:       "callback-secret",
+		AccrualCallbackReplayWindow: time.Minute,
+	}
+	deps, _, err := loadDependencies(cfg, storage{memory: memory.NewStore()}, false)
+	if err != nil {
+		t.Fatalf("loadDependencies() error = %v", err)
+	}
+	router := httpapi.InitRouter(deps)
+
+	do := func(method, path, contentType, body, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		if token != "" {
+			request.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	register := do(http.MethodPost, "/api/user/register", "application/json", `{"login":"alice","password":"secret-password"}`, "")
+	if register.Code != http.StatusOK {
+		t.Fatalf("register: want 200, got %d %s", register.Code, register.Body)
+	}
+	token := register.Header().Get("Authorization")
+
+	if code := do(http.MethodPost, "/api/user/register", "application/json", `{"login":"alice","password":"secret-password"}`, "").Code; code != http.StatusConflict {
+		t.Fatalf("duplicate register: want 409, got %d", code)
+	}
+	if code := do(http.MethodPost, "/api/user/orders", "text/plain", "79927398713", token).Code; code != http.StatusAccepted {
+		t.Fatalf("upload order: want 202, got %d", code)
+	}
+	if code := do(http.MethodPost, "/api/user/orders", "text/plain", "79927398713", token).Code; code != http.StatusOK {
+		t.Fatalf("repeated upload: want 200, got %d", code)
+	}
+
+	orders := do(http.MethodGet, "/api/user/orders", "", "", token)
+	if orders.Code != http.StatusOK || !strings.Contains(orders.Body.String(), `"status":"NEW"`) {
+		t.Fatalf("list orders: got %d %s", orders.Code, orders.Body)
+	}
+	history := do(http.MethodGet, "/api/user/orders/79927398713/history", "", "", token)
+	if history.Code != http.StatusOK || !strings.Contains(history.Body.String(), `"status":"NEW","source":"user"`) {
+		t.Fatalf("order history: got %d %s", history.Code, history.Body)
+	}
+	balance := do(http.MethodGet, "/api/user/balance", "", "", token)
+	if balance.Code != http.StatusOK || !strings.Contains(balance.Body.String(), `"current":"0"`) {
+		t.Fatalf("balance: got %d %s", balance.Code, balance.Body)
+	}
+	if code := do(http.MethodPost, "/api/user/balance/withdraw", "application/json", `{"order":"2377225624","sum":10}`, token).Code; code != http.StatusPaymentRequired {
+		t.Fatalf("withdraw without funds: want 402, got %d", code)
+	}
+
+	// Результат расчёта, присланный обратным вызовом, применяется так же, как результат опроса.
+	callbackBody := `{"order":"79927398713","status":"PROCESSED","accrual":500}`
+	if code := do(http.MethodPost, "/api/internal/accrual/callback", "application/json", callbackBody, "").Code; code != http.StatusUnauthorized {
+		t.Fatalf("unsigned callback: want 401, got %d", code)
+	}
+	timestamp := time.Now().Unix()
+	callback := httptest.NewRequest(http.MethodPost, "/api/internal/accrual/callback", strings.NewReader(callbackBody))
+	callback.Header.Set(accrualmiddleware.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	callback.Header.Set(accrualmiddleware.HeaderSignature, accrualmiddleware.Sign("callback-secret", timestamp, []byte(callbackBody)))
+	callbackRecorder := httptest.NewRecorder()
+	router.ServeHTTP(callbackRecorder, callback)
+	if callbackRecorder.Code != http.StatusOK {
+		t.Fatalf("signed callback: want 200, got %d %s", callbackRecorder.Code, callbackRecorder.Body)
+	}
+	balance = do(http.MethodGet, "/api/user/balance", "", "", token)
+	if !strings.Contains(balance.Body.String(), `"current":"500"`) {
+		t.Fatalf("balance after callback: got %s", balance.Body)
+	}
+	history = do(http.MethodGet, "/api/user/orders/79927398713/history", "", "", token)
+	if !strings.Contains(history.Body.String(), `"source":"webhook"`) {
+		t.Fatalf("order history after callback: got %s", history.Body)
+	}
+
+	// Функции, которые хранилище в памяти не поддерживает, не регистрируются.
+	if code := do(http.MethodGet, "/api/user/statement", "", "", token).Code; code != http.StatusNotFound {
+		t.Fatalf("statement: want 404, got %d", code)
+	}
+	if code := do(http.MethodGet, "/readyz", "", "", "").Code; code != http.StatusOK {
+		t.Fatalf("readyz without database checks: want 200, got %d", code)
+	}
+}
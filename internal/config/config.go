@@ -1,23 +1,115 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"io"
+	"loyalty/internal/tracing"
 	"loyalty/internal/util/auth"
 	"os"
-	"strconv"
+	"regexp"
 	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// defaultTierRules — правила уровней по умолчанию (без повышающих множителей).
+const defaultTierRules = "SILVER:1000,GOLD:5000,PLATINUM:15000"
+
+// configFileEnv — переменная окружения с путём к файлу конфигурации (альтернатива флагу -config).
+const configFileEnv = "CONFIG_FILE"
+
+var (
+	// errInvalidTierRules возвращается при некорректном формате TIER_RULES.
+	errInvalidTierRules = errors.New("invalid TIER_RULES")
+	// errInvalidAccrualProviders возвращается при некорректном формате ACCRUAL_PROVIDERS.
+	errInvalidAccrualProviders = errors.New("invalid ACCRUAL_PROVIDERS")
+	// ErrInvalidConfig возвращается, если значение параметра не прошло валидацию.
+	ErrInvalidConfig = errors.New("invalid config")
 )
 
+// Storage — хранилище данных сервиса.
+type Storage string
+
+const (
+	// StoragePostgres — PostgreSQL (по умолчанию).
+	StoragePostgres Storage = "postgres"
+	// StorageMemory — данные в памяти процесса: для тестов и демо, теряются при перезапуске.
+	StorageMemory Storage = "memory"
+)
+
+// TierRule — правило уровня лояльности из конфигурации.
+type TierRule struct {
+	Name       string
+	Threshold  decimal.Decimal
+	Multiplier decimal.Decimal
+}
+
+// AccrualProvider — дополнительная система accrual, которая считает заказы с подходящими номерами.
+// Условия Prefix, Length и Pattern объединяются по «и»; нулевые (nil, пустые) настройки
+// наследуются от основной системы (accrual.timeout, accrual.rate_limit, accrual.breaker_* и т. д.).
+type AccrualProvider struct {
+	Name    string
+	Address string
+
+	Prefix  string
+	Length  int
+	Pattern *regexp.Regexp
+
+	Timeout      time.Duration
+	RateLimit    int
+	RateLimitMax int
+
+	BreakerFailures       int
+	BreakerOpenTimeout    time.Duration
+	BreakerHalfOpenProbes int
+	RetryCount            *int
+	RetryBackoff          time.Duration
+	RetryStatuses         []int
+}
+
 // Config содержит параметры запуска и подключения к внешним зависимостям.
 type Config struct {
 	RunAddress           string
+	Storage              Storage
 	DatabaseURI          string
 	AccrualSystemAddress string
+	// AccrualMockScenario — файл сценария mock-клиента accrual (используется без AccrualSystemAddress).
+	AccrualMockScenario string
+	// AccrualTimeout — таймаут HTTP-запроса к системе accrual.
+	AccrualTimeout time.Duration
+	// AccrualRateLimit — стартовый лимит запросов к accrual в минуту; дальше подстраивается по ответам 429.
+	AccrualRateLimit int
+	// AccrualRateLimitMax — верхняя граница, до которой лимит повышается пробами.
+	AccrualRateLimitMax int
+	// AccrualBreakerFailures — ошибок подряд, после которых breaker клиента accrual открывается.
+	AccrualBreakerFailures int
+	// AccrualBreakerOpenTimeout — сколько breaker остаётся открытым до пробных запросов.
+	AccrualBreakerOpenTimeout time.Duration
+	// AccrualBreakerHalfOpenProbes — пробных запросов в half-open; столько успехов подряд закрывают breaker.
+	AccrualBreakerHalfOpenProbes int
+	// AccrualRetryCount — повторов запроса к accrual после временной ошибки (0 — без повторов).
+	AccrualRetryCount int
+	// AccrualRetryBackoff — пауза перед первым повтором; дальше удваивается.
+	AccrualRetryBackoff time.Duration
+	// AccrualRetryStatuses — коды ответа accrual, после которых запрос повторяется.
+	AccrualRetryStatuses []int
+	// AccrualProviders — дополнительные системы accrual в порядке проверки; заказ, не подошедший
+	// ни одной из них, считает основная система (AccrualSystemAddress).
+	AccrualProviders []AccrualProvider
+	// AccrualCallbackSecret — ключ HMAC-подписи обратных вызовов accrual (пусто — приём выключен).
+	AccrualCallbackSecret string
+	// AccrualCallbackReplayWindow — допустимое расхождение времени подписи обратного вызова с текущим.
+	AccrualCallbackReplayWindow time.Duration
 
 	JWTSecret string
-	JWTTTL    time.Duration
+	// JWTSecretGenerated — секрет не задан и сгенерирован случайно при загрузке.
+	JWTSecretGenerated bool
+	JWTTTL             time.Duration
+
+	AdminToken string
 
 	DBMaxOpenConns    int
 	DBMaxIdleConns    int
@@ -27,125 +119,246 @@ type Config struct {
 	EnableHTTPBodyLogging bool
 
 	DBQueryTimeout time.Duration
+	// DBAutoMigrate — применять миграции при старте сервиса (по умолчанию выключено).
+	DBAutoMigrate bool
 
 	AuthRateLimitRPS   int
 	AuthRateLimitBurst int
 
 	LogLevel string
+
+	TierRules  []TierRule
+	TierWindow time.Duration
+
+	// ReferralBonus — вознаграждение пригласившему за приглашённого (0 отключает вознаграждения).
+	ReferralBonus decimal.Decimal
+	// ReferralMaxRewards — лимит вознаграждений на одного пригласившего.
+	ReferralMaxRewards int
+
+	// TransferDailyLimit — максимальная сумма исходящих переводов пользователя за сутки (0 — без ограничения).
+	TransferDailyLimit decimal.Decimal
+	// TransferDailyCount — максимальное количество исходящих переводов пользователя за сутки.
+	TransferDailyCount int
+
+	// Tracing — параметры OpenTelemetry-трассировки.
+	Tracing tracing.Config
+
+	// ShutdownDrainDelay — пауза между переводом /readyz в «не готов» и остановкой HTTP-сервера.
+	ShutdownDrainDelay time.Duration
+	// ShutdownTimeout — общий дедлайн на остановку HTTP-сервера и дообработку заказов воркером.
+	ShutdownTimeout time.Duration
+
+	// Параметры воркера accrual.
+	WorkerPollInterval   time.Duration
+	WorkerMaxConcurrency int
+	WorkerQueryTimeout   time.Duration
+	WorkerRequestDelay   time.Duration
+	WorkerRetryAfter     time.Duration
+	// WorkerNotify — будить воркер уведомлениями PostgreSQL (LISTEN orders_new) вместо частого опроса.
+	WorkerNotify bool
+	// WorkerSweepInterval — интервал страховочного опроса при включённых уведомлениях.
+	WorkerSweepInterval time.Duration
+
+	// LeaderElection — запускать задачи-синглтоны (воркер accrual) только на реплике-лидере.
+	LeaderElection bool
+	// LeaderRenewInterval — интервал попыток стать лидером и проверки удерживаемой блокировки.
+	LeaderRenewInterval time.Duration
+
+	// SchedulerReconcileSchedule — расписание сверки балансов счетов с операциями.
+	SchedulerReconcileSchedule string
+	// SchedulerCleanupSchedule — расписание очистки журнала запусков фоновых задач.
+	SchedulerCleanupSchedule string
+	// SchedulerRunRetention — срок хранения записей журнала запусков.
+	SchedulerRunRetention time.Duration
+
+	// ConfigFile — путь к прочитанному файлу конфигурации (пустой, если файл не задан).
+	ConfigFile string
+	// PrintConfig — вывести итоговую конфигурацию (секреты скрыты) и завершиться.
+	PrintConfig bool
 }
 
-// LoadConfig загружает конфигурацию из env и CLI-флагов.
-// Приоритет: флаги (-a/-d/-r) перекрывают переменные окружения.
+// LoadConfig загружает конфигурацию из значений по умолчанию, файла, env и CLI-флагов.
+// Приоритет (от низшего к высшему): значения по умолчанию, файл (-config или CONFIG_FILE),
+// переменные окружения, флаги. Некорректное значение любого параметра — ошибка.
 func LoadConfig() (Config, error) {
-	runAddr := os.Getenv("RUN_ADDRESS")
-	if runAddr == "" {
-		port := os.Getenv("PORT")
-		if port == "" {
-			port = "8080"
-		}
-		runAddr = ":" + port
+	return LoadArgs(os.Args[1:])
+}
+
+// LoadArgs загружает конфигурацию так же, как LoadConfig, но с флагами из args
+// (аргументы командной строки после имени подкоманды).
+func LoadArgs(args []string) (Config, error) {
+	return load(args, os.LookupEnv)
+}
+
+func load(args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	flags, err := parseFlags(args)
+	if err != nil {
+		return Config{}, err
 	}
 
-	jwtTTL := 24 * time.Hour
-	if ttlEnv := os.Getenv("JWT_TTL_SECONDS"); ttlEnv != "" {
-		if sec, err := strconv.Atoi(ttlEnv); err == nil && sec > 0 {
-			jwtTTL = time.Duration(sec) * time.Second
+	var cfg Config
+	for _, f := range fields {
+		if err := f.set(&cfg, f.def); err != nil {
+			return Config{}, fmt.Errorf("default %s: %w", f.key, err)
 		}
 	}
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = auth.RandomSecret()
+	cfg.ConfigFile = flags.configFile
+	if cfg.ConfigFile == "" {
+		cfg.ConfigFile = strings.TrimSpace(envValue(lookupEnv, configFileEnv))
 	}
-
-	cfg := Config{
-		RunAddress:            runAddr,
-		DatabaseURI:           os.Getenv("DATABASE_URI"),
-		AccrualSystemAddress:  os.Getenv("ACCRUAL_SYSTEM_ADDRESS"),
-		JWTSecret:             jwtSecret,
-		JWTTTL:                jwtTTL,
-		DBMaxOpenConns:        parseIntEnv("DB_MAX_OPEN_CONNS", 100),
-		DBMaxIdleConns:        parseIntEnv("DB_MAX_IDLE_CONNS", 25),
-		DBConnMaxLifetime:     parseDurationEnv("DB_CONN_MAX_LIFETIME", 5*time.Minute),
-		DBConnMaxIdleTime:     parseDurationEnv("DB_CONN_MAX_IDLE_TIME", 1*time.Minute),
-		DBQueryTimeout:        parseDurationEnv("DB_QUERY_TIMEOUT", 3*time.Second),
-		EnableHTTPBodyLogging: parseBoolEnv("LOG_HTTP_BODIES", false),
-		AuthRateLimitRPS:      parseIntEnv("AUTH_RATE_LIMIT_RPS", 100),
-		AuthRateLimitBurst:    parseIntEnv("AUTH_RATE_LIMIT_BURST", 20),
-		LogLevel:              strings.TrimSpace(os.Getenv("LOG_LEVEL")),
+	if cfg.ConfigFile != "" {
+		values, err := readFile(cfg.ConfigFile)
+		if err != nil {
+			return Config{}, err
+		}
+		if err := applyValues(&cfg, values, "file "+cfg.ConfigFile); err != nil {
+			return Config{}, err
+		}
 	}
 
-	if err := applyFlags(&cfg, os.Args[1:]); err != nil {
+	if err := applyEnv(&cfg, lookupEnv); err != nil {
 		return Config{}, err
 	}
-
-	if cfg.DatabaseURI == "" {
-		cfg.DatabaseURI = "postgres://localhost:5432/postgres?sslmode=disable"
+	if err := applyValues(&cfg, flags.values, "flag"); err != nil {
+		return Config{}, err
 	}
+	cfg.PrintConfig = flags.printConfig
 
+	if err := validate(cfg); err != nil {
+		return Config{}, err
+	}
+	if cfg.JWTSecret == "" {
+		cfg.JWTSecret = auth.RandomSecret()
+		cfg.JWTSecretGenerated = true
+	}
 	return cfg, nil
 }
 
-func applyFlags(cfg *Config, args []string) error {
-	flagSet := flag.NewFlagSet("app", flag.ContinueOnError)
-	flagSet.SetOutput(io.Discard)
-
-	a := flagSet.String("a", "", "service run address (overrides RUN_ADDRESS)")
-	d := flagSet.String("d", "", "database uri (overrides DATABASE_URI)")
-	r := flagSet.String("r", "", "accrual system address (overrides ACCRUAL_SYSTEM_ADDRESS)")
+// applyEnv применяет переменные окружения; пустые значения считаются незаданными.
+// PORT используется как адрес ":<PORT>", если RUN_ADDRESS не задан.
+func applyEnv(cfg *Config, lookupEnv func(string) (string, bool)) error {
+	values := make(map[string]string)
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+		if val := envValue(lookupEnv, f.env); val != "" {
+			values[f.key] = val
+		}
+	}
+	if _, ok := values[keyRunAddress]; !ok {
+		if port := envValue(lookupEnv, "PORT"); port != "" {
+			values[keyRunAddress] = ":" + port
+		}
+	}
+	return applyValues(cfg, values, "env")
+}
 
-	if err := flagSet.Parse(args); err != nil {
-		return err
+// applyValues применяет значения в порядке объявления параметров (ключ -> строковое значение).
+func applyValues(cfg *Config, values map[string]string, source string) error {
+	for _, f := range fields {
+		raw, ok := values[f.key]
+		if !ok {
+			continue
+		}
+		if err := f.set(cfg, raw); err != nil {
+			return fmt.Errorf("%w: %s (%s): %w", ErrInvalidConfig, f.name(source), source, err)
+		}
 	}
+	return nil
+}
 
-	if *a != "" {
-		cfg.RunAddress = *a
+// validate проверяет согласованность параметров между собой.
+func validate(cfg Config) error {
+	if cfg.DBMaxIdleConns > cfg.DBMaxOpenConns {
+		return fmt.Errorf("%w: database.max_idle_conns (%d) exceeds database.max_open_conns (%d)",
+			ErrInvalidConfig, cfg.DBMaxIdleConns, cfg.DBMaxOpenConns)
+	}
+	if cfg.AccrualRateLimit > cfg.AccrualRateLimitMax {
+		return fmt.Errorf("%w: accrual.rate_limit (%d) exceeds accrual.rate_limit_max (%d)",
+			ErrInvalidConfig, cfg.AccrualRateLimit, cfg.AccrualRateLimitMax)
 	}
-	if *d != "" {
-		cfg.DatabaseURI = *d
+	for _, provider := range cfg.AccrualProviders {
+		rateLimit, rateLimitMax := provider.RateLimit, provider.RateLimitMax
+		if rateLimit == 0 {
+			rateLimit = cfg.AccrualRateLimit
+		}
+		if rateLimitMax == 0 {
+			rateLimitMax = cfg.AccrualRateLimitMax
+		}
+		if rateLimit > rateLimitMax {
+			return fmt.Errorf("%w: accrual.providers: %s: rate_limit (%d) exceeds rate_limit_max (%d)",
+				ErrInvalidConfig, provider.Name, rateLimit, rateLimitMax)
+		}
 	}
-	if *r != "" {
-		cfg.AccrualSystemAddress = *r
+	if cfg.DatabaseURI == "" {
+		return fmt.Errorf("%w: database.uri is empty", ErrInvalidConfig)
 	}
-
 	return nil
 }
 
-func parseIntEnv(key string, defaultValue int) int {
-	val := os.Getenv(key)
-	if val == "" {
-		return defaultValue
+func envValue(lookupEnv func(string) (string, bool), key string) string {
+	val, _ := lookupEnv(key)
+	return strings.TrimSpace(val)
+}
+
+// cliFlags — результат разбора командной строки.
+type cliFlags struct {
+	values      map[string]string
+	configFile  string
+	printConfig bool
+}
+
+// shortFlags — короткие флаги, сохранённые для совместимости.
+var shortFlags = map[string]string{
+	"a": keyRunAddress,
+	"d": keyDatabaseURI,
+	"r": keyAccrualAddress,
+}
+
+func parseFlags(args []string) (cliFlags, error) {
+	flagSet := flag.NewFlagSet("app", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	result := cliFlags{values: make(map[string]string)}
+	flagSet.StringVar(&result.configFile, "config", "", "path to YAML (.yaml/.yml) or TOML (.toml) config file (overrides "+configFileEnv+")")
+	flagSet.BoolVar(&result.printConfig, "print-config", false, "print resolved configuration with secrets redacted and exit")
+
+	for _, f := range fields {
+		flagSet.Var(&flagValue{key: f.key, values: result.values, isBool: f.isBool}, f.flag, f.usage)
 	}
-	parsed, err := strconv.Atoi(val)
-	if err != nil || parsed <= 0 {
-		return defaultValue
+	for short, key := range shortFlags {
+		f := fieldByKey[key]
+		flagSet.Var(&flagValue{key: key, values: result.values}, short, f.usage+" (short for -"+f.flag+")")
 	}
-	return parsed
-}
 
-func parseDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	val := os.Getenv(key)
-	if val == "" {
-		return defaultValue
+	if err := flagSet.Parse(args); err != nil {
+		return cliFlags{}, err
 	}
-	seconds, err := strconv.Atoi(val)
-	if err != nil || seconds <= 0 {
-		return defaultValue
+	if flagSet.NArg() > 0 {
+		return cliFlags{}, fmt.Errorf("unexpected arguments: %v", flagSet.Args())
 	}
-	return time.Duration(seconds) * time.Second
+	return result, nil
 }
 
-func parseBoolEnv(key string, defaultValue bool) bool {
-	val := os.Getenv(key)
-	if val == "" {
-		return defaultValue
-	}
-	switch val {
-	case "true", "1", "yes", "on":
-		return true
-	case "false", "0", "no", "off":
-		return false
-	default:
-		return defaultValue
+// flagValue сохраняет сырое значение флага под ключом параметра; разбор — общий с файлом и env.
+type flagValue struct {
+	key    string
+	values map[string]string
+	isBool bool
+}
+
+func (value *flagValue) String() string {
+	if value == nil || value.values == nil {
+		return ""
 	}
+	return value.values[value.key]
 }
+
+func (value *flagValue) Set(raw string) error {
+	value.values[value.key] = raw
+	return nil
+}
+
+func (value *flagValue) IsBoolFlag() bool { return value.isBool }
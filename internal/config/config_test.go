@@ -1,9 +1,14 @@
 package config
 
 import (
+	"errors"
+	"loyalty/internal/tracing"
 	"os"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 func TestLoadConfig_EnvAndDefaults(t *testing.T) {
@@ -163,37 +168,319 @@ func TestLoadConfig_DBPoolSettings(t *testing.T) {
 	}
 }
 
-func TestParseBoolEnv(t *testing.T) {
+func TestParseBool(t *testing.T) {
 	tests := []struct {
 		value    string
-		defVal   bool
 		expected bool
+		wantErr  bool
 	}{
-		{"", false, false},
-		{"", true, true},
-		{"true", false, true},
-		{"1", false, true},
-		{"yes", false, true},
-		{"on", false, true},
-		{"false", true, false},
-		{"0", true, false},
-		{"no", true, false},
-		{"off", true, false},
-		{"invalid", false, false},
-		{"invalid", true, true},
+		{"true", true, false},
+		{"1", true, false},
+		{"yes", true, false},
+		{"ON", true, false},
+		{"false", false, false},
+		{"0", false, false},
+		{"no", false, false},
+		{"off", false, false},
+		{"invalid", false, true},
+		{"", false, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.value, func(t *testing.T) {
-			if tt.value != "" {
-				t.Setenv("TEST_BOOL", tt.value)
-			} else {
-				t.Setenv("TEST_BOOL", "")
+			got, err := parseBool(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBool(%q) err = %v, wantErr %v", tt.value, err, tt.wantErr)
 			}
-			got := parseBoolEnv("TEST_BOOL", tt.defVal)
 			if got != tt.expected {
-				t.Errorf("parseBoolEnv(%q, %v) = %v, want %v", tt.value, tt.defVal, got, tt.expected)
+				t.Errorf("parseBool(%q) = %v, want %v", tt.value, got, tt.expected)
 			}
 		})
 	}
 }
+
+func TestLoadConfig_TierDefaults(t *testing.T) {
+	origArgs := os.Args
+	t.Cleanup(func() { os.Args = origArgs })
+
+	t.Setenv("TIER_RULES", "")
+	t.Setenv("TIER_WINDOW_DAYS", "")
+	t.Setenv("JWT_SECRET", "s")
+	os.Args = []string{"cmd"}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(cfg.TierRules) != 3 {
+		t.Fatalf("expected 3 default tier rules, got %d", len(cfg.TierRules))
+	}
+	if cfg.TierWindow != 90*24*time.Hour {
+		t.Fatalf("expected TierWindow=90d, got %v", cfg.TierWindow)
+	}
+}
+
+func TestLoadConfig_InvalidTierRules(t *testing.T) {
+	origArgs := os.Args
+	t.Cleanup(func() { os.Args = origArgs })
+
+	t.Setenv("TIER_RULES", "SILVER:abc")
+	t.Setenv("JWT_SECRET", "s")
+	os.Args = []string{"cmd"}
+
+	if _, err := LoadConfig(); err == nil {
+		t.Fatalf("expected error for invalid TIER_RULES")
+	}
+}
+
+func TestParseTierRules(t *testing.T) {
+	rules, err := parseTierRules(" silver:1000 , GOLD:5000:1.1,")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].Name != "SILVER" || rules[0].Threshold.String() != "1000" || rules[0].Multiplier.String() != "1" {
+		t.Fatalf("unexpected first rule: %+v", rules[0])
+	}
+	if rules[1].Name != "GOLD" || rules[1].Multiplier.String() != "1.1" {
+		t.Fatalf("unexpected second rule: %+v", rules[1])
+	}
+
+	for _, spec := range []string{"SILVER", "SILVER:1:2:3", ":100", "GOLD:100:x"} {
+		if _, err := parseTierRules(spec); err == nil {
+			t.Errorf("parseTierRules(%q) expected error", spec)
+		}
+	}
+}
+
+func TestLoadConfig_ReferralSettings(t *testing.T) {
+	origArgs := os.Args
+	t.Cleanup(func() { os.Args = origArgs })
+
+	t.Setenv("JWT_SECRET", "s")
+	t.Setenv("REFERRAL_BONUS", "250.5")
+	t.Setenv("REFERRAL_MAX_REWARDS", "3")
+	os.Args = []string{"cmd"}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !cfg.ReferralBonus.Equal(decimal.RequireFromString("250.5")) {
+		t.Fatalf("expected ReferralBonus=250.5, got %s", cfg.ReferralBonus)
+	}
+	if cfg.ReferralMaxRewards != 3 {
+		t.Fatalf("expected ReferralMaxRewards=3, got %d", cfg.ReferralMaxRewards)
+	}
+}
+
+func TestParseNonNegativeDecimal(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    decimal.Decimal
+		wantErr bool
+	}{
+		{"0", decimal.Zero, false},
+		{"12.5", decimal.RequireFromString("12.5"), false},
+		{"-1", decimal.Zero, true},
+		{"abc", decimal.Zero, true},
+		{"", decimal.Zero, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseNonNegativeDecimal(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNonNegativeDecimal(%q) err = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseNonNegativeDecimal(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_TracingSettings(t *testing.T) {
+	origArgs := os.Args
+	t.Cleanup(func() { os.Args = origArgs })
+
+	t.Setenv("JWT_SECRET", "s")
+	t.Setenv("TRACING_EXPORTER", " OTLP ")
+	t.Setenv("TRACING_OTLP_ENDPOINT", "collector:4318")
+	t.Setenv("TRACING_SAMPLE_RATIO", "0.25")
+	os.Args = []string{"cmd"}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.Tracing.Exporter != tracing.ExporterOTLP {
+		t.Fatalf("expected otlp exporter, got %q", cfg.Tracing.Exporter)
+	}
+	if cfg.Tracing.OTLPEndpoint != "collector:4318" || cfg.Tracing.ServiceName != "loyalty" {
+		t.Fatalf("unexpected tracing config: %+v", cfg.Tracing)
+	}
+	if cfg.Tracing.SampleRatio != 0.25 {
+		t.Fatalf("expected SampleRatio=0.25, got %v", cfg.Tracing.SampleRatio)
+	}
+}
+
+func TestLoadConfig_InvalidTracingExporter(t *testing.T) {
+	origArgs := os.Args
+	t.Cleanup(func() { os.Args = origArgs })
+
+	t.Setenv("JWT_SECRET", "s")
+	t.Setenv("TRACING_EXPORTER", "zipkin")
+	os.Args = []string{"cmd"}
+
+	if _, err := LoadConfig(); !errors.Is(err, tracing.ErrUnknownExporter) {
+		t.Fatalf("expected ErrUnknownExporter, got %v", err)
+	}
+}
+
+func TestLoadConfig_Storage(t *testing.T) {
+	cfg, err := load(nil, envMap(nil))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.Storage != StoragePostgres {
+		t.Fatalf("expected postgres storage by default, got %q", cfg.Storage)
+	}
+
+	cfg, err = load(nil, envMap(map[string]string{"STORAGE": " Memory "}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.Storage != StorageMemory {
+		t.Fatalf("expected memory storage, got %q", cfg.Storage)
+	}
+
+	if _, err := load([]string{"-storage", "sqlite"}, envMap(nil)); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig for unknown storage, got %v", err)
+	}
+}
+
+func TestLoadConfig_SchedulerSchedules(t *testing.T) {
+	cfg, err := load(nil, envMap(map[string]string{
+		"SCHEDULER_CLEANUP_SCHEDULE":   "@every 1h",
+		"SCHEDULER_RUN_RETENTION_DAYS": "30",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.SchedulerCleanupSchedule != "@every 1h" || cfg.SchedulerReconcileSchedule != "30 3 * * *" {
+		t.Fatalf("unexpected schedules: cleanup=%q reconcile=%q", cfg.SchedulerCleanupSchedule, cfg.SchedulerReconcileSchedule)
+	}
+	if cfg.SchedulerRunRetention != 30*24*time.Hour {
+		t.Fatalf("unexpected retention: %v", cfg.SchedulerRunRetention)
+	}
+
+	_, err = load([]string{"-scheduler-reconcile-schedule", "61 * * * *"}, envMap(nil))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig for invalid schedule, got %v", err)
+	}
+}
+
+func TestLoadConfig_AccrualRateLimit(t *testing.T) {
+	cfg, err := load(nil, envMap(map[string]string{"ACCRUAL_RATE_LIMIT": "120"}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.AccrualRateLimit != 120 || cfg.AccrualRateLimitMax != 6000 {
+		t.Fatalf("unexpected rate limits: initial=%d max=%d", cfg.AccrualRateLimit, cfg.AccrualRateLimitMax)
+	}
+
+	_, err = load([]string{"-accrual-rate-limit", "500", "-accrual-rate-limit-max", "100"}, envMap(nil))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig when initial limit exceeds max, got %v", err)
+	}
+}
+
+func TestLoadConfig_AccrualProviders(t *testing.T) {
+	cfg, err := load(nil, envMap(map[string]string{
+		"ACCRUAL_PROVIDERS": "merchant2=http://accrual-2:8080;prefix=42;length=16;timeout=2s;rate_limit=600, Legacy=http://legacy:8080;regex=^9\\d+$",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(cfg.AccrualProviders) != 2 {
+		t.Fatalf("expected 2 providers, got %+v", cfg.AccrualProviders)
+	}
+	first := cfg.AccrualProviders[0]
+	if first.Name != "merchant2" || first.Address != "http://accrual-2:8080" || first.Prefix != "42" || first.Length != 16 ||
+		first.Timeout != 2*time.Second || first.RateLimit != 600 || first.RateLimitMax != 0 {
+		t.Fatalf("unexpected first provider: %+v", first)
+	}
+	second := cfg.AccrualProviders[1]
+	if second.Name != "legacy" || second.Pattern == nil || !second.Pattern.MatchString("9123") {
+		t.Fatalf("unexpected second provider: %+v", second)
+	}
+	if got := formatAccrualProviders(cfg.AccrualProviders); got != `merchant2=http://accrual-2:8080;prefix=42;length=16;timeout=2s;rate_limit=600,legacy=http://legacy:8080;regex=^9\d+$` {
+		t.Fatalf("unexpected formatted providers: %s", got)
+	}
+
+	for _, spec := range []string{
+		"merchant2",
+		"merchant2=http://a;timeout=1s",
+		"merchant2=http://a;prefix=4;color=red",
+		"merchant2=http://a;regex=(",
+		"default=http://a;prefix=4",
+		"a=http://a;prefix=4,a=http://b;prefix=5",
+	} {
+		if _, err := parseAccrualProviders(spec); err == nil {
+			t.Errorf("parseAccrualProviders(%q) expected error", spec)
+		}
+	}
+
+	_, err = load(nil, envMap(map[string]string{"ACCRUAL_PROVIDERS": "merchant2=http://a;prefix=4;rate_limit=7000"}))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig when provider limit exceeds inherited max, got %v", err)
+	}
+}
+
+func TestLoadConfig_AccrualRetryPolicy(t *testing.T) {
+	cfg, err := load(nil, envMap(nil))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.AccrualBreakerFailures != 5 || cfg.AccrualBreakerOpenTimeout != 30*time.Second || cfg.AccrualBreakerHalfOpenProbes != 3 ||
+		cfg.AccrualRetryCount != 2 || cfg.AccrualRetryBackoff != 200*time.Millisecond ||
+		!reflect.DeepEqual(cfg.AccrualRetryStatuses, []int{502, 503, 504}) {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+
+	cfg, err = load(nil, envMap(map[string]string{
+		"ACCRUAL_BREAKER_FAILURES": "2",
+		"ACCRUAL_RETRY_COUNT":      "0",
+		"ACCRUAL_RETRY_STATUSES":   "500, 503",
+		"ACCRUAL_PROVIDERS":        "merchant2=http://a;prefix=4;breaker_failures=10;retry_count=0;retry_backoff=1s;retry_statuses=500|502",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.AccrualBreakerFailures != 2 || cfg.AccrualRetryCount != 0 || !reflect.DeepEqual(cfg.AccrualRetryStatuses, []int{500, 503}) {
+		t.Fatalf("unexpected retry policy: %+v", cfg)
+	}
+	provider := cfg.AccrualProviders[0]
+	if provider.BreakerFailures != 10 || provider.RetryCount == nil || *provider.RetryCount != 0 ||
+		provider.RetryBackoff != time.Second || !reflect.DeepEqual(provider.RetryStatuses, []int{500, 502}) {
+		t.Fatalf("unexpected provider policy: %+v", provider)
+	}
+	if got := formatAccrualProviders(cfg.AccrualProviders); got != "merchant2=http://a;prefix=4;breaker_failures=10;retry_count=0;retry_backoff=1s;retry_statuses=500|502" {
+		t.Fatalf("unexpected formatted providers: %s", got)
+	}
+
+	for _, env := range []map[string]string{
+		{"ACCRUAL_BREAKER_FAILURES": "0"},
+		{"ACCRUAL_RETRY_COUNT": "-1"},
+		{"ACCRUAL_RETRY_STATUSES": "429"},
+		{"ACCRUAL_RETRY_STATUSES": "200"},
+		{"ACCRUAL_PROVIDERS": "merchant2=http://a;prefix=4;retry_statuses=503,504"},
+	} {
+		if _, err := load(nil, envMap(env)); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("load(%v) expected ErrInvalidConfig, got %v", env, err)
+		}
+	}
+}
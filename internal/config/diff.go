@@ -0,0 +1,25 @@
+package config
+
+// Change — изменение значения параметра между двумя конфигурациями.
+// Значения секретных параметров скрыты.
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+// Diff возвращает изменённые параметры в порядке их объявления.
+func Diff(prev Config, next Config) []Change {
+	var changes []Change
+	for _, f := range fields {
+		oldValue, newValue := f.get(prev), f.get(next)
+		if oldValue == newValue {
+			continue
+		}
+		if f.secret {
+			oldValue, newValue = redactValue(f.key, oldValue), redactValue(f.key, newValue)
+		}
+		changes = append(changes, Change{Key: f.key, Old: oldValue, New: newValue})
+	}
+	return changes
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	prev, err := load(nil, envMap(map[string]string{"JWT_SECRET": "old-secret"}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if Diff(prev, prev) != nil {
+		t.Fatalf("expected no changes for equal configs")
+	}
+
+	next := prev
+	next.WorkerMaxConcurrency = 9
+	next.LogLevel = "warn"
+	next.JWTSecret = "new-secret"
+	next.DBQueryTimeout = 2 * time.Second
+
+	want := []Change{
+		{Key: "database.query_timeout", Old: "3s", New: "2s"},
+		{Key: "worker.max_concurrency", Old: "5", New: "9"},
+		{Key: "auth.jwt_secret", Old: redacted, New: redacted},
+		{Key: "log.level", Old: "", New: "warn"},
+	}
+	if got := Diff(prev, next); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected diff:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestLoad_MarksGeneratedJWTSecret(t *testing.T) {
+	cfg, err := load(nil, envMap(nil))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.JWTSecret == "" || !cfg.JWTSecretGenerated {
+		t.Fatalf("expected generated secret, got %+v", cfg)
+	}
+
+	cfg, err = load(nil, envMap(map[string]string{"JWT_SECRET": "s"}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.JWTSecretGenerated {
+		t.Fatalf("explicit secret must not be marked as generated")
+	}
+}
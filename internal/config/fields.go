@@ -0,0 +1,546 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"loyalty/internal/scheduler"
+	"loyalty/internal/tracing"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+// Ключи параметров, на которые есть ссылки из кода.
+const (
+	keyRunAddress     = "run_address"
+	keyDatabaseURI    = "database.uri"
+	keyAccrualAddress = "accrual.address"
+)
+
+// field описывает параметр конфигурации: ключ в файле, переменную окружения, флаг,
+// значение по умолчанию и разбор строкового значения. Все источники разбираются одинаково.
+type field struct {
+	key    string
+	env    string
+	flag   string
+	usage  string
+	def    string
+	secret bool
+	isBool bool
+	set    func(cfg *Config, raw string) error
+	get    func(cfg Config) string
+}
+
+// name возвращает имя параметра так, как оно задаётся в источнике.
+func (f field) name(source string) string {
+	switch {
+	case source == "env" && f.env != "":
+		return f.env
+	case source == "flag":
+		return "-" + f.flag
+	default:
+		return f.key
+	}
+}
+
+// newField связывает параметр с полем Config через accessor.
+func newField[T any](
+	key, env, usage, def string,
+	ptr func(cfg *Config) *T,
+	parse func(raw string) (T, error),
+	format func(value T) string,
+) field {
+	return field{
+		key:   key,
+		env:   env,
+		flag:  strings.NewReplacer(".", "-", "_", "-").Replace(key),
+		usage: usage,
+		def:   def,
+		set: func(cfg *Config, raw string) error {
+			value, err := parse(strings.TrimSpace(raw))
+			if err != nil {
+				return err
+			}
+			*ptr(cfg) = value
+			return nil
+		},
+		get: func(cfg Config) string { return format(*ptr(&cfg)) },
+	}
+}
+
+func secret(f field) field {
+	f.secret = true
+	return f
+}
+
+func boolean(f field) field {
+	f.isBool = true
+	return f
+}
+
+// fields — схема конфигурации. Порядок определяет порядок применения и вывода (-print-config).
+var fields = []field{
+	newField(keyRunAddress, "RUN_ADDRESS", "service run address (PORT=<n> is used as :<n> when unset)", ":8080",
+		func(cfg *Config) *string { return &cfg.RunAddress }, parseNonEmpty, formatString),
+	newField("storage", "STORAGE", "storage backend (postgres|memory)", string(StoragePostgres),
+		func(cfg *Config) *Storage { return &cfg.Storage }, parseStorage,
+		func(storage Storage) string { return string(storage) }),
+	secret(newField(keyDatabaseURI, "DATABASE_URI", "PostgreSQL connection URI",
+		"postgres://localhost:5432/postgres?sslmode=disable",
+		func(cfg *Config) *string { return &cfg.DatabaseURI }, parseNonEmpty, formatString)),
+	newField("database.max_open_conns", "DB_MAX_OPEN_CONNS", "max open connections in the pool", "100",
+		func(cfg *Config) *int { return &cfg.DBMaxOpenConns }, intAtLeast(1), strconv.Itoa),
+	newField("database.max_idle_conns", "DB_MAX_IDLE_CONNS", "max idle connections in the pool", "25",
+		func(cfg *Config) *int { return &cfg.DBMaxIdleConns }, intAtLeast(0), strconv.Itoa),
+	newField("database.conn_max_lifetime", "DB_CONN_MAX_LIFETIME", "max connection lifetime (seconds or Go duration)", "5m",
+		func(cfg *Config) *time.Duration { return &cfg.DBConnMaxLifetime }, positiveDuration(time.Second), formatDuration),
+	newField("database.conn_max_idle_time", "DB_CONN_MAX_IDLE_TIME", "max connection idle time (seconds or Go duration)", "1m",
+		func(cfg *Config) *time.Duration { return &cfg.DBConnMaxIdleTime }, positiveDuration(time.Second), formatDuration),
+	newField("database.query_timeout", "DB_QUERY_TIMEOUT", "timeout of a single DB query (seconds or Go duration)", "3s",
+		func(cfg *Config) *time.Duration { return &cfg.DBQueryTimeout }, positiveDuration(time.Second), formatDuration),
+	boolean(newField("database.auto_migrate", "DB_AUTO_MIGRATE", "apply pending migrations on start (otherwise run `loyalty migrate up`)", "false",
+		func(cfg *Config) *bool { return &cfg.DBAutoMigrate }, parseBool, strconv.FormatBool)),
+
+	newField(keyAccrualAddress, "ACCRUAL_SYSTEM_ADDRESS", "accrual system base URL (empty uses the mock client)", "",
+		func(cfg *Config) *string { return &cfg.AccrualSystemAddress }, parseString, formatString),
+	newField("accrual.mock_scenario", "ACCRUAL_MOCK_SCENARIO", "scenario file (YAML or JSON) of the mock accrual client used without accrual.address", "",
+		func(cfg *Config) *string { return &cfg.AccrualMockScenario }, parseString, formatString),
+	newField("accrual.timeout", "ACCRUAL_TIMEOUT", "accrual HTTP request timeout (seconds or Go duration)", "5s",
+		func(cfg *Config) *time.Duration { return &cfg.AccrualTimeout }, positiveDuration(time.Second), formatDuration),
+	newField("accrual.rate_limit", "ACCRUAL_RATE_LIMIT", "initial accrual requests per minute (adapted from 429 responses)", "3000",
+		func(cfg *Config) *int { return &cfg.AccrualRateLimit }, intAtLeast(1), strconv.Itoa),
+	newField("accrual.rate_limit_max", "ACCRUAL_RATE_LIMIT_MAX", "upper bound for accrual rate limit probing, requests per minute", "6000",
+		func(cfg *Config) *int { return &cfg.AccrualRateLimitMax }, intAtLeast(1), strconv.Itoa),
+	newField("accrual.breaker_failures", "ACCRUAL_BREAKER_FAILURES", "consecutive accrual failures that open the circuit breaker", "5",
+		func(cfg *Config) *int { return &cfg.AccrualBreakerFailures }, intAtLeast(1), strconv.Itoa),
+	newField("accrual.breaker_open_timeout", "ACCRUAL_BREAKER_OPEN_TIMEOUT", "how long the accrual breaker stays open before probing (seconds or Go duration)", "30s",
+		func(cfg *Config) *time.Duration { return &cfg.AccrualBreakerOpenTimeout }, positiveDuration(time.Second), formatDuration),
+	newField("accrual.breaker_half_open_probes", "ACCRUAL_BREAKER_HALF_OPEN_PROBES", "probe requests allowed while the accrual breaker is half-open", "3",
+		func(cfg *Config) *int { return &cfg.AccrualBreakerHalfOpenProbes }, intAtLeast(1), strconv.Itoa),
+	newField("accrual.retry_count", "ACCRUAL_RETRY_COUNT", "retries of an accrual request after a transient error (0 disables)", "2",
+		func(cfg *Config) *int { return &cfg.AccrualRetryCount }, intAtLeast(0), strconv.Itoa),
+	newField("accrual.retry_backoff", "ACCRUAL_RETRY_BACKOFF", "pause before the first accrual retry, doubled each time (milliseconds or Go duration)", "200ms",
+		func(cfg *Config) *time.Duration { return &cfg.AccrualRetryBackoff }, positiveDuration(time.Millisecond), formatDuration),
+	newField("accrual.retry_statuses", "ACCRUAL_RETRY_STATUSES", "accrual response codes that are retried", "502,503,504",
+		func(cfg *Config) *[]int { return &cfg.AccrualRetryStatuses }, parseRetryStatuses, formatRetryStatuses),
+	newField("accrual.providers", "ACCRUAL_PROVIDERS",
+		"extra accrual systems NAME=URL;prefix=P;length=N;regex=RE[;timeout=D;rate_limit=N;breaker_failures=N;retry_count=N;...],...", "",
+		func(cfg *Config) *[]AccrualProvider { return &cfg.AccrualProviders }, parseAccrualProviders, formatAccrualProviders),
+	secret(newField("accrual.callback_secret", "ACCRUAL_CALLBACK_SECRET", "HMAC-SHA256 key of accrual callbacks (empty disables callbacks)", "",
+		func(cfg *Config) *string { return &cfg.AccrualCallbackSecret }, parseString, formatString)),
+	newField("accrual.callback_replay_window", "ACCRUAL_CALLBACK_REPLAY_WINDOW", "max age of a signed accrual callback (seconds or Go duration)", "5m",
+		func(cfg *Config) *time.Duration { return &cfg.AccrualCallbackReplayWindow }, positiveDuration(time.Second), formatDuration),
+
+	newField("worker.poll_interval", "WORKER_POLL_INTERVAL", "accrual worker poll interval (seconds or Go duration)", "5s",
+		func(cfg *Config) *time.Duration { return &cfg.WorkerPollInterval }, positiveDuration(time.Second), formatDuration),
+	newField("worker.max_concurrency", "WORKER_MAX_CONCURRENCY", "accrual worker parallel requests", "5",
+		func(cfg *Config) *int { return &cfg.WorkerMaxConcurrency }, intAtLeast(1), strconv.Itoa),
+	newField("worker.query_timeout", "WORKER_QUERY_TIMEOUT", "accrual worker DB operation timeout (seconds or Go duration)", "3s",
+		func(cfg *Config) *time.Duration { return &cfg.WorkerQueryTimeout }, positiveDuration(time.Second), formatDuration),
+	newField("worker.request_delay", "WORKER_REQUEST_DELAY", "delay between accrual requests (seconds or Go duration, 0 disables)", "0",
+		func(cfg *Config) *time.Duration { return &cfg.WorkerRequestDelay }, nonNegativeDuration(time.Second), formatDuration),
+	newField("worker.retry_after", "WORKER_RETRY_AFTER", "pause after accrual 429/unavailable (seconds or Go duration)", "60s",
+		func(cfg *Config) *time.Duration { return &cfg.WorkerRetryAfter }, positiveDuration(time.Second), formatDuration),
+	boolean(newField("worker.notify", "WORKER_NOTIFY", "wake the accrual worker via LISTEN orders_new (postgres storage only)", "true",
+		func(cfg *Config) *bool { return &cfg.WorkerNotify }, parseBool, strconv.FormatBool)),
+	newField("worker.sweep_interval", "WORKER_SWEEP_INTERVAL", "fallback poll interval while notifications are enabled (seconds or Go duration)", "1m",
+		func(cfg *Config) *time.Duration { return &cfg.WorkerSweepInterval }, positiveDuration(time.Second), formatDuration),
+
+	boolean(newField("leader.election", "LEADER_ELECTION", "run singleton jobs (accrual worker) only on the replica holding the leader lock", "false",
+		func(cfg *Config) *bool { return &cfg.LeaderElection }, parseBool, strconv.FormatBool)),
+	newField("leader.renew_interval", "LEADER_RENEW_INTERVAL", "leader lock acquire/renew interval (seconds or Go duration)", "5s",
+		func(cfg *Config) *time.Duration { return &cfg.LeaderRenewInterval }, positiveDuration(time.Second), formatDuration),
+
+	newField("scheduler.reconcile_schedule", "SCHEDULER_RECONCILE_SCHEDULE", "balance reconciliation schedule (cron or @every <duration>, empty disables)", "30 3 * * *",
+		func(cfg *Config) *string { return &cfg.SchedulerReconcileSchedule }, parseSchedule, formatString),
+	newField("scheduler.cleanup_schedule", "SCHEDULER_CLEANUP_SCHEDULE", "job run history cleanup schedule (cron or @every <duration>, empty disables)", "0 3 * * *",
+		func(cfg *Config) *string { return &cfg.SchedulerCleanupSchedule }, parseSchedule, formatString),
+	newField("scheduler.run_retention", "SCHEDULER_RUN_RETENTION_DAYS", "job run history retention (days or Go duration)", "7",
+		func(cfg *Config) *time.Duration { return &cfg.SchedulerRunRetention }, positiveDuration(24*time.Hour), formatDuration),
+
+	secret(newField("auth.jwt_secret", "JWT_SECRET", "JWT signing secret (random when empty)", "",
+		func(cfg *Config) *string { return &cfg.JWTSecret }, parseString, formatString)),
+	newField("auth.jwt_ttl", "JWT_TTL_SECONDS", "JWT lifetime (seconds or Go duration)", "24h",
+		func(cfg *Config) *time.Duration { return &cfg.JWTTTL }, positiveDuration(time.Second), formatDuration),
+	newField("auth.rate_limit_rps", "AUTH_RATE_LIMIT_RPS", "register/login rate limit, requests per second", "100",
+		func(cfg *Config) *int { return &cfg.AuthRateLimitRPS }, intAtLeast(1), strconv.Itoa),
+	newField("auth.rate_limit_burst", "AUTH_RATE_LIMIT_BURST", "register/login rate limit burst", "20",
+		func(cfg *Config) *int { return &cfg.AuthRateLimitBurst }, intAtLeast(1), strconv.Itoa),
+	secret(newField("admin.token", "ADMIN_TOKEN", "static token for /api/admin (empty disables admin API)", "",
+		func(cfg *Config) *string { return &cfg.AdminToken }, parseString, formatString)),
+
+	newField("log.level", "LOG_LEVEL", "log level (debug|info|warn|error)", "",
+		func(cfg *Config) *string { return &cfg.LogLevel }, parseLogLevel, formatString),
+	boolean(newField("log.http_bodies", "LOG_HTTP_BODIES", "log HTTP request/response bodies", "false",
+		func(cfg *Config) *bool { return &cfg.EnableHTTPBodyLogging }, parseBool, strconv.FormatBool)),
+
+	newField("tier.rules", "TIER_RULES", "tier rules NAME:THRESHOLD[:MULTIPLIER],...", defaultTierRules,
+		func(cfg *Config) *[]TierRule { return &cfg.TierRules }, parseTierRules, formatTierRules),
+	newField("tier.window", "TIER_WINDOW_DAYS", "rolling accrual window (days or Go duration)", "90",
+		func(cfg *Config) *time.Duration { return &cfg.TierWindow }, positiveDuration(24*time.Hour), formatDuration),
+
+	newField("referral.bonus", "REFERRAL_BONUS", "referrer reward per referee (0 disables)", "100",
+		func(cfg *Config) *decimal.Decimal { return &cfg.ReferralBonus }, parseNonNegativeDecimal, decimal.Decimal.String),
+	newField("referral.max_rewards", "REFERRAL_MAX_REWARDS", "max rewards per referrer", "10",
+		func(cfg *Config) *int { return &cfg.ReferralMaxRewards }, intAtLeast(1), strconv.Itoa),
+
+	newField("transfer.daily_limit", "TRANSFER_DAILY_LIMIT", "max outgoing transfer sum per day (0 — unlimited)", "10000",
+		func(cfg *Config) *decimal.Decimal { return &cfg.TransferDailyLimit }, parseNonNegativeDecimal, decimal.Decimal.String),
+	newField("transfer.daily_count", "TRANSFER_DAILY_COUNT", "max outgoing transfers per day", "10",
+		func(cfg *Config) *int { return &cfg.TransferDailyCount }, intAtLeast(1), strconv.Itoa),
+
+	newField("tracing.exporter", "TRACING_EXPORTER", "span exporter (none|stdout|otlp)", "none",
+		func(cfg *Config) *tracing.Exporter { return &cfg.Tracing.Exporter }, tracing.ParseExporter,
+		func(exporter tracing.Exporter) string { return string(exporter) }),
+	newField("tracing.otlp_endpoint", "TRACING_OTLP_ENDPOINT", "OTLP collector host:port or URL", "",
+		func(cfg *Config) *string { return &cfg.Tracing.OTLPEndpoint }, parseString, formatString),
+	newField("tracing.service_name", "TRACING_SERVICE_NAME", "service name in trace resource", "loyalty",
+		func(cfg *Config) *string { return &cfg.Tracing.ServiceName }, parseNonEmpty, formatString),
+	newField("tracing.sample_ratio", "TRACING_SAMPLE_RATIO", "sampled share of root traces, 0..1", "1",
+		func(cfg *Config) *float64 { return &cfg.Tracing.SampleRatio }, parseRatio, formatFloat),
+
+	newField("shutdown.drain_delay", "SHUTDOWN_DRAIN_DELAY", "pause between readiness off and server shutdown (seconds or Go duration)", "5s",
+		func(cfg *Config) *time.Duration { return &cfg.ShutdownDrainDelay }, nonNegativeDuration(time.Second), formatDuration),
+	newField("shutdown.timeout", "SHUTDOWN_TIMEOUT", "deadline for draining HTTP requests and the accrual worker (seconds or Go duration)", "20s",
+		func(cfg *Config) *time.Duration { return &cfg.ShutdownTimeout }, positiveDuration(time.Second), formatDuration),
+}
+
+// fieldByKey — индекс схемы по ключу.
+var fieldByKey = func() map[string]field {
+	index := make(map[string]field, len(fields))
+	for _, f := range fields {
+		if _, duplicate := index[f.key]; duplicate {
+			panic(fmt.Sprintf("config: duplicate key %q", f.key))
+		}
+		index[f.key] = f
+	}
+	return index
+}()
+
+func parseString(raw string) (string, error) { return raw, nil }
+
+func parseStorage(raw string) (Storage, error) {
+	switch storage := Storage(strings.ToLower(strings.TrimSpace(raw))); storage {
+	case StoragePostgres, StorageMemory:
+		return storage, nil
+	default:
+		return "", fmt.Errorf("expected postgres or memory, got %q", raw)
+	}
+}
+
+func parseNonEmpty(raw string) (string, error) {
+	if raw == "" {
+		return "", fmt.Errorf("must not be empty")
+	}
+	return raw, nil
+}
+
+func formatString(value string) string { return value }
+
+func intAtLeast(minValue int) func(string) (int, error) {
+	return func(raw string) (int, error) {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an integer", raw)
+		}
+		if parsed < minValue {
+			return 0, fmt.Errorf("%d is less than %d", parsed, minValue)
+		}
+		return parsed, nil
+	}
+}
+
+// parseDuration разбирает целое число в единицах unit (секунды, дни) или строку Go duration ("1m30s").
+func parseDuration(raw string, unit time.Duration) (time.Duration, error) {
+	if whole, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Duration(whole) * unit, nil
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration", raw)
+	}
+	return parsed, nil
+}
+
+func positiveDuration(unit time.Duration) func(string) (time.Duration, error) {
+	return func(raw string) (time.Duration, error) {
+		parsed, err := parseDuration(raw, unit)
+		if err != nil {
+			return 0, err
+		}
+		if parsed <= 0 {
+			return 0, fmt.Errorf("%q must be positive", raw)
+		}
+		return parsed, nil
+	}
+}
+
+func nonNegativeDuration(unit time.Duration) func(string) (time.Duration, error) {
+	return func(raw string) (time.Duration, error) {
+		parsed, err := parseDuration(raw, unit)
+		if err != nil {
+			return 0, err
+		}
+		if parsed < 0 {
+			return 0, fmt.Errorf("%q must not be negative", raw)
+		}
+		return parsed, nil
+	}
+}
+
+func formatDuration(value time.Duration) string { return value.String() }
+
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(raw) {
+	case "true", "1", "yes", "on":
+		return true, nil
+	case "false", "0", "no", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%q is not a boolean", raw)
+	}
+}
+
+func parseNonNegativeDecimal(raw string) (decimal.Decimal, error) {
+	parsed, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%q is not a number", raw)
+	}
+	if parsed.IsNegative() {
+		return decimal.Zero, fmt.Errorf("%q must not be negative", raw)
+	}
+	return parsed, nil
+}
+
+func parseRatio(raw string) (float64, error) {
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", raw)
+	}
+	if parsed < 0 || parsed > 1 {
+		return 0, fmt.Errorf("%q is outside [0, 1]", raw)
+	}
+	return parsed, nil
+}
+
+func formatFloat(value float64) string { return strconv.FormatFloat(value, 'f', -1, 64) }
+
+// parseSchedule проверяет расписание фоновой задачи и возвращает его без изменений; пустое расписание
+// отключает задачу.
+func parseSchedule(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	if _, err := scheduler.Parse(raw); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+func parseLogLevel(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	if _, err := zerolog.ParseLevel(strings.ToLower(raw)); err != nil {
+		return "", fmt.Errorf("%q is not a log level", raw)
+	}
+	return raw, nil
+}
+
+// parseTierRules разбирает правила уровней в формате "NAME:THRESHOLD[:MULTIPLIER],...".
+// Если множитель не указан, используется 1 (уровень не влияет на начисления).
+func parseTierRules(spec string) ([]TierRule, error) {
+	var rules []TierRule
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("%w: %q", errInvalidTierRules, item)
+		}
+		name := strings.ToUpper(strings.TrimSpace(parts[0]))
+		threshold, err := decimal.NewFromString(strings.TrimSpace(parts[1]))
+		if name == "" || err != nil {
+			return nil, fmt.Errorf("%w: %q", errInvalidTierRules, item)
+		}
+		multiplier := decimal.NewFromInt(1)
+		if len(parts) == 3 {
+			multiplier, err = decimal.NewFromString(strings.TrimSpace(parts[2]))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", errInvalidTierRules, item)
+			}
+		}
+		rules = append(rules, TierRule{Name: name, Threshold: threshold, Multiplier: multiplier})
+	}
+	return rules, nil
+}
+
+func formatTierRules(rules []TierRule) string {
+	items := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, rule.Name+":"+rule.Threshold.String()+":"+rule.Multiplier.String())
+	}
+	return strings.Join(items, ",")
+}
+
+// accrualProviderName — допустимое имя провайдера accrual (используется в метках метрик и логах).
+var accrualProviderName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// parseAccrualProviders разбирает дополнительные системы accrual в формате
+// "NAME=URL;key=value;...,NAME=URL;...". Ключи условий: prefix, length, regex (хотя бы одно обязательно);
+// ключи настроек: timeout, rate_limit, rate_limit_max, breaker_failures, breaker_open_timeout,
+// breaker_half_open_probes, retry_count, retry_backoff, retry_statuses (коды через "|").
+// Запятая внутри regex не поддерживается.
+func parseAccrualProviders(spec string) ([]AccrualProvider, error) {
+	var providers []AccrualProvider
+	seen := make(map[string]struct{})
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		provider, err := parseAccrualProvider(item)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", errInvalidAccrualProviders, item, err)
+		}
+		if _, ok := seen[provider.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate provider %q", errInvalidAccrualProviders, provider.Name)
+		}
+		seen[provider.Name] = struct{}{}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+func parseAccrualProvider(item string) (AccrualProvider, error) {
+	parts := strings.Split(item, ";")
+	name, address, ok := strings.Cut(parts[0], "=")
+	provider := AccrualProvider{Name: strings.ToLower(strings.TrimSpace(name)), Address: strings.TrimSpace(address)}
+	if !ok || provider.Address == "" {
+		return AccrualProvider{}, errors.New("want NAME=URL first")
+	}
+	if !accrualProviderName.MatchString(provider.Name) || provider.Name == "default" {
+		return AccrualProvider{}, fmt.Errorf("invalid provider name %q", provider.Name)
+	}
+
+	for _, option := range parts[1:] {
+		key, value, ok := strings.Cut(option, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return AccrualProvider{}, fmt.Errorf("want key=value, got %q", option)
+		}
+		var err error
+		switch key {
+		case "prefix":
+			provider.Prefix = value
+		case "length":
+			provider.Length, err = intAtLeast(1)(value)
+		case "regex":
+			provider.Pattern, err = regexp.Compile(value)
+		case "timeout":
+			provider.Timeout, err = positiveDuration(time.Second)(value)
+		case "rate_limit":
+			provider.RateLimit, err = intAtLeast(1)(value)
+		case "rate_limit_max":
+			provider.RateLimitMax, err = intAtLeast(1)(value)
+		case "breaker_failures":
+			provider.BreakerFailures, err = intAtLeast(1)(value)
+		case "breaker_open_timeout":
+			provider.BreakerOpenTimeout, err = positiveDuration(time.Second)(value)
+		case "breaker_half_open_probes":
+			provider.BreakerHalfOpenProbes, err = intAtLeast(1)(value)
+		case "retry_count":
+			var retryCount int
+			retryCount, err = intAtLeast(0)(value)
+			provider.RetryCount = &retryCount
+		case "retry_backoff":
+			provider.RetryBackoff, err = positiveDuration(time.Millisecond)(value)
+		case "retry_statuses":
+			provider.RetryStatuses, err = parseRetryStatuses(value)
+		default:
+			err = errors.New("unknown key")
+		}
+		if err != nil {
+			return AccrualProvider{}, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if provider.Prefix == "" && provider.Length == 0 && provider.Pattern == nil {
+		return AccrualProvider{}, errors.New("want at least one of prefix, length, regex")
+	}
+	return provider, nil
+}
+
+func formatAccrualProviders(providers []AccrualProvider) string {
+	items := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		parts := []string{provider.Name + "=" + provider.Address}
+		if provider.Prefix != "" {
+			parts = append(parts, "prefix="+provider.Prefix)
+		}
+		if provider.Length > 0 {
+			parts = append(parts, "length="+strconv.Itoa(provider.Length))
+		}
+		if provider.Pattern != nil {
+			parts = append(parts, "regex="+provider.Pattern.String())
+		}
+		if provider.Timeout > 0 {
+			parts = append(parts, "timeout="+formatDuration(provider.Timeout))
+		}
+		if provider.RateLimit > 0 {
+			parts = append(parts, "rate_limit="+strconv.Itoa(provider.RateLimit))
+		}
+		if provider.RateLimitMax > 0 {
+			parts = append(parts, "rate_limit_max="+strconv.Itoa(provider.RateLimitMax))
+		}
+		if provider.BreakerFailures > 0 {
+			parts = append(parts, "breaker_failures="+strconv.Itoa(provider.BreakerFailures))
+		}
+		if provider.BreakerOpenTimeout > 0 {
+			parts = append(parts, "breaker_open_timeout="+formatDuration(provider.BreakerOpenTimeout))
+		}
+		if provider.BreakerHalfOpenProbes > 0 {
+			parts = append(parts, "breaker_half_open_probes="+strconv.Itoa(provider.BreakerHalfOpenProbes))
+		}
+		if provider.RetryCount != nil {
+			parts = append(parts, "retry_count="+strconv.Itoa(*provider.RetryCount))
+		}
+		if provider.RetryBackoff > 0 {
+			parts = append(parts, "retry_backoff="+formatDuration(provider.RetryBackoff))
+		}
+		if len(provider.RetryStatuses) > 0 {
+			parts = append(parts, "retry_statuses="+strings.ReplaceAll(formatRetryStatuses(provider.RetryStatuses), ",", "|"))
+		}
+		items = append(items, strings.Join(parts, ";"))
+	}
+	return strings.Join(items, ",")
+}
+
+// parseRetryStatuses разбирает коды ответа accrual для повторов: через запятую или "|",
+// допускаются 4xx и 5xx, кроме 429 (у него своя обработка — Retry-After и снижение лимита).
+// Пустая строка — не повторять по кодам ответа.
+func parseRetryStatuses(raw string) ([]int, error) {
+	var statuses []int
+	for _, item := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '|' }) {
+		status, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || status < 400 || status > 599 || status == 429 {
+			return nil, fmt.Errorf("%q is not a retryable status code (4xx or 5xx except 429)", item)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func formatRetryStatuses(statuses []int) string {
+	items := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		items = append(items, strconv.Itoa(status))
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// readFile читает файл конфигурации (YAML или TOML по расширению) и возвращает значения по ключам схемы.
+// Секции файла соответствуют префиксам ключей: database.max_open_conns задаётся как
+// max_open_conns в секции database. Неизвестные ключи — ошибка.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	document := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return nil, fmt.Errorf("%w: unsupported config file extension %q (want .yaml, .yml or .toml)", ErrInvalidConfig, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse %s: %w", ErrInvalidConfig, path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten("", document, values); err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for key := range values {
+		if _, ok := fieldByKey[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: unknown keys in %s: %s", ErrInvalidConfig, path, strings.Join(unknown, ", "))
+	}
+	return values, nil
+}
+
+// flatten раскладывает вложенные секции в плоские ключи через точку.
+// Списки скаляров склеиваются через запятую (например, tier.rules).
+func flatten(prefix string, node map[string]any, out map[string]string) error {
+	for name, value := range node {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		switch typed := value.(type) {
+		case map[string]any:
+			if err := flatten(key, typed, out); err != nil {
+				return err
+			}
+		case []any:
+			items := make([]string, 0, len(typed))
+			for _, item := range typed {
+				if _, nested := item.(map[string]any); nested {
+					return fmt.Errorf("%w: %s: nested tables in lists are not supported", ErrInvalidConfig, key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(typed)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func envMap(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		val, ok := values[key]
+		return val, ok
+	}
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_YAMLFile(t *testing.T) {
+	path := writeFile(t, "loyalty.yaml", `
+run_address: ":7000"
+database:
+  uri: postgres://file
+  max_open_conns: 40
+  query_timeout: 2s
+accrual:
+  timeout: 1500ms
+worker:
+  poll_interval: 10
+  max_concurrency: 8
+tier:
+  rules: [ "silver:100", "GOLD:500:1.5" ]
+tracing:
+  sample_ratio: 0.5
+`)
+
+	cfg, err := load([]string{"-config", path}, envMap(nil))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.RunAddress != ":7000" || cfg.DatabaseURI != "postgres://file" || cfg.DBMaxOpenConns != 40 {
+		t.Fatalf("unexpected values from file: %+v", cfg)
+	}
+	if cfg.DBQueryTimeout != 2*time.Second || cfg.AccrualTimeout != 1500*time.Millisecond {
+		t.Fatalf("unexpected durations: query=%v accrual=%v", cfg.DBQueryTimeout, cfg.AccrualTimeout)
+	}
+	if cfg.WorkerPollInterval != 10*time.Second || cfg.WorkerMaxConcurrency != 8 {
+		t.Fatalf("unexpected worker settings: %v %d", cfg.WorkerPollInterval, cfg.WorkerMaxConcurrency)
+	}
+	if len(cfg.TierRules) != 2 || cfg.TierRules[1].Multiplier.String() != "1.5" {
+		t.Fatalf("unexpected tier rules: %+v", cfg.TierRules)
+	}
+	if cfg.Tracing.SampleRatio != 0.5 {
+		t.Fatalf("unexpected sample ratio: %v", cfg.Tracing.SampleRatio)
+	}
+	if cfg.WorkerRetryAfter != 60*time.Second {
+		t.Fatalf("expected default retry_after, got %v", cfg.WorkerRetryAfter)
+	}
+}
+
+func TestLoad_TOMLFileFromEnv(t *testing.T) {
+	path := writeFile(t, "loyalty.toml", `
+run_address = ":7100"
+
+[referral]
+bonus = "25.5"
+max_rewards = 2
+
+[log]
+http_bodies = true
+`)
+
+	cfg, err := load(nil, envMap(map[string]string{configFileEnv: path}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.ConfigFile != path || cfg.RunAddress != ":7100" {
+		t.Fatalf("unexpected values from file: %+v", cfg)
+	}
+	if cfg.ReferralBonus.String() != "25.5" || cfg.ReferralMaxRewards != 2 || !cfg.EnableHTTPBodyLogging {
+		t.Fatalf("unexpected referral/log values: %+v", cfg)
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, "loyalty.yml", "worker:\n  max_concurrency: 3\n  poll_interval: 7s\nauth:\n  rate_limit_rps: 5\n")
+	env := envMap(map[string]string{"WORKER_MAX_CONCURRENCY": "4", "AUTH_RATE_LIMIT_RPS": "6"})
+
+	cfg, err := load([]string{"-config", path, "-worker-max-concurrency", "9"}, env)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.WorkerPollInterval != 7*time.Second {
+		t.Fatalf("file value expected, got %v", cfg.WorkerPollInterval)
+	}
+	if cfg.AuthRateLimitRPS != 6 {
+		t.Fatalf("env must override file, got %d", cfg.AuthRateLimitRPS)
+	}
+	if cfg.WorkerMaxConcurrency != 9 {
+		t.Fatalf("flag must override env, got %d", cfg.WorkerMaxConcurrency)
+	}
+}
+
+func TestLoad_FlagForEveryField(t *testing.T) {
+	cfg, err := load([]string{
+		"-run-address", ":9000",
+		"-database-query-timeout", "4",
+		"-accrual-timeout", "2s",
+		"-log-http-bodies",
+		"-tracing-exporter", "stdout",
+		"-shutdown-drain-delay", "0",
+		"-print-config",
+	}, envMap(nil))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if cfg.RunAddress != ":9000" || cfg.DBQueryTimeout != 4*time.Second || cfg.AccrualTimeout != 2*time.Second {
+		t.Fatalf("unexpected values from flags: %+v", cfg)
+	}
+	if !cfg.EnableHTTPBodyLogging || cfg.Tracing.Exporter != "stdout" || cfg.ShutdownDrainDelay != 0 || !cfg.PrintConfig {
+		t.Fatalf("unexpected values from flags: %+v", cfg)
+	}
+
+	for _, f := range fields {
+		if f.flag == "" {
+			t.Errorf("field %s has no flag", f.key)
+		}
+	}
+}
+
+func TestLoad_StrictValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		file string
+	}{
+		{name: "non-numeric duration env", env: map[string]string{"DB_QUERY_TIMEOUT": "abc"}},
+		{name: "negative int env", env: map[string]string{"DB_MAX_OPEN_CONNS": "-1"}},
+		{name: "zero concurrency flag", args: []string{"-worker-max-concurrency", "0"}},
+		{name: "invalid bool", env: map[string]string{"LOG_HTTP_BODIES": "maybe"}},
+		{name: "invalid log level", env: map[string]string{"LOG_LEVEL": "loud"}},
+		{name: "negative decimal", env: map[string]string{"REFERRAL_BONUS": "-5"}},
+		{name: "ratio out of range", env: map[string]string{"TRACING_SAMPLE_RATIO": "2"}},
+		{name: "idle exceeds open", env: map[string]string{"DB_MAX_OPEN_CONNS": "5", "DB_MAX_IDLE_CONNS": "6"}},
+		{name: "unknown flag", args: []string{"-no-such-flag", "1"}},
+		{name: "unknown file key", file: "database:\n  uri: x\n  pool_size: 3\n"},
+		{name: "invalid file value", file: "worker:\n  poll_interval: soon\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append(args, "-config", writeFile(t, "bad.yaml", tt.file))
+			}
+			if _, err := load(args, envMap(tt.env)); err == nil {
+				t.Fatalf("expected validation error")
+			}
+		})
+	}
+}
+
+func TestLoad_InvalidValueWrapsErrInvalidConfig(t *testing.T) {
+	_, err := load(nil, envMap(map[string]string{"DB_QUERY_TIMEOUT": "abc"}))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestLoad_UnsupportedFileExtension(t *testing.T) {
+	path := writeFile(t, "loyalty.json", "{}")
+	if _, err := load([]string{"-config", path}, envMap(nil)); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestLoad_ExampleConfig(t *testing.T) {
+	cfg, err := load([]string{"-config", "../../config.example.yaml"}, envMap(nil))
+	if err != nil {
+		t.Fatalf("example config must be valid: %v", err)
+	}
+	if len(cfg.TierRules) != 3 || cfg.TierWindow != 90*24*time.Hour {
+		t.Fatalf("unexpected tier settings: %+v %v", cfg.TierRules, cfg.TierWindow)
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// redacted — подстановка для секретных значений при выводе конфигурации.
+const redacted = "[redacted]"
+
+// Print выводит итоговую конфигурацию в формате YAML с плоскими ключами (пригоден как файл -config).
+// Секреты скрываются; у DATABASE_URI скрывается только пароль.
+func Print(w io.Writer, cfg Config) error {
+	if cfg.ConfigFile != "" {
+		if _, err := fmt.Fprintf(w, "# config file: %s\n", cfg.ConfigFile); err != nil {
+			return err
+		}
+	}
+	for _, f := range fields {
+		value := f.get(cfg)
+		if f.secret {
+			value = redactValue(f.key, value)
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", f.key, strconv.Quote(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactValue скрывает значение секретного параметра; пустое значение остаётся пустым.
+func redactValue(key string, value string) string {
+	if value == "" {
+		return ""
+	}
+	if key == keyDatabaseURI {
+		if parsed, err := url.Parse(value); err == nil && parsed.Scheme != "" {
+			return parsed.Redacted()
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	cfg, err := load(nil, envMap(map[string]string{
+		"DATABASE_URI": "postgres://app:s3cret@db:5432/loyalty",
+		"JWT_SECRET":   "jwt-secret-value",
+		"ADMIN_TOKEN":  "admin-token-value",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := Print(&out, cfg); err != nil {
+		t.Fatalf("print: %v", err)
+	}
+	printed := out.String()
+
+	for _, secretValue := range []string{"s3cret", "jwt-secret-value", "admin-token-value"} {
+		if strings.Contains(printed, secretValue) {
+			t.Fatalf("secret %q leaked:\n%s", secretValue, printed)
+		}
+	}
+	for _, line := range []string{
+		`database.uri: "postgres://app:xxxxx@db:5432/loyalty"`,
+		`auth.jwt_secret: "[redacted]"`,
+		`admin.token: "[redacted]"`,
+		`worker.poll_interval: "5s"`,
+	} {
+		if !strings.Contains(printed, line) {
+			t.Fatalf("expected line %q in:\n%s", line, printed)
+		}
+	}
+}
+
+func TestPrint_OutputIsLoadable(t *testing.T) {
+	cfg, err := load(nil, envMap(map[string]string{"WORKER_MAX_CONCURRENCY": "7", "TIER_RULES": "SILVER:10:1.2"}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	cfg.JWTSecret = ""
+
+	var out bytes.Buffer
+	if err := Print(&out, cfg); err != nil {
+		t.Fatalf("print: %v", err)
+	}
+
+	reloaded, err := load([]string{"-config", writeFile(t, "printed.yaml", out.String())}, envMap(nil))
+	if err != nil {
+		t.Fatalf("reload printed config: %v\n%s", err, out.String())
+	}
+	if reloaded.WorkerMaxConcurrency != 7 || formatTierRules(reloaded.TierRules) != "SILVER:10:1.2" {
+		t.Fatalf("unexpected reloaded config: %+v", reloaded)
+	}
+}
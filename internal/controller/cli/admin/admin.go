@@ -0,0 +1,203 @@
+// Package admin — CLI-контроллер подкоманды `loyalty admin` для задач поддержки.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	adminmodel "loyalty/internal/domain/admin/model"
+	adminuc "loyalty/internal/domain/admin/usecase"
+
+	"github.com/shopspring/decimal"
+)
+
+// Действия подкоманды admin.
+const (
+	ActionUser        = "user"
+	ActionOrders      = "orders"
+	ActionWithdrawals = "withdrawals"
+	ActionRequeue     = "requeue"
+	ActionAdjust      = "adjust"
+	ActionDump        = "dump"
+)
+
+// ErrUsage возвращается при некорректных аргументах подкоманды admin.
+var ErrUsage = errors.New("invalid admin usage")
+
+// Usage — справка по действиям подкоманды admin.
+const Usage = `admin actions:
+  user        (-login LOGIN | -user-id ID)                          user and balance
+  orders      (-login LOGIN | -user-id ID)                          user's orders
+  withdrawals (-login LOGIN | -user-id ID)                          user's withdrawals
+  requeue     -order NUMBER                                         return order to the accrual queue
+  adjust      (-login LOGIN | -user-id ID) -amount N -reason TEXT   credit (N > 0) or debit (N < 0) balance
+              [-actor NAME]                                         (actor defaults to $USER)
+  dump        (-login LOGIN | -user-id ID)                          all user data as JSON
+`
+
+// Request — разобранные аргументы действия admin.
+type Request struct {
+	Action string
+	User   adminmodel.UserRef
+	Order  string
+	Amount decimal.Decimal
+	Reason string
+	Actor  string
+}
+
+// ParseRequest разбирает "ACTION [flags]".
+func ParseRequest(args []string) (Request, error) {
+	if len(args) == 0 {
+		return Request{}, fmt.Errorf("%w: admin requires an action", ErrUsage)
+	}
+	request := Request{Action: args[0]}
+
+	flags := flag.NewFlagSet("admin "+request.Action, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var amount string
+	switch request.Action {
+	case ActionUser, ActionOrders, ActionWithdrawals, ActionDump:
+		bindUser(flags, &request.User)
+	case ActionRequeue:
+		flags.StringVar(&request.Order, "order", "", "order number")
+	case ActionAdjust:
+		bindUser(flags, &request.User)
+		flags.StringVar(&amount, "amount", "", "signed amount")
+		flags.StringVar(&request.Reason, "reason", "", "adjustment reason")
+		flags.StringVar(&request.Actor, "actor", os.Getenv("USER"), "who performs the adjustment")
+	default:
+		return Request{}, fmt.Errorf("%w: unknown admin action %q", ErrUsage, request.Action)
+	}
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return Request{}, fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	if flags.NArg() > 0 {
+		return Request{}, fmt.Errorf("%w: unexpected arguments %v", ErrUsage, flags.Args())
+	}
+
+	switch request.Action {
+	case ActionRequeue:
+		if strings.TrimSpace(request.Order) == "" {
+			return Request{}, fmt.Errorf("%w: requeue requires -order", ErrUsage)
+		}
+		return request, nil
+	case ActionAdjust:
+		parsed, err := decimal.NewFromString(strings.TrimSpace(amount))
+		if err != nil {
+			return Request{}, fmt.Errorf("%w: adjust requires a numeric -amount, got %q", ErrUsage, amount)
+		}
+		request.Amount = parsed
+		if strings.TrimSpace(request.Reason) == "" {
+			return Request{}, fmt.Errorf("%w: adjust requires -reason", ErrUsage)
+		}
+	}
+	if err := request.User.Validate(); err != nil {
+		return Request{}, fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	return request, nil
+}
+
+func bindUser(flags *flag.FlagSet, ref *adminmodel.UserRef) {
+	flags.StringVar(&ref.Login, "login", "", "user login")
+	flags.Int64Var(&ref.ID, "user-id", 0, "user id")
+}
+
+// Handler выполняет действия admin через usecase и печатает результат в out.
+type Handler struct {
+	usecase adminuc.AdminUsecase
+	out     io.Writer
+}
+
+// NewHandler создаёт обработчик подкоманды admin.
+func NewHandler(usecase adminuc.AdminUsecase, out io.Writer) *Handler {
+	return &Handler{usecase: usecase, out: out}
+}
+
+// Handle выполняет разобранное действие. Таблицы выводятся через tabwriter, dump — JSON.
+func (handler *Handler) Handle(ctx context.Context, request Request) error {
+	switch request.Action {
+	case ActionUser:
+		summary, err := handler.usecase.FindUser(ctx, request.User)
+		if err != nil {
+			return err
+		}
+		return handler.table(func(w io.Writer) {
+			_, _ = fmt.Fprintln(w, "ID\tLOGIN\tCURRENT\tWITHDRAWN")
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", summary.ID, summary.Login, summary.Balance.Current, summary.Balance.Withdrawn)
+		})
+	case ActionOrders:
+		orders, err := handler.usecase.ListOrders(ctx, request.User)
+		if err != nil {
+			return err
+		}
+		return handler.table(func(w io.Writer) {
+			_, _ = fmt.Fprintln(w, "NUMBER\tSTATUS\tACCRUAL\tUPLOADED_AT")
+			for _, order := range orders {
+				accrual := "-"
+				if order.Accrual != nil {
+					accrual = order.Accrual.String()
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", order.Number, order.Status, accrual, formatTime(order.UploadedAt))
+			}
+		})
+	case ActionWithdrawals:
+		withdrawals, err := handler.usecase.ListWithdrawals(ctx, request.User)
+		if err != nil {
+			return err
+		}
+		return handler.table(func(w io.Writer) {
+			_, _ = fmt.Fprintln(w, "ORDER\tSUM\tPROCESSED_AT")
+			for _, withdrawal := range withdrawals {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", withdrawal.OrderNumber, withdrawal.Sum, formatTime(withdrawal.ProcessedAt))
+			}
+		})
+	case ActionRequeue:
+		order, err := handler.usecase.RequeueOrder(ctx, request.Order)
+		if err != nil {
+			return err
+		}
+		return handler.table(func(w io.Writer) {
+			_, _ = fmt.Fprintln(w, "NUMBER\tUSER_ID\tSTATUS")
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", order.Number, order.UserID, order.Status)
+		})
+	case ActionAdjust:
+		adjustment, err := handler.usecase.AdjustBalance(ctx, request.User, request.Amount, request.Reason, request.Actor)
+		if err != nil {
+			return err
+		}
+		return handler.table(func(w io.Writer) {
+			_, _ = fmt.Fprintln(w, "ID\tUSER_ID\tAMOUNT\tREASON\tACTOR\tCREATED_AT")
+			_, _ = fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\n",
+				adjustment.ID, adjustment.UserID, adjustment.Amount, adjustment.Reason, adjustment.Actor, formatTime(adjustment.CreatedAt))
+		})
+	case ActionDump:
+		dump, err := handler.usecase.DumpUser(ctx, request.User)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(handler.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newDumpResponse(dump))
+	default:
+		return fmt.Errorf("%w: unknown admin action %q", ErrUsage, request.Action)
+	}
+}
+
+func (handler *Handler) table(write func(w io.Writer)) error {
+	w := tabwriter.NewWriter(handler.out, 0, 0, 2, ' ', 0)
+	write(w)
+	return w.Flush()
+}
+
+func formatTime(at time.Time) string {
+	return at.UTC().Format(time.RFC3339)
+}
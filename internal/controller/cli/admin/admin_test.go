@@ -0,0 +1,164 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	adjustmentmodel "loyalty/internal/domain/adjustment/model"
+	adminmodel "loyalty/internal/domain/admin/model"
+	balancemodel "loyalty/internal/domain/balance/model"
+	ordermodel "loyalty/internal/domain/order/model"
+	statementmodel "loyalty/internal/domain/statement/model"
+	withdrawalmodel "loyalty/internal/domain/withdrawal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockUsecase struct {
+	ref    adminmodel.UserRef
+	reason string
+}
+
+func (m *mockUsecase) FindUser(_ context.Context, ref adminmodel.UserRef) (adminmodel.UserSummary, error) {
+	m.ref = ref
+	return adminmodel.UserSummary{ID: 7, Login: "alice", Balance: balancemodel.Balance{
+		Current:   decimal.RequireFromString("729.98"),
+		Withdrawn: decimal.NewFromInt(20),
+	}}, nil
+}
+
+func (m *mockUsecase) ListOrders(context.Context, adminmodel.UserRef) ([]ordermodel.Order, error) {
+	return nil, nil
+}
+
+func (m *mockUsecase) ListWithdrawals(context.Context, adminmodel.UserRef) ([]withdrawalmodel.Withdrawal, error) {
+	return nil, nil
+}
+
+func (m *mockUsecase) RequeueOrder(_ context.Context, number string) (ordermodel.Order, error) {
+	return ordermodel.Order{Number: number, UserID: 7, Status: ordermodel.StatusNew}, nil
+}
+
+func (m *mockUsecase) AdjustBalance(
+	_ context.Context,
+	ref adminmodel.UserRef,
+	amount decimal.Decimal,
+	reason, actor string,
+) (adjustmentmodel.Adjustment, error) {
+	m.ref, m.reason = ref, reason
+	return adjustmentmodel.Adjustment{ID: 1, UserID: 7, Amount: amount, Reason: reason, Actor: actor}, nil
+}
+
+func (m *mockUsecase) DumpUser(context.Context, adminmodel.UserRef) (adminmodel.UserDump, error) {
+	accrual := decimal.NewFromInt(500)
+	at := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	return adminmodel.UserDump{
+		User:   adminmodel.UserSummary{ID: 7, Login: "alice"},
+		Orders: []ordermodel.Order{{Number: "79927398713", Status: ordermodel.StatusProcessed, Accrual: &accrual, UploadedAt: at}},
+		Statement: statementmodel.Statement{
+			Entries:     []statementmodel.Entry{{Type: statementmodel.EntryAdjustment, Description: "goodwill", Amount: decimal.NewFromInt(50), At: at}},
+			GeneratedAt: at,
+		},
+	}, nil
+}
+
+func TestParseRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    Request
+		wantErr bool
+	}{
+		{name: "user by login", args: []string{"user", "-login", "alice"}, want: Request{Action: ActionUser, User: adminmodel.UserRef{Login: "alice"}}},
+		{name: "orders by id", args: []string{"orders", "-user-id", "7"}, want: Request{Action: ActionOrders, User: adminmodel.UserRef{ID: 7}}},
+		{name: "requeue", args: []string{"requeue", "-order", "79927398713"}, want: Request{Action: ActionRequeue, Order: "79927398713"}},
+		{
+			name: "adjust",
+			args: []string{"adjust", "-login", "alice", "-amount", "-12.5", "-reason", "duplicate accrual", "-actor", "bob"},
+			want: Request{Action: ActionAdjust, User: adminmodel.UserRef{Login: "alice"}, Amount: decimal.RequireFromString("-12.5"), Reason: "duplicate accrual", Actor: "bob"},
+		},
+		{name: "no action", args: nil, wantErr: true},
+		{name: "unknown action", args: []string{"purge"}, wantErr: true},
+		{name: "no user", args: []string{"dump"}, wantErr: true},
+		{name: "both user refs", args: []string{"user", "-login", "alice", "-user-id", "7"}, wantErr: true},
+		{name: "requeue without order", args: []string{"requeue"}, wantErr: true},
+		{name: "adjust without reason", args: []string{"adjust", "-login", "alice", "-amount", "10"}, wantErr: true},
+		{name: "adjust with bad amount", args: []string{"adjust", "-login", "alice", "-amount", "ten", "-reason", "x"}, wantErr: true},
+		{name: "extra arguments", args: []string{"user", "-login", "alice", "extra"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRequest(tt.args)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUsage) {
+					t.Fatalf("want ErrUsage, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if got.Action != tt.want.Action || got.User != tt.want.User || got.Order != tt.want.Order ||
+				!got.Amount.Equal(tt.want.Amount) || got.Reason != tt.want.Reason || got.Actor != tt.want.Actor {
+				t.Fatalf("ParseRequest(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRequest_ActorDefaultsToUser(t *testing.T) {
+	t.Setenv("USER", "support-oncall")
+
+	got, err := ParseRequest([]string{"adjust", "-user-id", "7", "-amount", "5", "-reason", "goodwill"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Actor != "support-oncall" {
+		t.Fatalf("want actor from $USER, got %q", got.Actor)
+	}
+}
+
+func TestHandler_User(t *testing.T) {
+	var out bytes.Buffer
+	usecase := &mockUsecase{}
+
+	if err := NewHandler(usecase, &out).Handle(context.Background(), Request{Action: ActionUser, User: adminmodel.UserRef{Login: "alice"}}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "alice") || !strings.Contains(lines[1], "729.98") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	if usecase.ref.Login != "alice" {
+		t.Fatalf("expected user to be looked up by login, got %+v", usecase.ref)
+	}
+}
+
+func TestHandler_Dump(t *testing.T) {
+	var out bytes.Buffer
+
+	if err := NewHandler(&mockUsecase{}, &out).Handle(context.Background(), Request{Action: ActionDump, User: adminmodel.UserRef{ID: 7}}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	var dump struct {
+		User        dumpUser         `json:"user"`
+		Orders      []dumpOrder      `json:"orders"`
+		Withdrawals []dumpWithdrawal `json:"withdrawals"`
+		Statement   []dumpEntry      `json:"statement"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &dump); err != nil {
+		t.Fatalf("dump is not valid JSON: %v\n%s", err, out.String())
+	}
+	if dump.User.Login != "alice" || len(dump.Orders) != 1 || dump.Withdrawals == nil || len(dump.Statement) != 1 {
+		t.Fatalf("unexpected dump: %+v", dump)
+	}
+	if dump.Statement[0].Type != string(statementmodel.EntryAdjustment) || dump.Statement[0].Description != "goodwill" {
+		t.Fatalf("unexpected statement entry: %+v", dump.Statement[0])
+	}
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"time"
+
+	adminmodel "loyalty/internal/domain/admin/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// dumpResponse — JSON-представление данных пользователя для `admin dump`.
+type dumpResponse struct {
+	User        dumpUser         `json:"user"`
+	Balance     dumpBalance      `json:"balance"`
+	Orders      []dumpOrder      `json:"orders"`
+	Withdrawals []dumpWithdrawal `json:"withdrawals"`
+	Statement   []dumpEntry      `json:"statement"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+type dumpUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type dumpBalance struct {
+	Current   decimal.Decimal `json:"current"`
+	Withdrawn decimal.Decimal `json:"withdrawn"`
+}
+
+type dumpOrder struct {
+	Number     string           `json:"number"`
+	Status     string           `json:"status"`
+	Accrual    *decimal.Decimal `json:"accrual,omitempty"`
+	UploadedAt time.Time        `json:"uploaded_at"`
+}
+
+type dumpWithdrawal struct {
+	Order       string          `json:"order"`
+	Sum         decimal.Decimal `json:"sum"`
+	ProcessedAt time.Time       `json:"processed_at"`
+}
+
+type dumpEntry struct {
+	Type        string          `json:"type"`
+	Reference   string          `json:"reference,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Amount      decimal.Decimal `json:"amount"`
+	Balance     decimal.Decimal `json:"balance"`
+	At          time.Time       `json:"at"`
+}
+
+func newDumpResponse(dump adminmodel.UserDump) dumpResponse {
+	response := dumpResponse{
+		User:        dumpUser{ID: dump.User.ID, Login: dump.User.Login},
+		Balance:     dumpBalance{Current: dump.User.Balance.Current, Withdrawn: dump.User.Balance.Withdrawn},
+		Orders:      make([]dumpOrder, 0, len(dump.Orders)),
+		Withdrawals: make([]dumpWithdrawal, 0, len(dump.Withdrawals)),
+		Statement:   make([]dumpEntry, 0, len(dump.Statement.Entries)),
+		GeneratedAt: dump.Statement.GeneratedAt.UTC(),
+	}
+	for _, order := range dump.Orders {
+		response.Orders = append(response.Orders, dumpOrder{
+			Number:     order.Number,
+			Status:     string(order.Status),
+			Accrual:    order.Accrual,
+			UploadedAt: order.UploadedAt.UTC(),
+		})
+	}
+	for _, withdrawal := range dump.Withdrawals {
+		response.Withdrawals = append(response.Withdrawals, dumpWithdrawal{
+			Order:       withdrawal.OrderNumber,
+			Sum:         withdrawal.Sum,
+			ProcessedAt: withdrawal.ProcessedAt.UTC(),
+		})
+	}
+	for _, entry := range dump.Statement.Entries {
+		response.Statement = append(response.Statement, dumpEntry{
+			Type:        string(entry.Type),
+			Reference:   entry.Reference,
+			Description: entry.Description,
+			Amount:      entry.Amount,
+			Balance:     entry.Balance,
+			At:          entry.At.UTC(),
+		})
+	}
+	return response
+}
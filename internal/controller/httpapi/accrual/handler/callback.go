@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"loyalty/internal/controller/httpapi/accrual/model"
+	"net/http"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	accrualmodel "loyalty/internal/domain/accrual/model"
+	accrualusecase "loyalty/internal/domain/accrual/usecase"
+	"loyalty/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler — HTTP-хендлер обратных вызовов системы начислений.
+type Handler struct {
+	usecase accrualusecase.AccrualCallbackUsecase
+}
+
+// NewHandler создаёт хендлер обратных вызовов accrual.
+func NewHandler(usecase accrualusecase.AccrualCallbackUsecase) *Handler {
+	return &Handler{usecase: usecase}
+}
+
+// Callback принимает один результат расчёта (объект) или пакет результатов (массив) в формате
+// ответа GET /api/orders/{number} системы accrual. Для одного результата ответ — его исход
+// или ошибка с соответствующим статусом; для пакета — 200 со списком исходов по порядку,
+// или 500, если хотя бы один результат не применён из-за внутренней ошибки (пакет можно
+// повторить целиком: повторное применение того же статуса ничего не меняет).
+func (handler *Handler) Callback(ctx *gin.Context) {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeInvalidInput)
+		return
+	}
+	results, batch, err := decode(body)
+	if err != nil {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeInvalidInput)
+		return
+	}
+
+	errs := handler.usecase.ApplyResults(ctx, results)
+	resp := make([]model.CallbackResultResponse, len(results))
+	status := http.StatusOK
+	for i, result := range results {
+		itemStatus, code := common.MapError(errs[i])
+		if errs[i] == nil {
+			code = model.ResultApplied
+		}
+		if itemStatus == http.StatusInternalServerError || !batch {
+			status = itemStatus
+		}
+		metrics.AccrualCallbackItems.WithLabelValues(code).Inc()
+		resp[i] = model.CallbackResultResponse{Order: result.Order, Result: code}
+	}
+
+	if !batch {
+		if errs[0] != nil {
+			common.WriteError(ctx, status, resp[0].Result)
+			return
+		}
+		ctx.JSON(status, resp[0])
+		return
+	}
+	ctx.JSON(status, resp)
+}
+
+// decode разбирает объект или непустой массив результатов; Raw каждого результата — его исходный JSON.
+func decode(body []byte) (_ []accrualmodel.Accrual, batch bool, _ error) {
+	body = bytes.TrimSpace(body)
+	var items []json.RawMessage
+	if bytes.HasPrefix(body, []byte("[")) {
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, true, err
+		}
+		if len(items) == 0 {
+			return nil, true, accrualmodel.ErrInvalidPayload
+		}
+		batch = true
+	} else {
+		items = []json.RawMessage{body}
+	}
+
+	results := make([]accrualmodel.Accrual, 0, len(items))
+	for _, item := range items {
+		var result accrualmodel.Accrual
+		if err := json.Unmarshal(item, &result); err != nil {
+			return nil, batch, err
+		}
+		result.Raw = item
+		results = append(results, result)
+	}
+	return results, batch, nil
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"loyalty/internal/controller/httpapi/accrual/model"
+	accrualmodel "loyalty/internal/domain/accrual/model"
+	accrualusecase "loyalty/internal/domain/accrual/usecase"
+	ordersmodel "loyalty/internal/domain/order/model"
+)
+
+type mockCallbackUsecase struct {
+	results []accrualmodel.Accrual
+	errs    map[string]error
+}
+
+func (m *mockCallbackUsecase) ApplyResults(_ context.Context, results []accrualmodel.Accrual) []error {
+	m.results = results
+	errs := make([]error, len(results))
+	for i, result := range results {
+		if err := result.Validate(); err != nil {
+			errs[i] = err
+			continue
+		}
+		errs[i] = m.errs[result.Order]
+	}
+	return errs
+}
+
+var _ accrualusecase.AccrualCallbackUsecase = (*mockCallbackUsecase)(nil)
+
+func serve(t *testing.T, uc *mockCallbackUsecase, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/callback", NewHandler(uc).Callback)
+
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCallback_Single(t *testing.T) {
+	uc := &mockCallbackUsecase{}
+	body := `{"order":"79927398713","status":"PROCESSED","accrual":500}`
+	w := serve(t, uc, body)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp model.CallbackResultResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Order != "79927398713" || resp.Result != model.ResultApplied {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(uc.results) != 1 || uc.results[0].Status != accrualmodel.StatusProcessed || string(uc.results[0].Raw) != body {
+		t.Fatalf("unexpected results passed to usecase: %+v", uc.results)
+	}
+}
+
+func TestCallback_SingleError(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid json", `{"order":`, nil, http.StatusBadRequest, "invalid_input"},
+		{"unknown status", `{"order":"79927398713","status":"DONE"}`, nil, http.StatusBadRequest, "invalid_input"},
+		{"unknown order", `{"order":"79927398713","status":"PROCESSING"}`, ordersmodel.ErrOrderNotFound, http.StatusNotFound, "not_found"},
+		{
+			"illegal transition",
+			`{"order":"79927398713","status":"PROCESSING"}`,
+			fmt.Errorf("%w: PROCESSED -> PROCESSING", ordersmodel.ErrIllegalTransition),
+			http.StatusConflict,
+			"illegal_status_transition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mockCallbackUsecase{errs: map[string]error{"79927398713": tt.err}}
+			w := serve(t, uc, tt.body)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("want %d, got %d", tt.wantStatus, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Fatalf("want code %q, got %s", tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCallback_Batch(t *testing.T) {
+	uc := &mockCallbackUsecase{errs: map[string]error{"12345678903": ordersmodel.ErrOrderNotFound}}
+	w := serve(t, uc, `[
+		{"order":"79927398713","status":"PROCESSED","accrual":500},
+		{"order":"12345678903","status":"INVALID"},
+		{"order":"","status":"PROCESSED"}
+	]`)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp []model.CallbackResultResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := []model.CallbackResultResponse{
+		{Order: "79927398713", Result: model.ResultApplied},
+		{Order: "12345678903", Result: "not_found"},
+		{Order: "", Result: "invalid_input"},
+	}
+	if len(resp) != len(want) {
+		t.Fatalf("want %d results, got %+v", len(want), resp)
+	}
+	for i := range want {
+		if resp[i] != want[i] {
+			t.Fatalf("result %d: want %+v, got %+v", i, want[i], resp[i])
+		}
+	}
+	if string(uc.results[1].Raw) != `{"order":"12345678903","status":"INVALID"}` {
+		t.Fatalf("each result must keep its own raw JSON, got %s", uc.results[1].Raw)
+	}
+}
+
+func TestCallback_BatchInternalError(t *testing.T) {
+	uc := &mockCallbackUsecase{errs: map[string]error{"12345678903": errors.New("db down")}}
+	w := serve(t, uc, `[{"order":"79927398713","status":"PROCESSED","accrual":500},{"order":"12345678903","status":"INVALID"}]`)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("want 500 so the sender retries, got %d", w.Code)
+	}
+}
+
+func TestCallback_EmptyBatch(t *testing.T) {
+	w := serve(t, &mockCallbackUsecase{}, `[]`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want 400, got %d", w.Code)
+	}
+}
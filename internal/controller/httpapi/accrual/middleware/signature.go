@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	common "loyalty/internal/controller/httpapi/common/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderTimestamp — заголовок с моментом подписи обратного вызова (unix-время в секундах).
+	HeaderTimestamp = "X-Accrual-Timestamp"
+	// HeaderSignature — заголовок с подписью: hex(HMAC-SHA256(secret, timestamp + "." + body)),
+	// допускается префикс "sha256=".
+	HeaderSignature = "X-Accrual-Signature"
+
+	signaturePrefix = "sha256="
+	// maxBodyBytes ограничивает тело обратного вызова, которое читается целиком для проверки подписи.
+	maxBodyBytes = 1 << 20
+)
+
+// Sign возвращает подпись тела обратного вызова для заголовка HeaderSignature (без префикса).
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSignatureMiddleware создаёт middleware проверки подписи обратных вызовов accrual.
+// Запрос отклоняется с 401, если подпись не совпадает или метка времени отличается от текущего
+// времени больше чем на replayWindow (в обе стороны). Пустой secret запрещает доступ всем.
+// Прочитанное тело возвращается в запрос для хендлера.
+func NewSignatureMiddleware(secret string, replayWindow time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if secret == "" {
+			reject(ctx, http.StatusUnauthorized, common.CodeUnauthorized)
+			return
+		}
+
+		timestamp, err := strconv.ParseInt(strings.TrimSpace(ctx.GetHeader(HeaderTimestamp)), 10, 64)
+		if err != nil {
+			reject(ctx, http.StatusUnauthorized, common.CodeUnauthorized)
+			return
+		}
+		age := time.Since(time.Unix(timestamp, 0))
+		if age > replayWindow || age < -replayWindow {
+			reject(ctx, http.StatusUnauthorized, common.CodeUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodyBytes))
+		if err != nil {
+			reject(ctx, http.StatusRequestEntityTooLarge, common.CodeInvalidInput)
+			return
+		}
+
+		provided, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(ctx.GetHeader(HeaderSignature)), signaturePrefix))
+		expected, _ := hex.DecodeString(Sign(secret, timestamp, body))
+		if err != nil || !hmac.Equal(provided, expected) {
+			reject(ctx, http.StatusUnauthorized, common.CodeUnauthorized)
+			return
+		}
+
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		ctx.Next()
+	}
+}
+
+func reject(ctx *gin.Context, status int, code string) {
+	common.WriteError(ctx, status, code)
+	ctx.Abort()
+}
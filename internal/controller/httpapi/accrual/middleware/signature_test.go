@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSignatureMiddleware(t *testing.T) {
+	const (
+		secret = "callback-secret"
+		body   = `{"order":"79927398713","status":"PROCESSED","accrual":500}`
+	)
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name       string
+		configured string
+		timestamp  string
+		signature  string
+		wantStatus int
+	}{
+		{"valid signature", secret, strconv.FormatInt(now, 10), Sign(secret, now, []byte(body)), http.StatusOK},
+		{"prefixed signature", secret, strconv.FormatInt(now, 10), "sha256=" + Sign(secret, now, []byte(body)), http.StatusOK},
+		{"missing signature", secret, strconv.FormatInt(now, 10), "", http.StatusUnauthorized},
+		{"wrong secret", secret, strconv.FormatInt(now, 10), Sign("guess", now, []byte(body)), http.StatusUnauthorized},
+		{"signature of other timestamp", secret, strconv.FormatInt(now, 10), Sign(secret, now-1, []byte(body)), http.StatusUnauthorized},
+		{"missing timestamp", secret, "", Sign(secret, now, []byte(body)), http.StatusUnauthorized},
+		{"replayed", secret, strconv.FormatInt(now-600, 10), Sign(secret, now-600, []byte(body)), http.StatusUnauthorized},
+		{"from the future", secret, strconv.FormatInt(now+600, 10), Sign(secret, now+600, []byte(body)), http.StatusUnauthorized},
+		{"callbacks disabled", "", strconv.FormatInt(now, 10), Sign("", now, []byte(body)), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(NewSignatureMiddleware(tt.configured, 5*time.Minute))
+			var got string
+			r.POST("/x", func(c *gin.Context) {
+				raw, _ := io.ReadAll(c.Request.Body)
+				got = string(raw)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/x", strings.NewReader(body))
+			if tt.timestamp != "" {
+				req.Header.Set(HeaderTimestamp, tt.timestamp)
+			}
+			if tt.signature != "" {
+				req.Header.Set(HeaderSignature, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("want %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusOK && got != body {
+				t.Fatalf("handler must see the original body, got %q", got)
+			}
+		})
+	}
+}
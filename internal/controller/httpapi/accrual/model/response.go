@@ -0,0 +1,11 @@
+package model
+
+// ResultApplied — значение Result для применённого результата расчёта.
+const ResultApplied = "applied"
+
+// CallbackResultResponse — исход применения одного результата из обратного вызова accrual.
+type CallbackResultResponse struct {
+	Order string `json:"order"`
+	// Result — ResultApplied или код ошибки API (как в поле error ответов с ошибкой).
+	Result string `json:"result"`
+}
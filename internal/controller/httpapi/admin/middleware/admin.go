@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	common "loyalty/internal/controller/httpapi/common/model"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderAdminToken — заголовок со статическим токеном администратора.
+const HeaderAdminToken = "X-Admin-Token"
+
+// NewAdminMiddleware создаёт middleware авторизации административных маршрутов по статическому токену.
+// Пустой adminToken запрещает доступ всем.
+func NewAdminMiddleware(adminToken string) gin.HandlerFunc {
+	expected := []byte(adminToken)
+	return func(ctx *gin.Context) {
+		provided := []byte(strings.TrimSpace(ctx.GetHeader(HeaderAdminToken)))
+		if len(expected) == 0 || subtle.ConstantTimeCompare(provided, expected) != 1 {
+			common.WriteError(ctx, http.StatusUnauthorized, common.CodeUnauthorized)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		provided   string
+		wantStatus int
+	}{
+		{"valid token", "admin-secret", "admin-secret", http.StatusOK},
+		{"missing token", "admin-secret", "", http.StatusUnauthorized},
+		{"wrong token", "admin-secret", "guess", http.StatusUnauthorized},
+		{"admin disabled", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(NewAdminMiddleware(tt.configured))
+			r.GET("/x", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/x", nil)
+			if tt.provided != "" {
+				req.Header.Set(HeaderAdminToken, tt.provided)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("want %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
 )
 
 // Handler — HTTP-хендлеры аутентификации (register/login).
@@ -22,15 +22,15 @@ func NewAuthHandler(authUsecase usecase.AuthUsecase) *Handler {
 
 // Register обрабатывает регистрацию пользователя: валидирует запрос и возвращает токен.
 func (handler *Handler) Register(ctx *gin.Context) {
-	var request networkmodel.LoginRequest
+	var request networkmodel.RegisterRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
 		common.WriteError(ctx, http.StatusBadRequest, common.CodeBadRequest)
 		return
 	}
 
-	token, err := handler.authUsecase.Register(ctx.Request.Context(), request.Login, request.Password)
+	token, err := handler.authUsecase.Register(ctx.Request.Context(), request.Login, request.Password, request.ReferralCode)
 	if err != nil {
-		log.Error().Err(err).Str("login", request.Login).Msg("register failed")
+		zerolog.Ctx(ctx.Request.Context()).Error().Err(err).Str("login", request.Login).Msg("register failed")
 		status, code := common.MapError(err)
 		common.WriteError(ctx, status, code)
 		return
@@ -50,7 +50,7 @@ func (handler *Handler) Login(ctx *gin.Context) {
 
 	token, err := handler.authUsecase.Login(ctx.Request.Context(), request.Login, request.Password)
 	if err != nil {
-		log.Error().Err(err).Str("login", request.Login).Msg("login failed")
+		zerolog.Ctx(ctx.Request.Context()).Error().Err(err).Str("login", request.Login).Msg("login failed")
 		status, code := common.MapError(err)
 		common.WriteError(ctx, status, code)
 		return
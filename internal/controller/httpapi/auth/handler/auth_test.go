@@ -20,9 +20,12 @@ import (
 type mockUsecase struct {
 	registerFn func(ctx context.Context, login, password string) (string, error)
 	loginFn    func(ctx context.Context, login, password string) (string, error)
+
+	gotReferralCode string
 }
 
-func (m *mockUsecase) Register(ctx context.Context, login, password string) (string, error) {
+func (m *mockUsecase) Register(ctx context.Context, login, password, referralCode string) (string, error) {
+	m.gotReferralCode = referralCode
 	return m.registerFn(ctx, login, password)
 }
 func (m *mockUsecase) Login(ctx context.Context, login, password string) (string, error) {
@@ -142,3 +145,29 @@ func TestHandler_Register_500OnUnexpected(t *testing.T) {
 		t.Fatalf("want %d, got %d", http.StatusInternalServerError, w.Code)
 	}
 }
+
+func TestHandler_Register_PassesReferralCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	uc := &mockUsecase{
+		registerFn: func(context.Context, string, string) (string, error) { return "token123", nil },
+		loginFn:    func(context.Context, string, string) (string, error) { panic("not used") },
+	}
+	h := NewAuthHandler(uc)
+
+	r := gin.New()
+	r.POST("/api/user/register", h.Register)
+
+	body := []byte(`{"login":"bob","password":"longenough10","referral_code":"ABC123"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/user/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	if uc.gotReferralCode != "ABC123" {
+		t.Fatalf("want referral code %q, got %q", "ABC123", uc.gotReferralCode)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"loyalty/internal/controller/httpapi/auth/authctx"
 	common "loyalty/internal/controller/httpapi/common/model"
 	"loyalty/internal/domain/auth/service"
+	"loyalty/internal/tracing"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -30,6 +32,7 @@ func NewAuthMiddleware(tokenService service.TokenService) gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
+		trace.SpanFromContext(ctx.Request.Context()).SetAttributes(tracing.UserID(claims.UserID))
 		ctx.Request = ctx.Request.WithContext(authctx.WithUserID(ctx.Request.Context(), claims.UserID))
 		ctx.Next()
 	}
@@ -5,3 +5,10 @@ type LoginRequest struct {
 	Login    string `json:"login"`
 	Password string `json:"password"`
 }
+
+// RegisterRequest — тело запроса для регистрации пользователя (реферальный код необязателен).
+type RegisterRequest struct {
+	Login        string `json:"login"`
+	Password     string `json:"password"`
+	ReferralCode string `json:"referral_code,omitempty"`
+}
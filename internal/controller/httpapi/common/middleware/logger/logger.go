@@ -2,13 +2,15 @@ package logger
 
 import (
 	"loyalty/internal/controller/httpapi/common/middleware/routing"
+	applogger "loyalty/internal/logger"
 	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 )
 
-var httpLog = zerolog.New(os.Stderr)
+// httpLog дополняет записи trace_id/span_id спана запроса (см. applogger.TraceHook).
+var httpLog = zerolog.New(os.Stderr).Hook(applogger.TraceHook{})
 
 // maxLoggedBodyBytes — сколько байт тела мы максимум буферизуем для логирования на ошибках.
 // Значение < 0 означает "без лимита".
@@ -82,6 +84,10 @@ func withCommonHTTPFields(event *zerolog.Event, ctx *gin.Context, status int) *z
 	if ctx == nil || ctx.Request == nil || ctx.Request.URL == nil {
 		return event.Int("status", status)
 	}
+	event = event.Ctx(ctx.Request.Context())
+	if requestID := applogger.RequestID(ctx.Request.Context()); requestID != "" {
+		event = event.Str(applogger.RequestIDField, requestID)
+	}
 	return event.
 		Str("method", ctx.Request.Method).
 		Str("path", ctx.Request.URL.Path).
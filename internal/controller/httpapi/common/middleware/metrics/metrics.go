@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"loyalty/internal/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute — значение метки route для запросов, не попавших ни в один маршрут.
+// Сырой путь не используется, чтобы не раздувать кардинальность метрик.
+const unmatchedRoute = "unmatched"
+
+// NewMiddleware возвращает middleware, измеряющий длительность HTTP-запросов
+// в разрезе метода, шаблона маршрута и статуса ответа.
+func NewMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
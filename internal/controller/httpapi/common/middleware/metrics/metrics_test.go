@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"loyalty/internal/metrics"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func sampleCount(t *testing.T, labels ...string) uint64 {
+	t.Helper()
+
+	observer, err := metrics.HTTPRequestDuration.GetMetricWithLabelValues(labels...)
+	if err != nil {
+		t.Fatalf("get metric: %v", err)
+	}
+	var out dto.Metric
+	if err := observer.(prometheus.Metric).Write(&out); err != nil {
+		t.Fatalf("write metric: %v", err)
+	}
+	return out.GetHistogram().GetSampleCount()
+}
+
+func TestMetricsMiddleware_ObservesRouteTemplateAndStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewMiddleware())
+	router.GET("/items/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusAccepted)
+	})
+
+	before := sampleCount(t, http.MethodGet, "/items/:id", "202")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/42", nil))
+
+	if got := sampleCount(t, http.MethodGet, "/items/:id", "202"); got != before+1 {
+		t.Fatalf("want %d samples, got %d", before+1, got)
+	}
+}
+
+func TestMetricsMiddleware_UnmatchedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewMiddleware())
+
+	before := sampleCount(t, http.MethodGet, unmatchedRoute, "404")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/no/such/path", nil))
+
+	if got := sampleCount(t, http.MethodGet, unmatchedRoute, "404"); got != before+1 {
+		t.Fatalf("want %d samples, got %d", before+1, got)
+	}
+}
@@ -1,19 +1,41 @@
 package ratelimit
 
 import (
+	"loyalty/internal/metrics"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
 )
 
-// NewMiddleware создаёт middleware для ограничения частоты запросов (rate limiting).
-// Использует token bucket алгоритм: rps запросов/сек + burst для всплесков.
-func NewMiddleware(rps int, burst int) gin.HandlerFunc {
-	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+// Limiter — token bucket, параметры которого можно менять во время работы.
+// Один Limiter может обслуживать несколько маршрутов (общий лимит на группу).
+type Limiter struct {
+	limiter *rate.Limiter
+}
+
+// NewLimiter создаёт ограничитель: rps запросов/сек + burst для всплесков.
+func NewLimiter(rps int, burst int) *Limiter {
+	return &Limiter{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+}
+
+// SetLimits атомарно меняет rps и burst; новые значения действуют для следующих запросов.
+func (limiter *Limiter) SetLimits(rps int, burst int) {
+	limiter.limiter.SetLimit(rate.Limit(rps))
+	limiter.limiter.SetBurst(burst)
+}
 
+// Limits возвращает текущие rps и burst.
+func (limiter *Limiter) Limits() (rps int, burst int) {
+	return int(limiter.limiter.Limit()), limiter.limiter.Burst()
+}
+
+// Middleware возвращает middleware, отклоняющее запросы сверх лимита с 429.
+// Отклонённые запросы учитываются в метрике loyalty_http_ratelimit_rejections_total.
+func (limiter *Limiter) Middleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if !limiter.Allow() {
+		if !limiter.limiter.Allow() {
+			metrics.RateLimitRejections.WithLabelValues(ctx.FullPath()).Inc()
 			ctx.Header("Retry-After", "1")
 			ctx.Status(http.StatusTooManyRequests)
 			ctx.Abort()
@@ -22,3 +44,9 @@ func NewMiddleware(rps int, burst int) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// NewMiddleware создаёт middleware для ограничения частоты запросов (rate limiting).
+// Использует token bucket алгоритм: rps запросов/сек + burst для всплесков.
+func NewMiddleware(rps int, burst int) gin.HandlerFunc {
+	return NewLimiter(rps, burst).Middleware()
+}
@@ -1,11 +1,14 @@
 package ratelimit
 
 import (
+	"loyalty/internal/metrics"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestRateLimitMiddleware_AllowsWithinLimit(t *testing.T) {
@@ -52,3 +55,55 @@ func TestRateLimitMiddleware_Blocks429(t *testing.T) {
 		t.Fatalf("expected Retry-After header")
 	}
 }
+
+func TestRateLimitMiddleware_CountsRejections(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewMiddleware(1, 1))
+	router.GET("/limited", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	counter := metrics.RateLimitRejections.WithLabelValues("/limited")
+	before := testutil.ToFloat64(counter)
+
+	for i := 0; i < 2; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/limited", nil))
+	}
+
+	if got := testutil.ToFloat64(counter); got != before+1 {
+		t.Fatalf("want %v rejections, got %v", before+1, got)
+	}
+}
+
+func TestLimiter_SetLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := NewLimiter(1, 1)
+	router := gin.New()
+	router.Use(limiter.Middleware())
+	router.GET("/test", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	serve := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		return w.Code
+	}
+
+	if code := serve(); code != http.StatusOK {
+		t.Fatalf("first request: want 200, got %d", code)
+	}
+	if code := serve(); code != http.StatusTooManyRequests {
+		t.Fatalf("second request: want 429, got %d", code)
+	}
+
+	limiter.SetLimits(1000, 5)
+	if rps, burst := limiter.Limits(); rps != 1000 || burst != 5 {
+		t.Fatalf("unexpected limits: rps=%d burst=%d", rps, burst)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if code := serve(); code != http.StatusOK {
+		t.Fatalf("request after raising limit: want 200, got %d", code)
+	}
+}
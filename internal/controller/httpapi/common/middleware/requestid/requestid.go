@@ -0,0 +1,45 @@
+package requestid
+
+import (
+	"loyalty/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Header — заголовок с идентификатором запроса.
+const Header = "X-Request-ID"
+
+// maxLength — максимальная длина принимаемого от клиента идентификатора.
+const maxLength = 128
+
+// NewMiddleware возвращает middleware, который принимает X-Request-ID клиента (или генерирует новый),
+// возвращает его в ответе и кладёт в контекст запроса дочерний логгер с полем request_id.
+// Обработчики и нижележащие слои логируют через zerolog.Ctx(ctx).
+func NewMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(Header)
+		if !valid(requestID) {
+			requestID = logger.NewID()
+		}
+
+		ctx.Header(Header, requestID)
+		trace.SpanFromContext(ctx.Request.Context()).SetAttributes(attribute.String("request.id", requestID))
+		ctx.Request = ctx.Request.WithContext(logger.WithRequestID(ctx.Request.Context(), requestID))
+		ctx.Next()
+	}
+}
+
+// valid проверяет, что идентификатор непустой, не слишком длинный и состоит из видимых ASCII-символов.
+func valid(requestID string) bool {
+	if requestID == "" || len(requestID) > maxLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package requestid
+
+import (
+	"bytes"
+	"loyalty/internal/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+func newRouter(handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewMiddleware())
+	router.GET("/test", handler)
+	return router
+}
+
+func TestRequestID_EchoesClientHeader(t *testing.T) {
+	var seen string
+	router := newRouter(func(ctx *gin.Context) {
+		seen = logger.RequestID(ctx.Request.Context())
+		ctx.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set(Header, "abc-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get(Header); got != "abc-123" {
+		t.Fatalf("want echoed %q, got %q", "abc-123", got)
+	}
+	if seen != "abc-123" {
+		t.Fatalf("want request id in context, got %q", seen)
+	}
+}
+
+func TestRequestID_GeneratesWhenMissingOrInvalid(t *testing.T) {
+	router := newRouter(func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	for _, header := range []string{"", "has space", strings.Repeat("x", maxLength+1)} {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		if header != "" {
+			req.Header.Set(Header, header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		got := w.Header().Get(Header)
+		if got == "" || got == header {
+			t.Fatalf("header %q: expected generated request id, got %q", header, got)
+		}
+	}
+}
+
+func TestRequestID_ContextLoggerHasRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+	prev := zerolog.DefaultContextLogger
+	zerolog.DefaultContextLogger = &base
+	t.Cleanup(func() { zerolog.DefaultContextLogger = prev })
+
+	router := newRouter(func(ctx *gin.Context) {
+		zerolog.Ctx(ctx.Request.Context()).Info().Msg("handled")
+		ctx.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set(Header, "req-42")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !strings.Contains(buf.String(), `"request_id":"req-42"`) {
+		t.Fatalf("expected request_id in handler log, got %s", buf.String())
+	}
+}
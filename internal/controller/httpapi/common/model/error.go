@@ -2,8 +2,14 @@ package model
 
 import (
 	"errors"
+	accrualmodel "loyalty/internal/domain/accrual/model"
 	"loyalty/internal/domain/auth/model"
+	jobrunmodel "loyalty/internal/domain/jobrun/model"
 	ordersmodel "loyalty/internal/domain/order/model"
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	referralmodel "loyalty/internal/domain/referral/model"
+	statementmodel "loyalty/internal/domain/statement/model"
+	transfermodel "loyalty/internal/domain/transfer/model"
 	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
 	"net/http"
 
@@ -39,8 +45,18 @@ const (
 	CodeOrderAlreadyUploaded = "order_already_uploaded"
 	// CodeOrderAlreadyUploadedByAnother — номер заказа уже был загружен другим пользователем.
 	CodeOrderAlreadyUploadedByAnother = "order_already_uploaded_by_another"
+	// CodeIllegalStatusTransition — переход заказа в запрошенный статус недопустим из текущего.
+	CodeIllegalStatusTransition = "illegal_status_transition"
+	// CodeAccrualConflict — заказ уже зачислен с другой суммой начисления.
+	CodeAccrualConflict = "accrual_conflict"
 	// CodeInsufficientFunds — на счету недостаточно средств.
 	CodeInsufficientFunds = "insufficient_funds"
+	// CodeInvalidReferralCode — реферальный код не найден или приглашение недопустимо.
+	CodeInvalidReferralCode = "invalid_referral_code"
+	// CodeTransferLimitExceeded — превышен суточный лимит переводов.
+	CodeTransferLimitExceeded = "transfer_limit_exceeded"
+	// CodeNotFound — запрошенная сущность не найдена.
+	CodeNotFound = "not_found"
 	// CodeInternal — внутренняя ошибка сервера (детали не раскрываются клиенту).
 	CodeInternal = "internal"
 )
@@ -79,15 +95,45 @@ func MapError(err error) (status int, code string) {
 	case errors.Is(err, model.ErrInvalidToken):
 		return http.StatusUnauthorized, CodeUnauthorized
 
+	case errors.Is(err, accrualmodel.ErrInvalidPayload):
+		return http.StatusBadRequest, CodeInvalidInput
+
+	case errors.Is(err, jobrunmodel.ErrInvalidFilter):
+		return http.StatusBadRequest, CodeInvalidInput
+
 	case errors.Is(err, ordersmodel.ErrInvalidOrderNumber):
 		return http.StatusUnprocessableEntity, CodeInvalidOrderNumber
 	case errors.Is(err, ordersmodel.ErrOrderAlreadyUploaded):
 		return http.StatusOK, CodeOrderAlreadyUploaded
 	case errors.Is(err, ordersmodel.ErrOrderAlreadyUploadedByAnother):
 		return http.StatusConflict, CodeOrderAlreadyUploadedByAnother
+	case errors.Is(err, ordersmodel.ErrOrderNotFound):
+		return http.StatusNotFound, CodeNotFound
+	case errors.Is(err, ordersmodel.ErrIllegalTransition):
+		return http.StatusConflict, CodeIllegalStatusTransition
+	case errors.Is(err, ordersmodel.ErrAccrualConflict):
+		return http.StatusConflict, CodeAccrualConflict
 	case errors.Is(err, withdrawalsmodel.ErrInsufficientFunds):
 		return http.StatusPaymentRequired, CodeInsufficientFunds
 
+	case errors.Is(err, promotionmodel.ErrInvalidRule):
+		return http.StatusBadRequest, CodeInvalidInput
+	case errors.Is(err, promotionmodel.ErrRuleNotFound):
+		return http.StatusNotFound, CodeNotFound
+
+	case errors.Is(err, referralmodel.ErrInvalidReferralCode), errors.Is(err, referralmodel.ErrSelfReferral):
+		return http.StatusBadRequest, CodeInvalidReferralCode
+
+	case errors.Is(err, statementmodel.ErrInvalidQuery):
+		return http.StatusBadRequest, CodeInvalidInput
+
+	case errors.Is(err, transfermodel.ErrInvalidTransferSum), errors.Is(err, transfermodel.ErrSelfTransfer):
+		return http.StatusBadRequest, CodeInvalidInput
+	case errors.Is(err, transfermodel.ErrRecipientNotFound):
+		return http.StatusNotFound, CodeNotFound
+	case errors.Is(err, transfermodel.ErrDailyLimitExceeded):
+		return http.StatusUnprocessableEntity, CodeTransferLimitExceeded
+
 	default:
 		return http.StatusInternalServerError, CodeInternal
 	}
@@ -2,11 +2,18 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
+	accrualmodel "loyalty/internal/domain/accrual/model"
 	authmodel "loyalty/internal/domain/auth/model"
+	jobrunmodel "loyalty/internal/domain/jobrun/model"
 	ordersmodel "loyalty/internal/domain/order/model"
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	referralmodel "loyalty/internal/domain/referral/model"
+	statementmodel "loyalty/internal/domain/statement/model"
+	transfermodel "loyalty/internal/domain/transfer/model"
 	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
 )
 
@@ -83,12 +90,84 @@ func TestMapError(t *testing.T) {
 			wantStatus: http.StatusConflict,
 			wantCode:   CodeOrderAlreadyUploadedByAnother,
 		},
+		{
+			name:       "order not found",
+			err:        ordersmodel.ErrOrderNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+		},
+		{
+			name:       "illegal status transition",
+			err:        fmt.Errorf("%w: PROCESSED -> NEW", ordersmodel.ErrIllegalTransition),
+			wantStatus: http.StatusConflict,
+			wantCode:   CodeIllegalStatusTransition,
+		},
+		{
+			name:       "accrual conflict",
+			err:        fmt.Errorf("%w: order 1", ordersmodel.ErrAccrualConflict),
+			wantStatus: http.StatusConflict,
+			wantCode:   CodeAccrualConflict,
+		},
 		{
 			name:       "insufficient funds",
 			err:        withdrawalsmodel.ErrInsufficientFunds,
 			wantStatus: http.StatusPaymentRequired,
 			wantCode:   CodeInsufficientFunds,
 		},
+		{
+			name:       "invalid promotion rule",
+			err:        promotionmodel.ErrInvalidRule,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidInput,
+		},
+		{
+			name:       "promotion rule not found",
+			err:        promotionmodel.ErrRuleNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+		},
+		{
+			name:       "invalid referral code",
+			err:        referralmodel.ErrInvalidReferralCode,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidReferralCode,
+		},
+		{
+			name:       "self referral",
+			err:        referralmodel.ErrSelfReferral,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidReferralCode,
+		},
+		{
+			name:       "invalid accrual payload",
+			err:        fmt.Errorf("%w: empty order number", accrualmodel.ErrInvalidPayload),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidInput,
+		},
+		{
+			name:       "invalid job runs filter",
+			err:        jobrunmodel.ErrInvalidFilter,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidInput,
+		},
+		{
+			name:       "invalid statement query",
+			err:        statementmodel.ErrInvalidQuery,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidInput,
+		},
+		{
+			name:       "recipient not found",
+			err:        transfermodel.ErrRecipientNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+		},
+		{
+			name:       "transfer limit exceeded",
+			err:        transfermodel.ErrDailyLimitExceeded,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   CodeTransferLimitExceeded,
+		},
 		{
 			name:       "unknown error",
 			err:        errors.New("unknown"),
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"loyalty/internal/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler — HTTP-хендлеры liveness/readiness проб.
+type Handler struct {
+	probe *health.Probe
+}
+
+// NewHandler создаёт хендлеры проб. probe может быть nil — тогда сервис всегда готов.
+func NewHandler(probe *health.Probe) *Handler { return &Handler{probe: probe} }
+
+// Livez сообщает, что процесс жив и обрабатывает запросы; зависимости не проверяются.
+func (handler *Handler) Livez(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, health.Report{Status: health.StatusOK, Checks: map[string]health.Result{}})
+}
+
+// Readyz проверяет зависимости и возвращает отчёт; 503 — если сервис не готов принимать трафик.
+func (handler *Handler) Readyz(ctx *gin.Context) {
+	report := handler.probe.Ready(ctx.Request.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, report)
+}
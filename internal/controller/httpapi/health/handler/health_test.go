@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"loyalty/internal/health"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serve(t *testing.T, probe *health.Probe, path string) (*httptest.ResponseRecorder, health.Report) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handler := NewHandler(probe)
+	router := gin.New()
+	router.GET("/livez", handler.Livez)
+	router.GET("/readyz", handler.Readyz)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var report health.Report
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("invalid json %q: %v", w.Body.String(), err)
+	}
+	return w, report
+}
+
+func failingProbe() *health.Probe {
+	return health.NewProbe(time.Second, health.Check{
+		Name:     "database",
+		Critical: true,
+		Run: func(context.Context) health.Result {
+			return health.Result{Status: health.StatusFail, Detail: "connection refused"}
+		},
+	})
+}
+
+func TestReadyz_OK(t *testing.T) {
+	w, report := serve(t, health.NewProbe(time.Second), "/readyz")
+	if w.Code != http.StatusOK || report.Status != health.StatusOK {
+		t.Fatalf("want 200 ok, got %d %+v", w.Code, report)
+	}
+}
+
+func TestReadyz_CriticalFailure(t *testing.T) {
+	w, report := serve(t, failingProbe(), "/readyz")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("want 503, got %d", w.Code)
+	}
+	if report.Checks["database"].Detail != "connection refused" {
+		t.Fatalf("expected database detail in report, got %+v", report)
+	}
+}
+
+func TestReadyz_ShuttingDown(t *testing.T) {
+	probe := health.NewProbe(time.Second)
+	probe.Shutdown()
+
+	if w, _ := serve(t, probe, "/readyz"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("want 503 during shutdown, got %d", w.Code)
+	}
+}
+
+func TestLivez_IgnoresDependencies(t *testing.T) {
+	if w, _ := serve(t, failingProbe(), "/livez"); w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"loyalty/internal/controller/httpapi/jobrun/model"
+	"net/http"
+	"strconv"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	jobrunmodel "loyalty/internal/domain/jobrun/model"
+	jobrunusecase "loyalty/internal/domain/jobrun/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler — административный HTTP-хендлер журнала запусков фоновых задач.
+type Handler struct {
+	usecase jobrunusecase.JobRunUsecase
+}
+
+// NewHandler создаёт хендлер журнала запусков.
+func NewHandler(usecase jobrunusecase.JobRunUsecase) *Handler {
+	return &Handler{usecase: usecase}
+}
+
+// List возвращает последние запуски фоновых задач (новые первыми): job — только запуски этой задачи,
+// limit — размер выборки (по умолчанию 50, не больше 1000).
+func (handler *Handler) List(ctx *gin.Context) {
+	filter := jobrunmodel.Filter{Job: ctx.Query("job"), Limit: jobrunmodel.DefaultLimit}
+	if raw := ctx.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			common.WriteError(ctx, http.StatusBadRequest, common.CodeInvalidInput)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	runs, err := handler.usecase.ListRuns(ctx, filter)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	resp := make([]model.RunResponse, 0, len(runs))
+	for _, run := range runs {
+		resp = append(resp, toResponse(run))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func toResponse(run jobrunmodel.Run) model.RunResponse {
+	resp := model.RunResponse{
+		ID:        run.ID,
+		Job:       run.Job,
+		Status:    string(run.Status),
+		Error:     run.Error,
+		StartedAt: common.RFC3339Time{Time: run.StartedAt},
+	}
+	if run.FinishedAt != nil {
+		resp.FinishedAt = &common.RFC3339Time{Time: *run.FinishedAt}
+		duration := run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+		resp.DurationMS = &duration
+	}
+	return resp
+}
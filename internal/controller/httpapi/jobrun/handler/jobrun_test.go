@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	jobrunmodel "loyalty/internal/domain/jobrun/model"
+	jobrunusecase "loyalty/internal/domain/jobrun/usecase"
+)
+
+type mockJobRunUsecase struct {
+	filter jobrunmodel.Filter
+	runs   []jobrunmodel.Run
+	err    error
+}
+
+func (m *mockJobRunUsecase) ListRuns(_ context.Context, filter jobrunmodel.Filter) ([]jobrunmodel.Run, error) {
+	m.filter = filter
+	return m.runs, m.err
+}
+
+var _ jobrunusecase.JobRunUsecase = (*mockJobRunUsecase)(nil)
+
+func newRouter(uc jobrunusecase.JobRunUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/jobs/runs", NewHandler(uc).List)
+	return r
+}
+
+func TestHandler_List_200(t *testing.T) {
+	startedAt := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(1500 * time.Millisecond)
+	uc := &mockJobRunUsecase{runs: []jobrunmodel.Run{
+		{ID: 2, Job: "accrual_sweep", Status: jobrunmodel.StatusRunning, StartedAt: startedAt.Add(time.Minute)},
+		{ID: 1, Job: "accrual_sweep", Status: jobrunmodel.StatusFailed, Error: "db down", StartedAt: startedAt, FinishedAt: &finishedAt},
+	}}
+
+	w := httptest.NewRecorder()
+	newRouter(uc).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/runs?job=accrual_sweep&limit=10", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if uc.filter.Job != "accrual_sweep" || uc.filter.Limit != 10 {
+		t.Fatalf("unexpected filter: %+v", uc.filter)
+	}
+
+	var resp []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(resp))
+	}
+	if _, ok := resp[0]["finished_at"]; ok {
+		t.Fatalf("running job must not have finished_at: %v", resp[0])
+	}
+	if resp[1]["status"] != "FAILED" || resp[1]["error"] != "db down" || resp[1]["duration_ms"] != float64(1500) {
+		t.Fatalf("unexpected finished run: %v", resp[1])
+	}
+}
+
+func TestHandler_List_DefaultLimit(t *testing.T) {
+	uc := &mockJobRunUsecase{}
+	w := httptest.NewRecorder()
+	newRouter(uc).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/runs", nil))
+
+	if w.Code != http.StatusOK || w.Body.String() != "[]" {
+		t.Fatalf("want empty list, got %d: %s", w.Code, w.Body.String())
+	}
+	if uc.filter.Limit != jobrunmodel.DefaultLimit || uc.filter.Job != "" {
+		t.Fatalf("unexpected filter: %+v", uc.filter)
+	}
+}
+
+func TestHandler_List_400(t *testing.T) {
+	for _, query := range []string{"limit=abc", "limit=0"} {
+		uc := &mockJobRunUsecase{err: jobrunmodel.ErrInvalidFilter}
+		w := httptest.NewRecorder()
+		newRouter(uc).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/runs?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: want %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	common "loyalty/internal/controller/httpapi/common/model"
+)
+
+// RunResponse — запуск фоновой задачи в ответе административного API.
+type RunResponse struct {
+	ID         int64               `json:"id"`
+	Job        string              `json:"job"`
+	Status     string              `json:"status"`
+	Error      string              `json:"error,omitempty"`
+	StartedAt  common.RFC3339Time  `json:"started_at"`
+	FinishedAt *common.RFC3339Time `json:"finished_at,omitempty"`
+	// DurationMS — длительность завершённого запуска в миллисекундах.
+	DurationMS *int64 `json:"duration_ms,omitempty"`
+}
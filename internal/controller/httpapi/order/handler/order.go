@@ -86,3 +86,27 @@ func (handler *Handler) ListOrders(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// ListHistory возвращает историю статусов заказа пользователя (от старых записей к новым).
+func (handler *Handler) ListHistory(ctx *gin.Context) {
+	userID, _ := authctx.UserID(ctx.Request.Context())
+	history, err := handler.usecase.LoadHistory(ctx, userID, ctx.Param("number"))
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+
+	resp := make([]model.StatusChangeResponseItem, 0, len(history))
+	for _, change := range history {
+		resp = append(resp, model.StatusChangeResponseItem{
+			Status:          string(change.To),
+			PreviousStatus:  string(change.From),
+			Accrual:         change.Accrual,
+			Source:          string(change.Source),
+			AccrualResponse: change.Response,
+			ChangedAt:       common.RFC3339Time{Time: change.ChangedAt},
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
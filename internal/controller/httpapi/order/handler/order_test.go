@@ -18,8 +18,9 @@ import (
 )
 
 type mockOrdersUsecase struct {
-	uploadFn func(ctx context.Context, userID int64, number string) error
-	listFn   func(ctx context.Context, userID int64) ([]ordersmodel.Order, error)
+	uploadFn  func(ctx context.Context, userID int64, number string) error
+	listFn    func(ctx context.Context, userID int64) ([]ordersmodel.Order, error)
+	historyFn func(ctx context.Context, userID int64, number string) ([]ordersmodel.StatusChange, error)
 }
 
 func (m *mockOrdersUsecase) UploadOrder(ctx context.Context, userID int64, number string) error {
@@ -28,6 +29,9 @@ func (m *mockOrdersUsecase) UploadOrder(ctx context.Context, userID int64, numbe
 func (m *mockOrdersUsecase) LoadOrders(ctx context.Context, userID int64) ([]ordersmodel.Order, error) {
 	return m.listFn(ctx, userID)
 }
+func (m *mockOrdersUsecase) LoadHistory(ctx context.Context, userID int64, number string) ([]ordersmodel.StatusChange, error) {
+	return m.historyFn(ctx, userID, number)
+}
 
 var _ ordersusecase.OrdersUsecase = (*mockOrdersUsecase)(nil)
 
@@ -222,3 +226,79 @@ func TestHandler_ListOrders_500OnUnexpectedError(t *testing.T) {
 		t.Fatalf("want %d, got %d", http.StatusInternalServerError, w.Code)
 	}
 }
+
+func TestHandler_ListHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	changedAt := time.Date(2026, 1, 28, 12, 0, 0, 0, time.UTC)
+	accrual := decimal.RequireFromString("10.5")
+	tests := []struct {
+		name      string
+		historyFn func(context.Context, int64, string) ([]ordersmodel.StatusChange, error)
+		wantCode  int
+		wantBody  []string
+	}{
+		{
+			name: "history",
+			historyFn: func(_ context.Context, userID int64, number string) ([]ordersmodel.StatusChange, error) {
+				if userID != 1 || number != "79927398713" {
+					t.Errorf("unexpected args: %d %q", userID, number)
+				}
+				return []ordersmodel.StatusChange{
+					{Number: number, To: ordersmodel.StatusNew, Source: ordersmodel.SourceUser, ChangedAt: changedAt},
+					{
+						Number:    number,
+						From:      ordersmodel.StatusNew,
+						To:        ordersmodel.StatusProcessed,
+						Accrual:   &accrual,
+						Source:    ordersmodel.SourceWorker,
+						Response:  []byte(`{"order":"79927398713","status":"PROCESSED","accrual":10.5}`),
+						ChangedAt: changedAt.Add(time.Minute),
+					},
+				}, nil
+			},
+			wantCode: http.StatusOK,
+			wantBody: []string{
+				`{"status":"NEW","source":"user","changed_at":"2026-01-28T12:00:00Z"}`,
+				`{"status":"PROCESSED","previous_status":"NEW","accrual":"10.5","source":"worker",` +
+					`"accrual_response":{"order":"79927398713","status":"PROCESSED","accrual":10.5},"changed_at":"2026-01-28T12:01:00Z"}`,
+			},
+		},
+		{
+			name: "not found",
+			historyFn: func(context.Context, int64, string) ([]ordersmodel.StatusChange, error) {
+				return nil, ordersmodel.ErrOrderNotFound
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "invalid number",
+			historyFn: func(context.Context, int64, string) ([]ordersmodel.StatusChange, error) {
+				return nil, ordersmodel.ErrInvalidOrderNumber
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&mockOrdersUsecase{historyFn: tt.historyFn})
+			r := gin.New()
+			r.GET("/api/user/orders/:number/history", h.ListHistory)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user/orders/79927398713/history", nil)
+			req = req.WithContext(authctx.WithUserID(req.Context(), 1))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("want %d, got %d: %s", tt.wantCode, w.Code, w.Body)
+			}
+			for _, want := range tt.wantBody {
+				if !bytes.Contains(w.Body.Bytes(), []byte(want)) {
+					t.Fatalf("body %s does not contain %s", w.Body, want)
+				}
+			}
+		})
+	}
+}
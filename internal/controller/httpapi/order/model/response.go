@@ -1,6 +1,8 @@
 package model
 
 import (
+	"encoding/json"
+
 	common "loyalty/internal/controller/httpapi/common/model"
 
 	"github.com/shopspring/decimal"
@@ -13,3 +15,14 @@ type OrderResponseItem struct {
 	Accrual    *decimal.Decimal   `json:"accrual,omitempty"`
 	UploadedAt common.RFC3339Time `json:"uploaded_at"`
 }
+
+// StatusChangeResponseItem — элемент истории статусов заказа.
+type StatusChangeResponseItem struct {
+	Status         string           `json:"status"`
+	PreviousStatus string           `json:"previous_status,omitempty"`
+	Accrual        *decimal.Decimal `json:"accrual,omitempty"`
+	Source         string           `json:"source"`
+	// AccrualResponse — исходный ответ системы accrual, вызвавший переход.
+	AccrualResponse json.RawMessage    `json:"accrual_response,omitempty"`
+	ChangedAt       common.RFC3339Time `json:"changed_at"`
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"loyalty/internal/controller/httpapi/promotion/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	promotionusecase "loyalty/internal/domain/promotion/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler — административные HTTP-хендлеры управления правилами акций.
+type Handler struct {
+	usecase promotionusecase.PromotionUsecase
+}
+
+// NewHandler создаёт хендлеры управления правилами акций.
+func NewHandler(usecase promotionusecase.PromotionUsecase) *Handler {
+	return &Handler{usecase: usecase}
+}
+
+// Create создаёт правило акции.
+func (handler *Handler) Create(ctx *gin.Context) {
+	var req model.RuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeBadRequest)
+		return
+	}
+	rule, err := handler.usecase.CreateRule(ctx, toDomainRule(req))
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	ctx.JSON(http.StatusCreated, toResponse(rule))
+}
+
+// Get возвращает правило акции по ID.
+func (handler *Handler) Get(ctx *gin.Context) {
+	id, ok := ruleID(ctx)
+	if !ok {
+		return
+	}
+	rule, err := handler.usecase.GetRule(ctx, id)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponse(rule))
+}
+
+// List возвращает все правила акций.
+func (handler *Handler) List(ctx *gin.Context) {
+	rules, err := handler.usecase.ListRules(ctx)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	resp := make([]model.RuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, toResponse(rule))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Update полностью заменяет правило акции.
+func (handler *Handler) Update(ctx *gin.Context) {
+	id, ok := ruleID(ctx)
+	if !ok {
+		return
+	}
+	var req model.RuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeBadRequest)
+		return
+	}
+	rule := toDomainRule(req)
+	rule.ID = id
+	updated, err := handler.usecase.UpdateRule(ctx, rule)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	ctx.JSON(http.StatusOK, toResponse(updated))
+}
+
+// Delete удаляет правило акции.
+func (handler *Handler) Delete(ctx *gin.Context) {
+	id, ok := ruleID(ctx)
+	if !ok {
+		return
+	}
+	if err := handler.usecase.DeleteRule(ctx, id); err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func ruleID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func toDomainRule(req model.RuleRequest) promotionmodel.Rule {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	weekdays := make([]time.Weekday, 0, len(req.Conditions.Weekdays))
+	for _, weekday := range req.Conditions.Weekdays {
+		weekdays = append(weekdays, time.Weekday(weekday))
+	}
+	return promotionmodel.Rule{
+		Name:      req.Name,
+		Active:    active,
+		ValidFrom: req.ValidFrom,
+		ValidTo:   req.ValidTo,
+		Conditions: promotionmodel.Conditions{
+			Weekdays:   weekdays,
+			FirstOrder: req.Conditions.FirstOrder,
+			Tiers:      req.Conditions.Tiers,
+			MinAccrual: req.Conditions.MinAccrual,
+		},
+		Effect: promotionmodel.Effect{
+			Type:  promotionmodel.EffectType(req.Effect.Type),
+			Value: req.Effect.Value,
+		},
+	}
+}
+
+func toResponse(rule promotionmodel.Rule) model.RuleResponse {
+	weekdays := make([]int, 0, len(rule.Conditions.Weekdays))
+	for _, weekday := range rule.Conditions.Weekdays {
+		weekdays = append(weekdays, int(weekday))
+	}
+	resp := model.RuleResponse{
+		ID:     rule.ID,
+		Name:   rule.Name,
+		Active: rule.Active,
+		Conditions: model.ConditionsDTO{
+			Weekdays:   weekdays,
+			FirstOrder: rule.Conditions.FirstOrder,
+			Tiers:      rule.Conditions.Tiers,
+			MinAccrual: rule.Conditions.MinAccrual,
+		},
+		Effect: model.EffectDTO{
+			Type:  string(rule.Effect.Type),
+			Value: rule.Effect.Value,
+		},
+		CreatedAt: common.RFC3339Time{Time: rule.CreatedAt},
+	}
+	if rule.ValidFrom != nil {
+		resp.ValidFrom = &common.RFC3339Time{Time: *rule.ValidFrom}
+	}
+	if rule.ValidTo != nil {
+		resp.ValidTo = &common.RFC3339Time{Time: *rule.ValidTo}
+	}
+	return resp
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	promotionusecase "loyalty/internal/domain/promotion/usecase"
+)
+
+type mockPromotionUsecase struct {
+	created promotionmodel.Rule
+	updated promotionmodel.Rule
+	err     error
+}
+
+func (m *mockPromotionUsecase) CreateRule(ctx context.Context, rule promotionmodel.Rule) (promotionmodel.Rule, error) {
+	m.created = rule
+	rule.ID = 1
+	rule.CreatedAt = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	return rule, m.err
+}
+func (m *mockPromotionUsecase) GetRule(ctx context.Context, id int64) (promotionmodel.Rule, error) {
+	return promotionmodel.Rule{ID: id}, m.err
+}
+func (m *mockPromotionUsecase) ListRules(context.Context) ([]promotionmodel.Rule, error) {
+	return nil, m.err
+}
+func (m *mockPromotionUsecase) UpdateRule(ctx context.Context, rule promotionmodel.Rule) (promotionmodel.Rule, error) {
+	m.updated = rule
+	return rule, m.err
+}
+func (m *mockPromotionUsecase) DeleteRule(context.Context, int64) error { return m.err }
+
+var _ promotionusecase.PromotionUsecase = (*mockPromotionUsecase)(nil)
+
+func newRouter(uc promotionusecase.PromotionUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewHandler(uc)
+	r := gin.New()
+	r.GET("/promotions", h.List)
+	r.POST("/promotions", h.Create)
+	r.GET("/promotions/:id", h.Get)
+	r.PUT("/promotions/:id", h.Update)
+	r.DELETE("/promotions/:id", h.Delete)
+	return r
+}
+
+func TestHandler_Create_201(t *testing.T) {
+	uc := &mockPromotionUsecase{}
+	body := `{"name":"weekend","conditions":{"weekdays":[0,6]},"effect":{"type":"MULTIPLIER","value":"2"}}`
+
+	req := httptest.NewRequest(http.MethodPost, "/promotions", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	newRouter(uc).ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("want %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if !uc.created.Active {
+		t.Fatalf("expected rule to be active by default")
+	}
+	if len(uc.created.Conditions.Weekdays) != 2 || uc.created.Conditions.Weekdays[1] != time.Saturday {
+		t.Fatalf("unexpected weekdays: %v", uc.created.Conditions.Weekdays)
+	}
+	if !uc.created.Effect.Value.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("unexpected effect: %+v", uc.created.Effect)
+	}
+
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp["id"] != float64(1) || resp["created_at"] != "2024-06-01T00:00:00Z" {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestHandler_Create_400OnInvalidRule(t *testing.T) {
+	uc := &mockPromotionUsecase{err: promotionmodel.ErrInvalidRule}
+
+	req := httptest.NewRequest(http.MethodPost, "/promotions", bytes.NewBufferString(`{"name":""}`))
+	w := httptest.NewRecorder()
+	newRouter(uc).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandler_Update_UsesPathID(t *testing.T) {
+	uc := &mockPromotionUsecase{}
+	body := `{"name":"gold","active":false,"effect":{"type":"FIXED","value":"10"}}`
+
+	req := httptest.NewRequest(http.MethodPut, "/promotions/42", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	newRouter(uc).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	if uc.updated.ID != 42 || uc.updated.Active {
+		t.Fatalf("unexpected updated rule: %+v", uc.updated)
+	}
+}
+
+func TestHandler_Get_404(t *testing.T) {
+	uc := &mockPromotionUsecase{err: promotionmodel.ErrRuleNotFound}
+
+	req := httptest.NewRequest(http.MethodGet, "/promotions/7", nil)
+	w := httptest.NewRecorder()
+	newRouter(uc).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("want %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"deleted", "/promotions/1", http.StatusNoContent},
+		{"bad id", "/promotions/abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			w := httptest.NewRecorder()
+			newRouter(&mockPromotionUsecase{}).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("want %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_List_EmptyArray(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/promotions", nil)
+	w := httptest.NewRecorder()
+	newRouter(&mockPromotionUsecase{}).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Body.String() != "[]" {
+		t.Fatalf("want 200 [], got %d %s", w.Code, w.Body.String())
+	}
+}
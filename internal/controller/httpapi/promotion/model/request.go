@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleRequest — тело запроса на создание/обновление правила акции.
+type RuleRequest struct {
+	Name       string        `json:"name"`
+	Active     *bool         `json:"active,omitempty"`
+	ValidFrom  *time.Time    `json:"valid_from,omitempty"`
+	ValidTo    *time.Time    `json:"valid_to,omitempty"`
+	Conditions ConditionsDTO `json:"conditions"`
+	Effect     EffectDTO     `json:"effect"`
+}
+
+// ConditionsDTO — условия применения правила. Дни недели: 0 — воскресенье, 6 — суббота.
+type ConditionsDTO struct {
+	Weekdays   []int            `json:"weekdays,omitempty"`
+	FirstOrder bool             `json:"first_order,omitempty"`
+	Tiers      []string         `json:"tiers,omitempty"`
+	MinAccrual *decimal.Decimal `json:"min_accrual,omitempty"`
+}
+
+// EffectDTO — эффект правила: MULTIPLIER (множитель начисления) или FIXED (фиксированный бонус).
+type EffectDTO struct {
+	Type  string          `json:"type"`
+	Value decimal.Decimal `json:"value"`
+}
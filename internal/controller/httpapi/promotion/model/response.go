@@ -0,0 +1,17 @@
+package model
+
+import (
+	common "loyalty/internal/controller/httpapi/common/model"
+)
+
+// RuleResponse — правило акции в ответе административного API.
+type RuleResponse struct {
+	ID         int64               `json:"id"`
+	Name       string              `json:"name"`
+	Active     bool                `json:"active"`
+	ValidFrom  *common.RFC3339Time `json:"valid_from,omitempty"`
+	ValidTo    *common.RFC3339Time `json:"valid_to,omitempty"`
+	Conditions ConditionsDTO       `json:"conditions"`
+	Effect     EffectDTO           `json:"effect"`
+	CreatedAt  common.RFC3339Time  `json:"created_at"`
+}
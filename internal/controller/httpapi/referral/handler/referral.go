@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"loyalty/internal/controller/httpapi/referral/model"
+	"net/http"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	referralusecase "loyalty/internal/domain/referral/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler — HTTP-хендлеры реферальной программы.
+type Handler struct {
+	usecase referralusecase.ReferralUsecase
+}
+
+// NewHandler создаёт хендлеры реферальной программы.
+func NewHandler(usecase referralusecase.ReferralUsecase) *Handler { return &Handler{usecase: usecase} }
+
+// GetSummary возвращает реферальный код пользователя, приглашённых и полученные вознаграждения.
+func (handler *Handler) GetSummary(ctx *gin.Context) {
+	userID, ok := authctx.UserID(ctx.Request.Context())
+	if !ok || userID <= 0 {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeBadRequest)
+		return
+	}
+	summary, err := handler.usecase.GetSummary(ctx, userID)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+
+	resp := model.SummaryResponse{
+		Code:       summary.Code,
+		Referrals:  make([]model.ReferralItem, 0, len(summary.Referrals)),
+		Bonuses:    make([]model.BonusItem, 0, len(summary.Bonuses)),
+		TotalBonus: summary.Total,
+	}
+	for _, referral := range summary.Referrals {
+		resp.Referrals = append(resp.Referrals, model.ReferralItem{
+			Login:        referral.RefereeLogin,
+			RegisteredAt: common.RFC3339Time{Time: referral.CreatedAt},
+			Rewarded:     referral.Rewarded,
+		})
+	}
+	for _, bonus := range summary.Bonuses {
+		resp.Bonuses = append(resp.Bonuses, model.BonusItem{
+			Login:       bonus.RefereeLogin,
+			Order:       bonus.OrderNumber,
+			Sum:         bonus.Amount,
+			ProcessedAt: common.RFC3339Time{Time: bonus.CreatedAt},
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"loyalty/internal/controller/httpapi/referral/model"
+	referralmodel "loyalty/internal/domain/referral/model"
+	referralusecase "loyalty/internal/domain/referral/usecase"
+)
+
+type mockReferralUsecase struct {
+	summary referralmodel.Summary
+	err     error
+}
+
+func (m *mockReferralUsecase) GetSummary(context.Context, int64) (referralmodel.Summary, error) {
+	return m.summary, m.err
+}
+
+var _ referralusecase.ReferralUsecase = (*mockReferralUsecase)(nil)
+
+func serveSummary(t *testing.T, uc referralusecase.ReferralUsecase) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/api/user/referrals", NewHandler(uc).GetSummary)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/referrals", nil)
+	req = req.WithContext(authctx.WithUserID(req.Context(), 1))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_GetSummary_200(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	w := serveSummary(t, &mockReferralUsecase{summary: referralmodel.Summary{
+		Code:      "ABC123",
+		Referrals: []referralmodel.Referral{{RefereeLogin: "bob", CreatedAt: at, Rewarded: true}},
+		Bonuses:   []referralmodel.Bonus{{RefereeLogin: "bob", OrderNumber: "79927398713", Amount: decimal.NewFromInt(100), CreatedAt: at}},
+		Total:     decimal.NewFromInt(100),
+	}})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp model.SummaryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Code != "ABC123" || len(resp.Referrals) != 1 || len(resp.Bonuses) != 1 {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+	if !resp.Referrals[0].Rewarded || resp.Bonuses[0].Order != "79927398713" {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+	if !resp.TotalBonus.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("want total 100, got %s", resp.TotalBonus)
+	}
+}
+
+func TestHandler_GetSummary_EmptyListsAreArrays(t *testing.T) {
+	w := serveSummary(t, &mockReferralUsecase{summary: referralmodel.Summary{Code: "ABC123"}})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if string(raw["referrals"]) != "[]" || string(raw["bonuses"]) != "[]" {
+		t.Fatalf("expected empty arrays, got %s", w.Body.String())
+	}
+}
+
+func TestHandler_GetSummary_500OnError(t *testing.T) {
+	w := serveSummary(t, &mockReferralUsecase{err: errors.New("db error")})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("want %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
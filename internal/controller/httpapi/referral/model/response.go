@@ -0,0 +1,30 @@
+package model
+
+import (
+	common "loyalty/internal/controller/httpapi/common/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// SummaryResponse — реферальный код пользователя, приглашённые и полученные вознаграждения.
+type SummaryResponse struct {
+	Code       string          `json:"code"`
+	Referrals  []ReferralItem  `json:"referrals"`
+	Bonuses    []BonusItem     `json:"bonuses"`
+	TotalBonus decimal.Decimal `json:"total_bonus"`
+}
+
+// ReferralItem — приглашённый пользователь.
+type ReferralItem struct {
+	Login        string             `json:"login"`
+	RegisteredAt common.RFC3339Time `json:"registered_at"`
+	Rewarded     bool               `json:"rewarded"`
+}
+
+// BonusItem — вознаграждение за приглашённого пользователя.
+type BonusItem struct {
+	Login       string             `json:"login"`
+	Order       string             `json:"order"`
+	Sum         decimal.Decimal    `json:"sum"`
+	ProcessedAt common.RFC3339Time `json:"processed_at"`
+}
@@ -1,21 +1,44 @@
 package httpapi
 
 import (
+	accrualcallback "loyalty/internal/controller/httpapi/accrual/handler"
+	accrualmiddleware "loyalty/internal/controller/httpapi/accrual/middleware"
+	adminmiddleware "loyalty/internal/controller/httpapi/admin/middleware"
 	"loyalty/internal/controller/httpapi/auth/handler"
 	"loyalty/internal/controller/httpapi/auth/middleware"
 	userbalance "loyalty/internal/controller/httpapi/balance/handler"
 	"loyalty/internal/controller/httpapi/common/middleware/gzip"
 	"loyalty/internal/controller/httpapi/common/middleware/logger"
+	httpmetrics "loyalty/internal/controller/httpapi/common/middleware/metrics"
 	"loyalty/internal/controller/httpapi/common/middleware/ratelimit"
+	"loyalty/internal/controller/httpapi/common/middleware/requestid"
+	healthhandler "loyalty/internal/controller/httpapi/health/handler"
+	adminjobruns "loyalty/internal/controller/httpapi/jobrun/handler"
 	userorders "loyalty/internal/controller/httpapi/order/handler"
+	adminpromotions "loyalty/internal/controller/httpapi/promotion/handler"
+	userreferrals "loyalty/internal/controller/httpapi/referral/handler"
+	userstatement "loyalty/internal/controller/httpapi/statement/handler"
+	usertier "loyalty/internal/controller/httpapi/tier/handler"
+	usertransfers "loyalty/internal/controller/httpapi/transfer/handler"
 	userwithdrawals "loyalty/internal/controller/httpapi/withdrawal/handler"
+	accrualusecase "loyalty/internal/domain/accrual/usecase"
 	"loyalty/internal/domain/auth/service"
 	authusecase "loyalty/internal/domain/auth/usecase"
 	balanceusecase "loyalty/internal/domain/balance/usecase"
+	jobrunusecase "loyalty/internal/domain/jobrun/usecase"
 	ordersusecase "loyalty/internal/domain/order/usecase"
+	promotionusecase "loyalty/internal/domain/promotion/usecase"
+	referralusecase "loyalty/internal/domain/referral/usecase"
+	statementusecase "loyalty/internal/domain/statement/usecase"
+	tierusecase "loyalty/internal/domain/tier/usecase"
+	transferusecase "loyalty/internal/domain/transfer/usecase"
 	withdrawalsusecase "loyalty/internal/domain/withdrawal/usecase"
+	"loyalty/internal/health"
+	"loyalty/internal/metrics"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // Deps содержит зависимости HTTP-слоя, необходимые для регистрации маршрутов.
@@ -24,46 +47,135 @@ type Deps struct {
 	OrdersUsecase      ordersusecase.OrdersUsecase
 	BalanceUsecase     balanceusecase.BalanceUsecase
 	WithdrawalsUsecase withdrawalsusecase.WithdrawalsUsecase
+	TierUsecase        tierusecase.TierUsecase
+	PromotionUsecase   promotionusecase.PromotionUsecase
+	ReferralUsecase    referralusecase.ReferralUsecase
+	TransferUsecase    transferusecase.TransferUsecase
+	StatementUsecase   statementusecase.StatementUsecase
+	JobRunUsecase      jobrunusecase.JobRunUsecase
 	TokenService       service.TokenService
 
+	AccrualCallbackUsecase accrualusecase.AccrualCallbackUsecase
+
+	// Readiness — проверки готовности для /readyz; nil означает «всегда готов».
+	Readiness *health.Probe
+
+	// CoreRoutesOnly — регистрировать только регистрацию/вход, заказы, баланс, списания
+	// и обратные вызовы accrual
+	// (хранилище не поддерживает остальные функции, например STORAGE=memory).
+	CoreRoutesOnly bool
+
+	// AdminToken — статический токен административных маршрутов (/api/admin); пустой отключает доступ.
+	AdminToken string
+
+	// AccrualCallbackSecret — ключ HMAC-подписи обратных вызовов accrual; пустой отключает приём.
+	AccrualCallbackSecret string
+	// AccrualCallbackReplayWindow — максимальное расхождение метки времени обратного вызова с текущим временем.
+	AccrualCallbackReplayWindow time.Duration
+
 	EnableHTTPBodyLogging bool
 
 	AuthRateLimitRPS   int
 	AuthRateLimitBurst int
+	// AuthRateLimiter — общий ограничитель для /api/user/register и /api/user/login.
+	// Если nil, создаётся из AuthRateLimitRPS/AuthRateLimitBurst; задаётся снаружи, чтобы менять лимиты на лету.
+	AuthRateLimiter *ratelimit.Limiter
 }
 
 func RegisterRoutes(router *gin.Engine, deps Deps) {
 	registerRoutes(router, deps)
 }
 
+// tracingServiceName — имя сервера в атрибутах HTTP-спанов.
+const tracingServiceName = "loyalty"
+
+// serviceRoutes — служебные маршруты мониторинга и проб.
+var serviceRoutes = map[string]struct{}{
+	"/health":  {},
+	"/livez":   {},
+	"/readyz":  {},
+	"/metrics": {},
+}
+
 func InitRouter(deps Deps) *gin.Engine {
+	router := newEngine(deps)
+	registerRoutes(router, deps)
+	return router
+}
+
+// InitServiceRouter создаёт роутер только со служебными маршрутами (пробы и метрики)
+// для процесса, в котором не обслуживается API (например, отдельно запущенный воркер).
+func InitServiceRouter(deps Deps) *gin.Engine {
+	router := newEngine(deps)
+	registerServiceRoutes(router, deps)
+	return router
+}
+
+// newEngine создаёт gin.Engine с общими middleware.
+// Обработчики передают *gin.Context в usecase как context.Context, поэтому ContextWithFallback
+// включён: значения (логгер с request_id, спан запроса), дедлайн и отмена берутся из контекста запроса.
+func newEngine(deps Deps) *gin.Engine {
 	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(otelgin.Middleware(tracingServiceName, otelgin.WithGinFilter(func(ctx *gin.Context) bool {
+		// Служебные маршруты не трассируем, чтобы не засорять трассы опросами мониторинга.
+		_, service := serviceRoutes[ctx.FullPath()]
+		return !service
+	})))
+	router.Use(requestid.NewMiddleware())
 	router.Use(logger.NewMiddleware(deps.EnableHTTPBodyLogging, "/api/user/register", "/api/user/login"))
+	router.Use(httpmetrics.NewMiddleware())
 	router.Use(gin.Recovery())
-	registerRoutes(router, deps)
 	return router
 }
 
 func registerRoutes(routesEngine *gin.Engine, deps Deps) {
-	routesEngine.GET("/health", func(ctx *gin.Context) {
-		ctx.String(200, "ok")
-	})
+	registerServiceRoutes(routesEngine, deps)
 
 	api := routesEngine.Group("/api")
 	registerAuthRoutes(api, deps)
 
 	authed := api.Group("/user")
 	authed.Use(middleware.NewAuthMiddleware(deps.TokenService))
-	authed.Use(gzip.NewMiddleware(1024, "/api/user/orders", "/api/user/withdrawals"))
+	authed.Use(gzip.NewMiddleware(1024, "/api/user/orders", "/api/user/withdrawals", "/api/user/transactions", "/api/user/statement"))
 
 	registerOrdersRoutes(authed, deps.OrdersUsecase)
 	registerBalanceRoutes(authed, deps.BalanceUsecase)
 	registerWithdrawalsRoutes(authed, deps.WithdrawalsUsecase)
+	registerAccrualCallbackRoutes(api, deps)
+	if deps.CoreRoutesOnly {
+		return
+	}
+	registerTierRoutes(authed, deps.TierUsecase)
+	registerReferralRoutes(authed, deps.ReferralUsecase)
+	registerTransferRoutes(authed, deps.TransferUsecase)
+	registerStatementRoutes(authed, deps.StatementUsecase)
+
+	admin := api.Group("/admin")
+	admin.Use(adminmiddleware.NewAdminMiddleware(deps.AdminToken))
+	registerPromotionRoutes(admin, deps.PromotionUsecase)
+	registerJobRunRoutes(admin, deps.JobRunUsecase)
+}
+
+// registerServiceRoutes регистрирует служебные маршруты мониторинга и проб.
+func registerServiceRoutes(routesEngine *gin.Engine, deps Deps) {
+	routesEngine.GET("/health", func(ctx *gin.Context) {
+		ctx.String(200, "ok")
+	})
+	routesEngine.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	healthHandler := healthhandler.NewHandler(deps.Readiness)
+	routesEngine.GET("/livez", healthHandler.Livez)
+	routesEngine.GET("/readyz", healthHandler.Readyz)
 }
 
 func registerAuthRoutes(api *gin.RouterGroup, deps Deps) {
 	authHandler := handler.NewAuthHandler(deps.AuthUsecase)
-	rateLimiter := ratelimit.NewMiddleware(deps.AuthRateLimitRPS, deps.AuthRateLimitBurst)
+	limiter := deps.AuthRateLimiter
+	if limiter == nil {
+		limiter = ratelimit.NewLimiter(deps.AuthRateLimitRPS, deps.AuthRateLimitBurst)
+	}
+	rateLimiter := limiter.Middleware()
 	api.POST("/user/register", rateLimiter, authHandler.Register)
 	api.POST("/user/login", rateLimiter, authHandler.Login)
 }
@@ -72,6 +184,14 @@ func registerOrdersRoutes(authed *gin.RouterGroup, ordersUsecase ordersusecase.O
 	ordersHandler := userorders.NewHandler(ordersUsecase)
 	authed.POST("/orders", ordersHandler.UploadOrder)
 	authed.GET("/orders", ordersHandler.ListOrders)
+	authed.GET("/orders/:number/history", ordersHandler.ListHistory)
+}
+
+// registerAccrualCallbackRoutes регистрирует приём результатов расчёта, которые присылает система accrual.
+func registerAccrualCallbackRoutes(api *gin.RouterGroup, deps Deps) {
+	callbackHandler := accrualcallback.NewHandler(deps.AccrualCallbackUsecase)
+	signature := accrualmiddleware.NewSignatureMiddleware(deps.AccrualCallbackSecret, deps.AccrualCallbackReplayWindow)
+	api.POST("/internal/accrual/callback", signature, callbackHandler.Callback)
 }
 
 func registerBalanceRoutes(authed *gin.RouterGroup, balanceUsecase balanceusecase.BalanceUsecase) {
@@ -84,3 +204,38 @@ func registerWithdrawalsRoutes(authed *gin.RouterGroup, withdrawalsUsecase withd
 	authed.POST("/balance/withdraw", withdrawalsHandler.Withdraw)
 	authed.GET("/withdrawals", withdrawalsHandler.List)
 }
+
+func registerTierRoutes(authed *gin.RouterGroup, tierUsecase tierusecase.TierUsecase) {
+	tierHandler := usertier.NewHandler(tierUsecase)
+	authed.GET("/profile", tierHandler.GetProfile)
+}
+
+func registerReferralRoutes(authed *gin.RouterGroup, referralUsecase referralusecase.ReferralUsecase) {
+	referralHandler := userreferrals.NewHandler(referralUsecase)
+	authed.GET("/referrals", referralHandler.GetSummary)
+}
+
+func registerTransferRoutes(authed *gin.RouterGroup, transferUsecase transferusecase.TransferUsecase) {
+	transferHandler := usertransfers.NewHandler(transferUsecase)
+	authed.POST("/balance/transfer", transferHandler.Transfer)
+	authed.GET("/transactions", transferHandler.ListTransactions)
+}
+
+func registerStatementRoutes(authed *gin.RouterGroup, statementUsecase statementusecase.StatementUsecase) {
+	statementHandler := userstatement.NewHandler(statementUsecase)
+	authed.GET("/statement", statementHandler.GetStatement)
+}
+
+func registerPromotionRoutes(admin *gin.RouterGroup, promotionUsecase promotionusecase.PromotionUsecase) {
+	promotionsHandler := adminpromotions.NewHandler(promotionUsecase)
+	admin.GET("/promotions", promotionsHandler.List)
+	admin.POST("/promotions", promotionsHandler.Create)
+	admin.GET("/promotions/:id", promotionsHandler.Get)
+	admin.PUT("/promotions/:id", promotionsHandler.Update)
+	admin.DELETE("/promotions/:id", promotionsHandler.Delete)
+}
+
+func registerJobRunRoutes(admin *gin.RouterGroup, jobRunUsecase jobrunusecase.JobRunUsecase) {
+	jobRunsHandler := adminjobruns.NewHandler(jobRunUsecase)
+	admin.GET("/jobs/runs", jobRunsHandler.List)
+}
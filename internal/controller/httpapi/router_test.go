@@ -10,16 +10,23 @@ import (
 	tokensvc "loyalty/internal/adapter/token/jwt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	networkmodel "loyalty/internal/controller/httpapi/auth/model"
+	"loyalty/internal/controller/httpapi/common/middleware/requestid"
 	balancemodel "loyalty/internal/domain/balance/model"
 	ordersmodel "loyalty/internal/domain/order/model"
 	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
+	"loyalty/internal/tracing"
 )
 
 type mockAuthUsecase struct {
@@ -27,7 +34,7 @@ type mockAuthUsecase struct {
 	loginFn    func(ctx context.Context, login, password string) (string, error)
 }
 
-func (m *mockAuthUsecase) Register(ctx context.Context, login, password string) (string, error) {
+func (m *mockAuthUsecase) Register(ctx context.Context, login, password, _ string) (string, error) {
 	return m.registerFn(ctx, login, password)
 }
 func (m *mockAuthUsecase) Login(ctx context.Context, login, password string) (string, error) {
@@ -40,6 +47,9 @@ func (m *mockOrdersUsecase) UploadOrder(context.Context, int64, string) error {
 func (m *mockOrdersUsecase) LoadOrders(context.Context, int64) ([]ordersmodel.Order, error) {
 	return nil, nil
 }
+func (m *mockOrdersUsecase) LoadHistory(context.Context, int64, string) ([]ordersmodel.StatusChange, error) {
+	return nil, nil
+}
 
 type mockOrdersUsecaseWithOrders struct {
 	orders []ordersmodel.Order
@@ -49,6 +59,9 @@ func (m *mockOrdersUsecaseWithOrders) UploadOrder(context.Context, int64, string
 func (m *mockOrdersUsecaseWithOrders) LoadOrders(context.Context, int64) ([]ordersmodel.Order, error) {
 	return m.orders, nil
 }
+func (m *mockOrdersUsecaseWithOrders) LoadHistory(context.Context, int64, string) ([]ordersmodel.StatusChange, error) {
+	return nil, nil
+}
 
 type mockBalanceUsecase struct{}
 
@@ -127,6 +140,51 @@ func TestRegisterRoutes_Health(t *testing.T) {
 	}
 }
 
+func TestInitRouter_MetricsEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := InitRouter(Deps{
+		AuthUsecase:        &mockAuthUsecase{},
+		OrdersUsecase:      &mockOrdersUsecase{},
+		BalanceUsecase:     &mockBalanceUsecase{},
+		WithdrawalsUsecase: &mockWithdrawalsUsecase{},
+		TokenService:       tokensvc.NewTokenService("secret", time.Hour),
+		AuthRateLimitRPS:   100,
+		AuthRateLimitBurst: 20,
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `loyalty_http_request_duration_seconds_count{method="GET",route="/health",status="200"}`) {
+		t.Fatalf("expected http duration metric for /health, got:\n%s", w.Body.String())
+	}
+}
+
+func TestInitServiceRouter_OnlyServiceRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := InitServiceRouter(Deps{})
+
+	for _, path := range []string{"/health", "/livez", "/readyz", "/metrics"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: want %d, got %d", path, http.StatusOK, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/user/login", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("API routes must not be served, got %d", w.Code)
+	}
+}
+
 func TestRegisterRoutes_UserBalance_UnauthorizedWithoutToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -440,3 +498,300 @@ func TestRegisterRoutes_UserWithdrawals_GzipWhenAcceptedAndLarge(t *testing.T) {
 		t.Fatalf("want %d items, got %d", 200, len(resp))
 	}
 }
+
+func TestRegisterRoutes_UserProfile_UnauthorizedWithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, Deps{
+		AuthUsecase: &mockAuthUsecase{
+			registerFn: func(context.Context, string, string) (string, error) { return "", nil },
+			loginFn:    func(context.Context, string, string) (string, error) { return "", nil },
+		},
+		OrdersUsecase:         &mockOrdersUsecase{},
+		BalanceUsecase:        &mockBalanceUsecase{},
+		WithdrawalsUsecase:    &mockWithdrawalsUsecase{},
+		TokenService:          tokensvc.NewTokenService("secret", time.Hour),
+		EnableHTTPBodyLogging: false,
+		AuthRateLimitRPS:      100,
+		AuthRateLimitBurst:    20,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/profile", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("want %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRegisterRoutes_AdminPromotions_UnauthorizedWithoutAdminToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, Deps{
+		AuthUsecase: &mockAuthUsecase{
+			registerFn: func(context.Context, string, string) (string, error) { return "", nil },
+			loginFn:    func(context.Context, string, string) (string, error) { return "", nil },
+		},
+		OrdersUsecase:         &mockOrdersUsecase{},
+		BalanceUsecase:        &mockBalanceUsecase{},
+		WithdrawalsUsecase:    &mockWithdrawalsUsecase{},
+		TokenService:          tokensvc.NewTokenService("secret", time.Hour),
+		AdminToken:            "admin-secret",
+		EnableHTTPBodyLogging: false,
+		AuthRateLimitRPS:      100,
+		AuthRateLimitBurst:    20,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/promotions", nil)
+	req.Header.Set("X-Admin-Token", "wrong")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("want %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRegisterRoutes_AdminJobRuns_UnauthorizedWithoutAdminToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, Deps{
+		AuthUsecase: &mockAuthUsecase{
+			registerFn: func(context.Context, string, string) (string, error) { return "", nil },
+			loginFn:    func(context.Context, string, string) (string, error) { return "", nil },
+		},
+		OrdersUsecase:         &mockOrdersUsecase{},
+		BalanceUsecase:        &mockBalanceUsecase{},
+		WithdrawalsUsecase:    &mockWithdrawalsUsecase{},
+		TokenService:          tokensvc.NewTokenService("secret", time.Hour),
+		AdminToken:            "admin-secret",
+		EnableHTTPBodyLogging: false,
+		AuthRateLimitRPS:      100,
+		AuthRateLimitBurst:    20,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/jobs/runs", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("want %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRegisterRoutes_UserReferrals_UnauthorizedWithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, Deps{
+		AuthUsecase: &mockAuthUsecase{
+			registerFn: func(context.Context, string, string) (string, error) { return "", nil },
+			loginFn:    func(context.Context, string, string) (string, error) { return "", nil },
+		},
+		OrdersUsecase:         &mockOrdersUsecase{},
+		BalanceUsecase:        &mockBalanceUsecase{},
+		WithdrawalsUsecase:    &mockWithdrawalsUsecase{},
+		TokenService:          tokensvc.NewTokenService("secret", time.Hour),
+		EnableHTTPBodyLogging: false,
+		AuthRateLimitRPS:      100,
+		AuthRateLimitBurst:    20,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/referrals", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("want %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRegisterRoutes_UserTransfer_UnauthorizedWithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, Deps{
+		AuthUsecase: &mockAuthUsecase{
+			registerFn: func(context.Context, string, string) (string, error) { return "", nil },
+			loginFn:    func(context.Context, string, string) (string, error) { return "", nil },
+		},
+		OrdersUsecase:         &mockOrdersUsecase{},
+		BalanceUsecase:        &mockBalanceUsecase{},
+		WithdrawalsUsecase:    &mockWithdrawalsUsecase{},
+		TokenService:          tokensvc.NewTokenService("secret", time.Hour),
+		EnableHTTPBodyLogging: false,
+		AuthRateLimitRPS:      100,
+		AuthRateLimitBurst:    20,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/user/balance/transfer", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("want %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRegisterRoutes_UserStatement_UnauthorizedWithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, Deps{
+		AuthUsecase: &mockAuthUsecase{
+			registerFn: func(context.Context, string, string) (string, error) { return "", nil },
+			loginFn:    func(context.Context, string, string) (string, error) { return "", nil },
+		},
+		OrdersUsecase:         &mockOrdersUsecase{},
+		BalanceUsecase:        &mockBalanceUsecase{},
+		WithdrawalsUsecase:    &mockWithdrawalsUsecase{},
+		TokenService:          tokensvc.NewTokenService("secret", time.Hour),
+		EnableHTTPBodyLogging: false,
+		AuthRateLimitRPS:      100,
+		AuthRateLimitBurst:    20,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/statement", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("want %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestRegisterRoutes_CoreRoutesOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterRoutes(r, Deps{
+		AuthUsecase: &mockAuthUsecase{
+			registerFn: func(context.Context, string, string) (string, error) { return "", nil },
+			loginFn:    func(context.Context, string, string) (string, error) { return "", nil },
+		},
+		OrdersUsecase:      &mockOrdersUsecase{},
+		BalanceUsecase:     &mockBalanceUsecase{},
+		WithdrawalsUsecase: &mockWithdrawalsUsecase{},
+		TokenService:       tokensvc.NewTokenService("secret", time.Hour),
+		CoreRoutesOnly:     true,
+		AuthRateLimitRPS:   100,
+		AuthRateLimitBurst: 20,
+	})
+
+	for _, path := range []string{"/api/user/balance", "/api/user/orders", "/api/user/withdrawals"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("%s: want %d, got %d", path, http.StatusUnauthorized, w.Code)
+		}
+	}
+	for _, path := range []string{"/api/user/profile", "/api/user/statement", "/api/user/referrals", "/api/admin/promotions"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("%s: want %d, got %d", path, http.StatusNotFound, w.Code)
+		}
+	}
+}
+
+// loggingOrdersUsecase логирует через логгер из контекста, как это делают usecase и репозитории.
+type loggingOrdersUsecase struct {
+	mockOrdersUsecase
+}
+
+func (m *loggingOrdersUsecase) LoadOrders(ctx context.Context, _ int64) ([]ordersmodel.Order, error) {
+	zerolog.Ctx(ctx).Info().Msg("usecase load orders")
+	return nil, nil
+}
+
+func TestInitRouter_UsecaseLogCarriesRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+	prev := zerolog.DefaultContextLogger
+	zerolog.DefaultContextLogger = &base
+	t.Cleanup(func() { zerolog.DefaultContextLogger = prev })
+
+	svc, token := mustIssueToken(t)
+	r := InitRouter(Deps{
+		AuthUsecase:        &mockAuthUsecase{},
+		OrdersUsecase:      &loggingOrdersUsecase{},
+		BalanceUsecase:     &mockBalanceUsecase{},
+		WithdrawalsUsecase: &mockWithdrawalsUsecase{},
+		TokenService:       svc,
+		CoreRoutesOnly:     true,
+		AuthRateLimitRPS:   100,
+		AuthRateLimitBurst: 20,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(requestid.Header, "req-77")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("want %d, got %d", http.StatusNoContent, w.Code)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, `"message":"usecase load orders"`) {
+			if !strings.Contains(line, `"request_id":"req-77"`) {
+				t.Fatalf("expected request_id in usecase log, got %s", line)
+			}
+			return
+		}
+	}
+	t.Fatalf("usecase log line not found in %s", buf.String())
+}
+
+// tracingOrdersUsecase открывает спаны usecase и репозитория, как это делают реальные слои.
+type tracingOrdersUsecase struct {
+	mockOrdersUsecase
+}
+
+func (m *tracingOrdersUsecase) LoadOrders(ctx context.Context, _ int64) ([]ordersmodel.Order, error) {
+	ctx, usecaseSpan := tracing.Start(ctx, "OrdersUsecase.LoadOrders")
+	defer usecaseSpan.End()
+	_, repositorySpan := tracing.Start(ctx, "OrdersRepository.ListByUser")
+	repositorySpan.End()
+	return nil, nil
+}
+
+func TestInitRouter_RepositorySpanIsChildOfHTTPSpan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	svc, token := mustIssueToken(t)
+	r := InitRouter(Deps{
+		AuthUsecase:        &mockAuthUsecase{},
+		OrdersUsecase:      &tracingOrdersUsecase{},
+		BalanceUsecase:     &mockBalanceUsecase{},
+		WithdrawalsUsecase: &mockWithdrawalsUsecase{},
+		TokenService:       svc,
+		CoreRoutesOnly:     true,
+		AuthRateLimitRPS:   100,
+		AuthRateLimitBurst: 20,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/orders", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	server, usecase, repository := spans["GET /api/user/orders"], spans["OrdersUsecase.LoadOrders"], spans["OrdersRepository.ListByUser"]
+	if server == nil || usecase == nil || repository == nil {
+		t.Fatalf("expected server, usecase and repository spans, got %v", spans)
+	}
+	if usecase.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Fatalf("usecase span parent = %s, want HTTP span %s", usecase.Parent().SpanID(), server.SpanContext().SpanID())
+	}
+	if repository.Parent().SpanID() != usecase.SpanContext().SpanID() {
+		t.Fatalf("repository span parent = %s, want usecase span %s", repository.Parent().SpanID(), usecase.SpanContext().SpanID())
+	}
+	if repository.SpanContext().TraceID() != server.SpanContext().TraceID() {
+		t.Fatalf("repository span is in trace %s, want %s", repository.SpanContext().TraceID(), server.SpanContext().TraceID())
+	}
+}
@@ -18,13 +18,22 @@ const (
 // StartServer поднимает HTTP-сервер и запускает его в отдельной горутине.
 // Возвращает сам сервер (для Shutdown) и канал, в который будет отправлена ошибка ListenAndServe.
 func StartServer(appConfig config.Config, deps Deps) (*http.Server, <-chan error) {
+	return startServer(appConfig, InitRouter(deps))
+}
+
+// StartServiceServer поднимает HTTP-сервер только со служебными маршрутами (/livez, /readyz, /metrics).
+func StartServiceServer(appConfig config.Config, deps Deps) (*http.Server, <-chan error) {
+	return startServer(appConfig, InitServiceRouter(deps))
+}
+
+func startServer(appConfig config.Config, handler http.Handler) (*http.Server, <-chan error) {
 	srv := &http.Server{
 		Addr:              appConfig.RunAddress,
 		ReadHeaderTimeout: readHeaderTimeout,
 		ReadTimeout:       readTimeout,
 		WriteTimeout:      writeTimeout,
 		IdleTimeout:       idleTimeout,
-		Handler:           InitRouter(deps),
+		Handler:           handler,
 	}
 
 	errChannel := make(chan error, 1)
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/csv"
+	"loyalty/internal/controller/httpapi/statement/model"
+	"net/http"
+	"time"
+
+	statementmodel "loyalty/internal/domain/statement/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	documentTitle      = "Account statement"
+	documentTimeLayout = "2006-01-02 15:04"
+	amountPlaces       = 2
+)
+
+var exportColumns = []string{"processed_at", "type", "order", "description", "amount", "balance"}
+
+// writeCSV отдаёт выписку в CSV (по строке на операцию, время в RFC3339).
+func writeCSV(ctx *gin.Context, statement statementmodel.Statement) {
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", `attachment; filename="statement.csv"`)
+	ctx.Status(http.StatusOK)
+
+	writer := csv.NewWriter(ctx.Writer)
+	records := make([][]string, 0, len(statement.Entries)+1)
+	records = append(records, exportColumns)
+	for _, entry := range statement.Entries {
+		records = append(records, []string{
+			entry.At.Format(time.RFC3339),
+			string(entry.Type),
+			entry.Reference,
+			entry.Description,
+			entry.Amount.String(),
+			entry.Balance.String(),
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		zerolog.Ctx(ctx.Request.Context()).Error().Err(err).Msg("write statement csv failed")
+	}
+}
+
+// toDocument готовит выписку к вёрстке в PDF: даты и суммы отформатированы, строки — в порядке колонок.
+func toDocument(statement statementmodel.Statement) model.DocumentResponse {
+	doc := model.DocumentResponse{
+		Title:       documentTitle,
+		Account:     statement.Query.UserID,
+		GeneratedAt: statement.GeneratedAt.UTC().Format(documentTimeLayout),
+		Summary: model.DocumentSummary{
+			OpeningBalance: formatAmount(statement.Summary.OpeningBalance),
+			TotalCredit:    formatAmount(statement.Summary.TotalCredit),
+			TotalDebit:     formatAmount(statement.Summary.TotalDebit),
+			ClosingBalance: formatAmount(statement.Summary.ClosingBalance),
+			Entries:        statement.Summary.Count,
+		},
+		Columns: exportColumns,
+		Rows:    make([][]string, 0, len(statement.Entries)),
+	}
+	if statement.Query.From != nil {
+		doc.Period.From = statement.Query.From.UTC().Format(dateLayout)
+	}
+	if statement.Query.To != nil {
+		// To — исключающая граница, в документе показываем последний включённый день.
+		doc.Period.To = statement.Query.To.UTC().Add(-time.Nanosecond).Format(dateLayout)
+	}
+	for _, entry := range statement.Entries {
+		doc.Rows = append(doc.Rows, []string{
+			entry.At.UTC().Format(documentTimeLayout),
+			string(entry.Type),
+			entry.Reference,
+			entry.Description,
+			formatAmount(entry.Amount),
+			formatAmount(entry.Balance),
+		})
+	}
+	return doc
+}
+
+func formatAmount(amount decimal.Decimal) string {
+	return amount.StringFixed(amountPlaces)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"loyalty/internal/controller/httpapi/statement/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	statementmodel "loyalty/internal/domain/statement/model"
+	statementusecase "loyalty/internal/domain/statement/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+	formatPDF  = "pdf"
+
+	dateLayout = "2006-01-02"
+)
+
+// Handler — HTTP-хендлер выписки по счёту.
+type Handler struct {
+	usecase statementusecase.StatementUsecase
+}
+
+// NewHandler создаёт хендлер выписки.
+func NewHandler(usecase statementusecase.StatementUsecase) *Handler {
+	return &Handler{usecase: usecase}
+}
+
+// GetStatement возвращает выписку за диапазон дат (from/to) постранично (page/page_size).
+// format=csv и format=pdf отдают выписку для выгрузки; без page_size в них попадает весь диапазон.
+func (handler *Handler) GetStatement(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", formatJSON)
+	if format != formatJSON && format != formatCSV && format != formatPDF {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeInvalidInput)
+		return
+	}
+
+	userID, _ := authctx.UserID(ctx.Request.Context())
+	query, ok := parseQuery(ctx, userID, format)
+	if !ok {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeInvalidInput)
+		return
+	}
+
+	statement, err := handler.usecase.GetStatement(ctx, query)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+
+	switch format {
+	case formatCSV:
+		writeCSV(ctx, statement)
+	case formatPDF:
+		ctx.JSON(http.StatusOK, toDocument(statement))
+	default:
+		ctx.JSON(http.StatusOK, toResponse(statement))
+	}
+}
+
+// parseQuery разбирает параметры выписки. Дата без времени в to включает весь день.
+func parseQuery(ctx *gin.Context, userID int64, format string) (statementmodel.Query, bool) {
+	query := statementmodel.Query{UserID: userID, Page: 1, PageSize: statementmodel.DefaultPageSize}
+	if format != formatJSON {
+		query.PageSize = 0
+	}
+
+	if raw := ctx.Query("from"); raw != "" {
+		from, _, ok := parseTime(raw)
+		if !ok {
+			return statementmodel.Query{}, false
+		}
+		query.From = &from
+	}
+	if raw := ctx.Query("to"); raw != "" {
+		to, dateOnly, ok := parseTime(raw)
+		if !ok {
+			return statementmodel.Query{}, false
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		query.To = &to
+	}
+	if raw := ctx.Query("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil {
+			return statementmodel.Query{}, false
+		}
+		query.Page = page
+	}
+	if raw := ctx.Query("page_size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil {
+			return statementmodel.Query{}, false
+		}
+		query.PageSize = size
+	}
+	return query, true
+}
+
+func parseTime(raw string) (value time.Time, dateOnly bool, ok bool) {
+	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		return parsed, false, true
+	}
+	if parsed, err := time.Parse(dateLayout, raw); err == nil {
+		return parsed, true, true
+	}
+	return time.Time{}, false, false
+}
+
+func toResponse(statement statementmodel.Statement) model.StatementResponse {
+	resp := model.StatementResponse{
+		Page:           statement.Query.Page,
+		PageSize:       statement.Query.PageSize,
+		Total:          statement.Summary.Count,
+		OpeningBalance: statement.Summary.OpeningBalance,
+		ClosingBalance: statement.Summary.ClosingBalance,
+		TotalCredit:    statement.Summary.TotalCredit,
+		TotalDebit:     statement.Summary.TotalDebit,
+		Entries:        make([]model.EntryItem, 0, len(statement.Entries)),
+	}
+	if statement.Query.From != nil {
+		resp.From = &common.RFC3339Time{Time: *statement.Query.From}
+	}
+	if statement.Query.To != nil {
+		resp.To = &common.RFC3339Time{Time: *statement.Query.To}
+	}
+	for _, entry := range statement.Entries {
+		resp.Entries = append(resp.Entries, model.EntryItem{
+			Type:        string(entry.Type),
+			Order:       entry.Reference,
+			Description: entry.Description,
+			Amount:      entry.Amount,
+			Balance:     entry.Balance,
+			ProcessedAt: common.RFC3339Time{Time: entry.At},
+		})
+	}
+	return resp
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"loyalty/internal/controller/httpapi/statement/model"
+	statementmodel "loyalty/internal/domain/statement/model"
+	statementusecase "loyalty/internal/domain/statement/usecase"
+)
+
+type mockStatementUsecase struct {
+	gotQuery statementmodel.Query
+	err      error
+}
+
+func (m *mockStatementUsecase) GetStatement(_ context.Context, query statementmodel.Query) (statementmodel.Statement, error) {
+	m.gotQuery = query
+	if m.err != nil {
+		return statementmodel.Statement{}, m.err
+	}
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	return statementmodel.Statement{
+		Query: query,
+		Summary: statementmodel.Summary{
+			OpeningBalance: decimal.NewFromInt(10),
+			ClosingBalance: decimal.NewFromInt(80),
+			TotalCredit:    decimal.NewFromInt(100),
+			TotalDebit:     decimal.NewFromInt(30),
+			Count:          2,
+		},
+		Entries: []statementmodel.Entry{
+			{Type: statementmodel.EntryAccrual, Reference: "79927398713", Amount: decimal.NewFromInt(100), Balance: decimal.NewFromInt(110), At: at},
+			{Type: statementmodel.EntryWithdrawal, Reference: "2377225624", Amount: decimal.NewFromInt(-30), Balance: decimal.NewFromInt(80), At: at.Add(time.Hour)},
+		},
+		GeneratedAt: at,
+	}, nil
+}
+
+var _ statementusecase.StatementUsecase = (*mockStatementUsecase)(nil)
+
+func serve(t *testing.T, uc statementusecase.StatementUsecase, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/api/user/statement", NewHandler(uc).GetStatement)
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req = req.WithContext(authctx.WithUserID(req.Context(), 1))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_GetStatement_JSON(t *testing.T) {
+	uc := &mockStatementUsecase{}
+	w := serve(t, uc, "/api/user/statement?from=2024-01-01&to=2024-01-31&page=2&page_size=10")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	if uc.gotQuery.Page != 2 || uc.gotQuery.PageSize != 10 || uc.gotQuery.UserID != 1 {
+		t.Fatalf("unexpected query: %+v", uc.gotQuery)
+	}
+	if want := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); uc.gotQuery.To == nil || !uc.gotQuery.To.Equal(want) {
+		t.Fatalf("expected inclusive to date to become %v, got %v", want, uc.gotQuery.To)
+	}
+
+	var resp model.StatementResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 2 || len(resp.Entries) != 2 || !resp.Entries[1].Balance.Equal(decimal.NewFromInt(80)) {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+}
+
+func TestHandler_GetStatement_DefaultPagination(t *testing.T) {
+	uc := &mockStatementUsecase{}
+	serve(t, uc, "/api/user/statement")
+
+	if uc.gotQuery.Page != 1 || uc.gotQuery.PageSize != statementmodel.DefaultPageSize {
+		t.Fatalf("unexpected query: %+v", uc.gotQuery)
+	}
+	if uc.gotQuery.From != nil || uc.gotQuery.To != nil {
+		t.Fatalf("expected open range, got %+v", uc.gotQuery)
+	}
+}
+
+func TestHandler_GetStatement_CSV(t *testing.T) {
+	uc := &mockStatementUsecase{}
+	w := serve(t, uc, "/api/user/statement?format=csv")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	if uc.gotQuery.PageSize != 0 {
+		t.Fatalf("expected export of the whole range, got page size %d", uc.gotQuery.PageSize)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "processed_at" || records[2][1] != "WITHDRAWAL" || records[2][4] != "-30" {
+		t.Fatalf("unexpected csv: %q", records)
+	}
+}
+
+func TestHandler_GetStatement_PDFReadyJSON(t *testing.T) {
+	w := serve(t, &mockStatementUsecase{}, "/api/user/statement?format=pdf&from=2024-01-01&to=2024-01-31")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	var doc model.DocumentResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc.Period.From != "2024-01-01" || doc.Period.To != "2024-01-31" {
+		t.Fatalf("unexpected period: %+v", doc.Period)
+	}
+	if doc.Summary.ClosingBalance != "80.00" || len(doc.Rows) != 2 || doc.Rows[1][4] != "-30.00" {
+		t.Fatalf("unexpected document: %s", w.Body.String())
+	}
+}
+
+func TestHandler_GetStatement_400OnInvalidParams(t *testing.T) {
+	for _, target := range []string{
+		"/api/user/statement?format=xml",
+		"/api/user/statement?from=yesterday",
+		"/api/user/statement?page=abc",
+	} {
+		w := serve(t, &mockStatementUsecase{}, target)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: want %d, got %d", target, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestHandler_GetStatement_400OnInvalidQuery(t *testing.T) {
+	w := serve(t, &mockStatementUsecase{err: statementmodel.ErrInvalidQuery}, "/api/user/statement?page=0")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandler_GetStatement_500OnError(t *testing.T) {
+	w := serve(t, &mockStatementUsecase{err: errors.New("db error")}, "/api/user/statement")
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("want %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
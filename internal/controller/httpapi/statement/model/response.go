@@ -0,0 +1,57 @@
+package model
+
+import (
+	common "loyalty/internal/controller/httpapi/common/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// StatementResponse — выписка по счёту в формате JSON.
+type StatementResponse struct {
+	From           *common.RFC3339Time `json:"from,omitempty"`
+	To             *common.RFC3339Time `json:"to,omitempty"`
+	Page           int                 `json:"page"`
+	PageSize       int                 `json:"page_size"`
+	Total          int                 `json:"total"`
+	OpeningBalance decimal.Decimal     `json:"opening_balance"`
+	ClosingBalance decimal.Decimal     `json:"closing_balance"`
+	TotalCredit    decimal.Decimal     `json:"total_credit"`
+	TotalDebit     decimal.Decimal     `json:"total_debit"`
+	Entries        []EntryItem         `json:"entries"`
+}
+
+// EntryItem — операция выписки: знаковая сумма и остаток после операции.
+type EntryItem struct {
+	Type        string             `json:"type"`
+	Order       string             `json:"order,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Amount      decimal.Decimal    `json:"amount"`
+	Balance     decimal.Decimal    `json:"balance"`
+	ProcessedAt common.RFC3339Time `json:"processed_at"`
+}
+
+// DocumentResponse — выписка, подготовленная для вёрстки в PDF: все значения уже отформатированы.
+type DocumentResponse struct {
+	Title       string          `json:"title"`
+	Account     int64           `json:"account"`
+	Period      DocumentPeriod  `json:"period"`
+	GeneratedAt string          `json:"generated_at"`
+	Summary     DocumentSummary `json:"summary"`
+	Columns     []string        `json:"columns"`
+	Rows        [][]string      `json:"rows"`
+}
+
+// DocumentPeriod — период выписки (даты включительно; пустая строка — без ограничения).
+type DocumentPeriod struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DocumentSummary — итоги выписки в отформатированном виде.
+type DocumentSummary struct {
+	OpeningBalance string `json:"opening_balance"`
+	TotalCredit    string `json:"total_credit"`
+	TotalDebit     string `json:"total_debit"`
+	ClosingBalance string `json:"closing_balance"`
+	Entries        int    `json:"entries"`
+}
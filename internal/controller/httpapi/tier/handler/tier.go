@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"loyalty/internal/controller/httpapi/tier/model"
+	"net/http"
+	"time"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	tierusecase "loyalty/internal/domain/tier/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler — HTTP-хендлеры профиля (уровня) пользователя.
+type Handler struct {
+	usecase tierusecase.TierUsecase
+}
+
+// NewHandler создаёт хендлеры профиля пользователя.
+func NewHandler(usecase tierusecase.TierUsecase) *Handler { return &Handler{usecase: usecase} }
+
+// GetProfile возвращает текущий уровень пользователя и прогресс до следующего уровня.
+func (handler *Handler) GetProfile(ctx *gin.Context) {
+	userID, ok := authctx.UserID(ctx.Request.Context())
+	if !ok || userID <= 0 {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeBadRequest)
+		return
+	}
+	profile, err := handler.usecase.GetProfile(ctx, userID)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+
+	resp := model.ProfileResponse{
+		Tier:           string(profile.Tier.Level),
+		Multiplier:     profile.Tier.Multiplier,
+		RollingAccrual: profile.Tier.RollingAccrual,
+		WindowDays:     int(profile.Window / (24 * time.Hour)),
+	}
+	if profile.Next != nil {
+		resp.NextTier = &model.NextTier{
+			Tier:      string(profile.Next.Level),
+			Threshold: profile.Next.Threshold,
+			Remaining: profile.ToNext,
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
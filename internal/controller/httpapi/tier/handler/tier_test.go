@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	tiermodel "loyalty/internal/domain/tier/model"
+	tierusecase "loyalty/internal/domain/tier/usecase"
+)
+
+type mockTierUsecase struct {
+	getFn func(ctx context.Context, userID int64) (tiermodel.Profile, error)
+}
+
+func (m *mockTierUsecase) GetProfile(ctx context.Context, userID int64) (tiermodel.Profile, error) {
+	return m.getFn(ctx, userID)
+}
+
+var _ tierusecase.TierUsecase = (*mockTierUsecase)(nil)
+
+func serveProfile(t *testing.T, uc tierusecase.TierUsecase) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/api/user/profile", NewHandler(uc).GetProfile)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/profile", nil)
+	req = req.WithContext(authctx.WithUserID(req.Context(), 1))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_GetProfile_200WithNextTier(t *testing.T) {
+	w := serveProfile(t, &mockTierUsecase{
+		getFn: func(context.Context, int64) (tiermodel.Profile, error) {
+			return tiermodel.Profile{
+				Tier: tiermodel.UserTier{
+					Level:          tiermodel.LevelSilver,
+					Multiplier:     decimal.NewFromInt(1),
+					RollingAccrual: decimal.NewFromInt(1200),
+				},
+				Window: 90 * 24 * time.Hour,
+				Next: &tiermodel.Rule{
+					Level:     tiermodel.LevelGold,
+					Threshold: decimal.NewFromInt(5000),
+				},
+				ToNext: decimal.NewFromInt(3800),
+			}, nil
+		},
+	})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	want := `{"tier":"SILVER","multiplier":"1","rolling_accrual":"1200","window_days":90,` +
+		`"next_tier":{"tier":"GOLD","threshold":"5000","remaining":"3800"}}`
+	if got := w.Body.String(); got != want {
+		t.Fatalf("unexpected body: %s", got)
+	}
+}
+
+func TestHandler_GetProfile_TopTierOmitsNext(t *testing.T) {
+	w := serveProfile(t, &mockTierUsecase{
+		getFn: func(context.Context, int64) (tiermodel.Profile, error) {
+			return tiermodel.Profile{
+				Tier: tiermodel.UserTier{
+					Level:          tiermodel.LevelPlatinum,
+					Multiplier:     decimal.RequireFromString("1.25"),
+					RollingAccrual: decimal.NewFromInt(20000),
+				},
+				Window: 90 * 24 * time.Hour,
+			}, nil
+		},
+	})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	want := `{"tier":"PLATINUM","multiplier":"1.25","rolling_accrual":"20000","window_days":90}`
+	if got := w.Body.String(); got != want {
+		t.Fatalf("unexpected body: %s", got)
+	}
+}
+
+func TestHandler_GetProfile_500OnUnexpected(t *testing.T) {
+	w := serveProfile(t, &mockTierUsecase{
+		getFn: func(context.Context, int64) (tiermodel.Profile, error) {
+			return tiermodel.Profile{}, errors.New("boom")
+		},
+	})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("want %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// ProfileResponse — ответ с уровнем пользователя и прогрессом до следующего уровня.
+type ProfileResponse struct {
+	Tier           string          `json:"tier"`
+	Multiplier     decimal.Decimal `json:"multiplier"`
+	RollingAccrual decimal.Decimal `json:"rolling_accrual"`
+	WindowDays     int             `json:"window_days"`
+	NextTier       *NextTier       `json:"next_tier,omitempty"`
+}
+
+// NextTier — следующий уровень и сколько баллов осталось до него.
+type NextTier struct {
+	Tier      string          `json:"tier"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Remaining decimal.Decimal `json:"remaining"`
+}
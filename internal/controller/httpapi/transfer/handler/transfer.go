@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"loyalty/internal/controller/httpapi/transfer/model"
+	"net/http"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	transferusecase "loyalty/internal/domain/transfer/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler — HTTP-хендлеры переводов баллов между пользователями.
+type Handler struct {
+	usecase transferusecase.TransferUsecase
+}
+
+// NewHandler создаёт хендлеры переводов.
+func NewHandler(usecase transferusecase.TransferUsecase) *Handler {
+	return &Handler{usecase: usecase}
+}
+
+// Transfer обрабатывает запрос на перевод баллов другому пользователю.
+func (handler *Handler) Transfer(ctx *gin.Context) {
+	var req model.TransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.WriteError(ctx, http.StatusBadRequest, common.CodeBadRequest)
+		return
+	}
+	userID, _ := authctx.UserID(ctx.Request.Context())
+
+	transfer, err := handler.usecase.Transfer(ctx, userID, req.Login, req.Sum)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.TransferResponse{
+		ID:          transfer.ID,
+		Recipient:   transfer.RecipientLogin,
+		Sum:         transfer.Sum,
+		ProcessedAt: common.RFC3339Time{Time: transfer.CreatedAt},
+	})
+}
+
+// ListTransactions возвращает единую историю списаний и переводов пользователя.
+func (handler *Handler) ListTransactions(ctx *gin.Context) {
+	userID, _ := authctx.UserID(ctx.Request.Context())
+	items, err := handler.usecase.ListTransactions(ctx, userID)
+	if err != nil {
+		status, code := common.MapError(err)
+		common.WriteError(ctx, status, code)
+		return
+	}
+	if len(items) == 0 {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+
+	result := make([]model.TransactionResponseItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, model.TransactionResponseItem{
+			Type:         string(item.Type),
+			Order:        item.Order,
+			Counterparty: item.Counterparty,
+			Sum:          item.Sum,
+			ProcessedAt:  common.RFC3339Time{Time: item.ProcessedAt},
+		})
+	}
+	ctx.JSON(http.StatusOK, result)
+}
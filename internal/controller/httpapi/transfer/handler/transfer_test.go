@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"loyalty/internal/controller/httpapi/auth/authctx"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	common "loyalty/internal/controller/httpapi/common/model"
+	"loyalty/internal/controller/httpapi/transfer/model"
+	transfermodel "loyalty/internal/domain/transfer/model"
+	transferusecase "loyalty/internal/domain/transfer/usecase"
+	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
+)
+
+type mockTransferUsecase struct {
+	transferFn func(ctx context.Context, senderID int64, login string, sum decimal.Decimal) (transfermodel.Transfer, error)
+	listFn     func(ctx context.Context, userID int64) ([]transfermodel.Transaction, error)
+}
+
+func (m *mockTransferUsecase) Transfer(ctx context.Context, senderID int64, login string, sum decimal.Decimal) (transfermodel.Transfer, error) {
+	return m.transferFn(ctx, senderID, login, sum)
+}
+func (m *mockTransferUsecase) ListTransactions(ctx context.Context, userID int64) ([]transfermodel.Transaction, error) {
+	return m.listFn(ctx, userID)
+}
+
+var _ transferusecase.TransferUsecase = (*mockTransferUsecase)(nil)
+
+func serve(t *testing.T, h *Handler, method, path string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.POST("/api/user/balance/transfer", h.Transfer)
+	r.GET("/api/user/transactions", h.ListTransactions)
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(authctx.WithUserID(req.Context(), 1))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_Transfer_200(t *testing.T) {
+	h := NewHandler(&mockTransferUsecase{
+		transferFn: func(_ context.Context, senderID int64, login string, sum decimal.Decimal) (transfermodel.Transfer, error) {
+			if senderID != 1 || login != "bob" || !sum.Equal(decimal.NewFromInt(25)) {
+				t.Fatalf("unexpected args: %d %q %s", senderID, login, sum)
+			}
+			return transfermodel.Transfer{ID: 5, RecipientLogin: login, Sum: sum, CreatedAt: time.Now()}, nil
+		},
+	})
+
+	w := serve(t, h, http.MethodPost, "/api/user/balance/transfer", []byte(`{"login":"bob","sum":25}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp model.TransferResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.ID != 5 || resp.Recipient != "bob" {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+}
+
+func TestHandler_Transfer_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"insufficient funds", withdrawalsmodel.ErrInsufficientFunds, http.StatusPaymentRequired, common.CodeInsufficientFunds},
+		{"recipient not found", transfermodel.ErrRecipientNotFound, http.StatusNotFound, common.CodeNotFound},
+		{"self transfer", transfermodel.ErrSelfTransfer, http.StatusBadRequest, common.CodeInvalidInput},
+		{"invalid sum", transfermodel.ErrInvalidTransferSum, http.StatusBadRequest, common.CodeInvalidInput},
+		{"daily limit", transfermodel.ErrDailyLimitExceeded, http.StatusUnprocessableEntity, common.CodeTransferLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&mockTransferUsecase{
+				transferFn: func(context.Context, int64, string, decimal.Decimal) (transfermodel.Transfer, error) {
+					return transfermodel.Transfer{}, tt.err
+				},
+			})
+			w := serve(t, h, http.MethodPost, "/api/user/balance/transfer", []byte(`{"login":"bob","sum":25}`))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("want %d, got %d", tt.wantStatus, w.Code)
+			}
+			var resp common.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Error != tt.wantCode {
+				t.Fatalf("want code %q, got %q", tt.wantCode, resp.Error)
+			}
+		})
+	}
+}
+
+func TestHandler_Transfer_400OnInvalidJSON(t *testing.T) {
+	h := NewHandler(&mockTransferUsecase{})
+
+	w := serve(t, h, http.MethodPost, "/api/user/balance/transfer", []byte(`{`))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandler_ListTransactions(t *testing.T) {
+	h := NewHandler(&mockTransferUsecase{
+		listFn: func(context.Context, int64) ([]transfermodel.Transaction, error) {
+			return []transfermodel.Transaction{
+				{Type: transfermodel.TransactionTransferIn, Counterparty: "alice", Sum: decimal.NewFromInt(10), ProcessedAt: time.Now()},
+				{Type: transfermodel.TransactionWithdrawal, Order: "79927398713", Sum: decimal.NewFromInt(5), ProcessedAt: time.Now()},
+			}, nil
+		},
+	})
+
+	w := serve(t, h, http.MethodGet, "/api/user/transactions", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp []model.TransactionResponseItem
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp) != 2 || resp[0].Type != "TRANSFER_IN" || resp[1].Order != "79927398713" {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+}
+
+func TestHandler_ListTransactions_204WhenEmpty(t *testing.T) {
+	h := NewHandler(&mockTransferUsecase{
+		listFn: func(context.Context, int64) ([]transfermodel.Transaction, error) { return nil, nil },
+	})
+
+	w := serve(t, h, http.MethodGet, "/api/user/transactions", nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("want %d, got %d", http.StatusNoContent, w.Code)
+	}
+}
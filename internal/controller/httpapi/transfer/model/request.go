@@ -0,0 +1,9 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// TransferRequest — тело запроса на перевод баллов другому пользователю.
+type TransferRequest struct {
+	Login string          `json:"login"`
+	Sum   decimal.Decimal `json:"sum"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	common "loyalty/internal/controller/httpapi/common/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// TransferResponse — ответ о совершённом переводе.
+type TransferResponse struct {
+	ID          int64              `json:"id"`
+	Recipient   string             `json:"recipient"`
+	Sum         decimal.Decimal    `json:"sum"`
+	ProcessedAt common.RFC3339Time `json:"processed_at"`
+}
+
+// TransactionResponseItem — элемент единой истории списаний и переводов.
+type TransactionResponseItem struct {
+	Type         string             `json:"type"`
+	Order        string             `json:"order,omitempty"`
+	Counterparty string             `json:"counterparty,omitempty"`
+	Sum          decimal.Decimal    `json:"sum"`
+	ProcessedAt  common.RFC3339Time `json:"processed_at"`
+}
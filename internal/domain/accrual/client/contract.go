@@ -5,8 +5,17 @@ import (
 	"loyalty/internal/domain/accrual/model"
 )
 
+// DefaultProvider — имя основной системы accrual (ACCRUAL_SYSTEM_ADDRESS) в метриках и логах.
+const DefaultProvider = "default"
+
 // AccrualClient представляет клиент для взаимодействия с системой расчёта начислений.
 type AccrualClient interface {
 	// GetOrderAccrual получает информацию о начислении для заказа.
 	GetOrderAccrual(ctx context.Context, orderNumber string) (*model.Accrual, error)
 }
+
+// ProviderResolver — необязательный интерфейс клиента, который распределяет заказы между несколькими
+// системами accrual: возвращает имя провайдера, который считает заказ.
+type ProviderResolver interface {
+	Provider(orderNumber string) string
+}
@@ -1,6 +1,11 @@
 package model
 
-import "github.com/shopspring/decimal"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
 
 // AccrualStatus представляет статус расчёта начисления в системе accrual.
 type AccrualStatus string
@@ -21,4 +26,22 @@ type Accrual struct {
 	Order   string           `json:"order"`
 	Status  AccrualStatus    `json:"status"`
 	Accrual *decimal.Decimal `json:"accrual,omitempty"`
+	// Raw — исходное тело ответа (для истории статусов заказа); пустое, если ответ собран не из JSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Validate проверяет, что результат расчёта можно применить к заказу.
+func (accrual Accrual) Validate() error {
+	if accrual.Order == "" {
+		return fmt.Errorf("%w: empty order number", ErrInvalidPayload)
+	}
+	switch accrual.Status {
+	case StatusRegistered, StatusInvalid, StatusProcessing, StatusProcessed:
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidPayload, accrual.Status)
+	}
+	if accrual.Accrual != nil && accrual.Accrual.IsNegative() {
+		return fmt.Errorf("%w: negative accrual", ErrInvalidPayload)
+	}
+	return nil
 }
@@ -2,6 +2,10 @@ package model
 
 import "errors"
 
+// ErrInvalidPayload возвращается для результата расчёта без номера заказа, с неизвестным статусом
+// или отрицательным начислением (например, в обратном вызове accrual).
+var ErrInvalidPayload = errors.New("invalid accrual payload")
+
 // ErrTooManyRequests возвращается при превышении rate limit (429 Too Many Requests).
 var ErrTooManyRequests = errors.New("accrual system rate limit exceeded")
 
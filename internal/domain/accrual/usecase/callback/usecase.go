@@ -0,0 +1,68 @@
+package callback
+
+import (
+	"context"
+
+	"loyalty/internal/domain/accrual/model"
+	ordersmodel "loyalty/internal/domain/order/model"
+	orderssvc "loyalty/internal/domain/order/service"
+	tiersvc "loyalty/internal/domain/tier/service"
+	"loyalty/internal/tracing"
+
+	"github.com/rs/zerolog"
+)
+
+// Usecase — реализация usecase.AccrualCallbackUsecase: результаты проходят тот же
+// OrdersService.UpdateFromAccrual, что и результаты опроса воркером.
+type Usecase struct {
+	ordersService orderssvc.OrdersService
+	tierService   tiersvc.TierService
+}
+
+// NewUsecase создаёт usecase обратных вызовов accrual. tierService может быть nil —
+// тогда уровень пользователя после начисления не пересчитывается.
+func NewUsecase(ordersService orderssvc.OrdersService, tierService tiersvc.TierService) *Usecase {
+	return &Usecase{ordersService: ordersService, tierService: tierService}
+}
+
+// ApplyResults применяет результаты по порядку; ошибка одного результата не мешает остальным.
+func (usecase *Usecase) ApplyResults(ctx context.Context, results []model.Accrual) []error {
+	errs := make([]error, len(results))
+	for i, result := range results {
+		errs[i] = usecase.apply(ctx, result)
+	}
+	return errs
+}
+
+func (usecase *Usecase) apply(ctx context.Context, result model.Accrual) (err error) {
+	ctx, span := tracing.Start(ctx, "AccrualCallbackUsecase.Apply", tracing.OrderNumber(result.Order))
+	defer func() { tracing.End(span, err) }()
+
+	if err := result.Validate(); err != nil {
+		return err
+	}
+	order, err := usecase.ordersService.GetOrder(ctx, result.Order)
+	if err != nil {
+		return err
+	}
+	if err := usecase.ordersService.UpdateFromAccrual(ctx, result.Order, ordersmodel.SourceWebhook, result); err != nil {
+		return err
+	}
+	// Повторный результат по уже зачисленному заказу ничего не изменил — уровень не пересчитываем
+	if result.Status == model.StatusProcessed && order.Status != ordersmodel.StatusProcessed {
+		usecase.recalculateTier(ctx, order)
+	}
+	return nil
+}
+
+// recalculateTier пересчитывает уровень владельца заказа так же, как воркер после начисления;
+// ошибка только логируется — начисление уже применено.
+func (usecase *Usecase) recalculateTier(ctx context.Context, order ordersmodel.Order) {
+	if usecase.tierService == nil {
+		return
+	}
+
+	if _, err := usecase.tierService.Recalculate(ctx, order.UserID); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("order", order.Number).Msg("failed to recalculate user tier")
+	}
+}
@@ -0,0 +1,174 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"loyalty/internal/adapter/memory"
+	"loyalty/internal/domain/accrual/model"
+	ordersmodel "loyalty/internal/domain/order/model"
+	"loyalty/internal/domain/order/service/orders"
+	"loyalty/internal/domain/order/service/validator"
+	tiermodel "loyalty/internal/domain/tier/model"
+)
+
+type update struct {
+	number   string
+	source   ordersmodel.Source
+	response model.Accrual
+}
+
+type mockOrdersService struct {
+	orders    map[string]ordersmodel.Order
+	updateErr map[string]error
+	updates   []update
+}
+
+func (m *mockOrdersService) ValidateNumber(number string) (string, error) {
+	return number, nil
+}
+
+func (m *mockOrdersService) UploadOrder(context.Context, int64, string) error {
+	return nil
+}
+
+func (m *mockOrdersService) LoadOrders(context.Context, int64) ([]ordersmodel.Order, error) {
+	return nil, nil
+}
+
+func (m *mockOrdersService) GetOrder(_ context.Context, number string) (ordersmodel.Order, error) {
+	order, ok := m.orders[number]
+	if !ok {
+		return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (m *mockOrdersService) LoadHistory(context.Context, int64, string) ([]ordersmodel.StatusChange, error) {
+	return nil, nil
+}
+
+func (m *mockOrdersService) UpdateFromAccrual(_ context.Context, number string, source ordersmodel.Source, response model.Accrual) error {
+	if err := m.updateErr[number]; err != nil {
+		return err
+	}
+	m.updates = append(m.updates, update{number: number, source: source, response: response})
+	return nil
+}
+
+func (m *mockOrdersService) RequeueOrder(context.Context, string) (ordersmodel.Order, error) {
+	return ordersmodel.Order{}, nil
+}
+
+func (m *mockOrdersService) GetAccrual(context.Context, string) (string, *decimal.Decimal, error) {
+	return "", nil, nil
+}
+
+type mockTierService struct {
+	recalculated []int64
+}
+
+func (m *mockTierService) Recalculate(_ context.Context, userID int64) (tiermodel.UserTier, error) {
+	m.recalculated = append(m.recalculated, userID)
+	return tiermodel.UserTier{UserID: userID, Level: tiermodel.LevelBase}, nil
+}
+
+func (m *mockTierService) GetProfile(context.Context, int64) (tiermodel.Profile, error) {
+	return tiermodel.Profile{}, nil
+}
+
+func TestApplyResults(t *testing.T) {
+	amount := decimal.NewFromInt(500)
+	orders := &mockOrdersService{
+		orders: map[string]ordersmodel.Order{
+			"1": {Number: "1", UserID: 7},
+			"2": {Number: "2", UserID: 8},
+		},
+		updateErr: map[string]error{"3": ordersmodel.ErrOrderNotFound},
+	}
+	tiers := &mockTierService{}
+	uc := NewUsecase(orders, tiers)
+
+	errs := uc.ApplyResults(context.Background(), []model.Accrual{
+		{Order: "1", Status: model.StatusProcessed, Accrual: &amount, Raw: []byte(`{"order":"1"}`)},
+		{Order: "2", Status: model.StatusProcessing},
+		{Order: "3", Status: model.StatusInvalid},
+		{Order: "4", Status: "DONE"},
+	})
+
+	if len(errs) != 4 {
+		t.Fatalf("want one error per result, got %d", len(errs))
+	}
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("want first two results applied, got %v, %v", errs[0], errs[1])
+	}
+	if !errors.Is(errs[2], ordersmodel.ErrOrderNotFound) {
+		t.Fatalf("want ErrOrderNotFound, got %v", errs[2])
+	}
+	if !errors.Is(errs[3], model.ErrInvalidPayload) {
+		t.Fatalf("want ErrInvalidPayload, got %v", errs[3])
+	}
+
+	if len(orders.updates) != 2 {
+		t.Fatalf("want 2 updates, got %+v", orders.updates)
+	}
+	for _, u := range orders.updates {
+		if u.source != ordersmodel.SourceWebhook {
+			t.Fatalf("want source %q, got %q", ordersmodel.SourceWebhook, u.source)
+		}
+	}
+	if string(orders.updates[0].response.Raw) != `{"order":"1"}` {
+		t.Fatalf("raw payload must be passed through, got %s", orders.updates[0].response.Raw)
+	}
+	if len(tiers.recalculated) != 1 || tiers.recalculated[0] != 7 {
+		t.Fatalf("want tier recalculated only for the processed order owner, got %v", tiers.recalculated)
+	}
+}
+
+func TestApplyResults_WithoutTierService(t *testing.T) {
+	orders := &mockOrdersService{orders: map[string]ordersmodel.Order{"1": {Number: "1", UserID: 7}}}
+	uc := NewUsecase(orders, nil)
+
+	errs := uc.ApplyResults(context.Background(), []model.Accrual{{Order: "1", Status: model.StatusProcessed}})
+	if errs[0] != nil {
+		t.Fatalf("unexpected error: %v", errs[0])
+	}
+}
+
+func TestApplyResults_DuplicateProcessedResult(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewOrdersRepository(memory.NewStore())
+	if err := repo.Create(ctx, 7, "1"); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	tiers := &mockTierService{}
+	uc := NewUsecase(orders.NewService(repo, validator.NewValidator(), nil, nil), tiers)
+
+	first, same, other := decimal.NewFromInt(500), decimal.RequireFromString("500.00"), decimal.NewFromInt(900)
+	errs := uc.ApplyResults(ctx, []model.Accrual{
+		{Order: "1", Status: model.StatusProcessed, Accrual: &first},
+		{Order: "1", Status: model.StatusProcessed, Accrual: &same},
+		{Order: "1", Status: model.StatusProcessed, Accrual: &other},
+	})
+
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("want first result applied and its duplicate ignored, got %v, %v", errs[0], errs[1])
+	}
+	if !errors.Is(errs[2], ordersmodel.ErrAccrualConflict) {
+		t.Fatalf("want ErrAccrualConflict for a different amount, got %v", errs[2])
+	}
+	order, err := repo.Get(ctx, "1")
+	if err != nil || order.Accrual == nil || !order.Accrual.Equal(first) {
+		t.Fatalf("stored accrual must stay %s, got %+v, %v", first, order, err)
+	}
+	history, err := repo.ListHistory(ctx, "1")
+	if err != nil || len(history) != 2 {
+		t.Fatalf("duplicates must not add history records, got %+v, %v", history, err)
+	}
+	if len(tiers.recalculated) != 1 {
+		t.Fatalf("want tier recalculated once, got %v", tiers.recalculated)
+	}
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"loyalty/internal/domain/accrual/model"
+)
+
+// AccrualCallbackUsecase применяет результаты расчёта, присланные системой начислений обратным вызовом.
+type AccrualCallbackUsecase interface {
+	// ApplyResults применяет результаты по порядку и возвращает ошибку для каждого из них
+	// (nil — результат применён).
+	ApplyResults(ctx context.Context, results []model.Accrual) []error
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidAmount возвращается, если сумма корректировки равна нулю.
+	ErrInvalidAmount = errors.New("invalid adjustment amount")
+	// ErrReasonRequired возвращается, если не указана причина корректировки.
+	ErrReasonRequired = errors.New("adjustment reason is required")
+)
+
+// Adjustment — ручная корректировка баланса пользователя (начисление или списание).
+type Adjustment struct {
+	ID     int64
+	UserID int64
+	// Amount — знаковая сумма: положительная начисляет баллы, отрицательная списывает.
+	Amount decimal.Decimal
+	Reason string
+	// Actor — кто выполнил корректировку (оператор поддержки).
+	Actor     string
+	CreatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"loyalty/internal/domain/adjustment/model"
+)
+
+// AdjustmentRepository — порт хранилища ручных корректировок баланса.
+type AdjustmentRepository interface {
+	// Apply атомарно меняет текущий баланс пользователя на adjustment.Amount и записывает корректировку.
+	// Если списание уводит баланс в минус, возвращает withdrawalsmodel.ErrInsufficientFunds.
+	Apply(ctx context.Context, adjustment model.Adjustment) (model.Adjustment, error)
+}
@@ -0,0 +1,50 @@
+package adjustment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"loyalty/internal/domain/adjustment/model"
+	adjustmentrepo "loyalty/internal/domain/adjustment/repository"
+	adjustmentsvc "loyalty/internal/domain/adjustment/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// Service — реализация adjustmentsvc.AdjustmentService.
+type Service struct {
+	repo adjustmentrepo.AdjustmentRepository
+	now  func() time.Time
+}
+
+// NewService создаёт прикладной сервис ручных корректировок баланса.
+func NewService(repo adjustmentrepo.AdjustmentRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Adjust валидирует сумму и причину и применяет корректировку.
+func (service *Service) Adjust(
+	ctx context.Context,
+	userID int64,
+	amount decimal.Decimal,
+	reason, actor string,
+) (model.Adjustment, error) {
+	if amount.IsZero() {
+		return model.Adjustment{}, model.ErrInvalidAmount
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return model.Adjustment{}, model.ErrReasonRequired
+	}
+
+	return service.repo.Apply(ctx, model.Adjustment{
+		UserID:    userID,
+		Amount:    amount,
+		Reason:    reason,
+		Actor:     strings.TrimSpace(actor),
+		CreatedAt: service.now().UTC(),
+	})
+}
+
+var _ adjustmentsvc.AdjustmentService = (*Service)(nil)
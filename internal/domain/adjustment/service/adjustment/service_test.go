@@ -0,0 +1,59 @@
+package adjustment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/adjustment/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockRepo struct {
+	called     bool
+	adjustment model.Adjustment
+}
+
+func (m *mockRepo) Apply(_ context.Context, adjustment model.Adjustment) (model.Adjustment, error) {
+	m.called = true
+	m.adjustment = adjustment
+	adjustment.ID = 1
+	return adjustment, nil
+}
+
+func TestService_Adjust_Validation(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo)
+
+	if _, err := svc.Adjust(context.Background(), 1, decimal.Zero, "goodwill", "alice"); !errors.Is(err, model.ErrInvalidAmount) {
+		t.Fatalf("want ErrInvalidAmount, got %v", err)
+	}
+	if _, err := svc.Adjust(context.Background(), 1, decimal.NewFromInt(10), "  ", "alice"); !errors.Is(err, model.ErrReasonRequired) {
+		t.Fatalf("want ErrReasonRequired, got %v", err)
+	}
+	if repo.called {
+		t.Fatalf("did not expect repo.Apply to be called")
+	}
+}
+
+func TestService_Adjust_PassesTrimmedFields(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo)
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	adjustment, err := svc.Adjust(context.Background(), 7, decimal.NewFromInt(-25), " duplicate accrual ", " alice ")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := model.Adjustment{UserID: 7, Amount: decimal.NewFromInt(-25), Reason: "duplicate accrual", Actor: "alice", CreatedAt: now}
+	if repo.adjustment.UserID != want.UserID || !repo.adjustment.Amount.Equal(want.Amount) ||
+		repo.adjustment.Reason != want.Reason || repo.adjustment.Actor != want.Actor || !repo.adjustment.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected adjustment: %+v", repo.adjustment)
+	}
+	if adjustment.ID != 1 {
+		t.Fatalf("expected stored adjustment to be returned, got %+v", adjustment)
+	}
+}
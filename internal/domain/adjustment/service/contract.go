@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"loyalty/internal/domain/adjustment/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// AdjustmentService содержит прикладную логику ручных корректировок баланса.
+type AdjustmentService interface {
+	// Adjust начисляет (amount > 0) или списывает (amount < 0) баллы пользователю с обязательной причиной.
+	Adjust(ctx context.Context, userID int64, amount decimal.Decimal, reason, actor string) (model.Adjustment, error)
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"errors"
+	"strings"
+
+	balancemodel "loyalty/internal/domain/balance/model"
+	ordermodel "loyalty/internal/domain/order/model"
+	statementmodel "loyalty/internal/domain/statement/model"
+	withdrawalmodel "loyalty/internal/domain/withdrawal/model"
+)
+
+// ErrInvalidUserRef возвращается, если пользователь не задан или задан одновременно по id и логину.
+var ErrInvalidUserRef = errors.New("user must be referenced by either id or login")
+
+// UserRef — ссылка на пользователя: по id или по логину (ровно одно из полей).
+type UserRef struct {
+	ID    int64
+	Login string
+}
+
+// Validate проверяет, что задано ровно одно из полей.
+func (ref UserRef) Validate() error {
+	byID, byLogin := ref.ID != 0, strings.TrimSpace(ref.Login) != ""
+	if byID == byLogin || ref.ID < 0 {
+		return ErrInvalidUserRef
+	}
+	return nil
+}
+
+// UserSummary — пользователь и состояние его счёта.
+type UserSummary struct {
+	ID      int64
+	Login   string
+	Balance balancemodel.Balance
+}
+
+// UserDump — все данные пользователя для разбора обращений в поддержку.
+type UserDump struct {
+	User        UserSummary
+	Orders      []ordermodel.Order
+	Withdrawals []withdrawalmodel.Withdrawal
+	// Statement — выписка за всю историю (без пагинации).
+	Statement statementmodel.Statement
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUserRef_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  UserRef
+		ok   bool
+	}{
+		{name: "by id", ref: UserRef{ID: 7}, ok: true},
+		{name: "by login", ref: UserRef{Login: "alice"}, ok: true},
+		{name: "empty", ref: UserRef{}},
+		{name: "blank login", ref: UserRef{Login: "  "}},
+		{name: "both", ref: UserRef{ID: 7, Login: "alice"}},
+		{name: "negative id", ref: UserRef{ID: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ref.Validate()
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidUserRef) {
+				t.Fatalf("want ErrInvalidUserRef, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"context"
+
+	adjustmentmodel "loyalty/internal/domain/adjustment/model"
+	adjustmentsvc "loyalty/internal/domain/adjustment/service"
+	"loyalty/internal/domain/admin/model"
+	"loyalty/internal/domain/admin/usecase"
+	authmodel "loyalty/internal/domain/auth/model"
+	authsvc "loyalty/internal/domain/auth/service"
+	balancesvc "loyalty/internal/domain/balance/service"
+	ordermodel "loyalty/internal/domain/order/model"
+	ordersvc "loyalty/internal/domain/order/service"
+	statementmodel "loyalty/internal/domain/statement/model"
+	statementsvc "loyalty/internal/domain/statement/service"
+	withdrawalmodel "loyalty/internal/domain/withdrawal/model"
+	withdrawalsvc "loyalty/internal/domain/withdrawal/service"
+	"loyalty/internal/tracing"
+
+	"github.com/shopspring/decimal"
+)
+
+// Usecase — реализация usecase.AdminUsecase поверх доменных сервисов.
+type Usecase struct {
+	userService        authsvc.UserService
+	balanceService     balancesvc.BalanceService
+	ordersService      ordersvc.OrdersService
+	withdrawalsService withdrawalsvc.WithdrawalsService
+	adjustmentService  adjustmentsvc.AdjustmentService
+	statementService   statementsvc.StatementService
+}
+
+// NewUsecase создаёт usecase поддержки.
+func NewUsecase(
+	userService authsvc.UserService,
+	balanceService balancesvc.BalanceService,
+	ordersService ordersvc.OrdersService,
+	withdrawalsService withdrawalsvc.WithdrawalsService,
+	adjustmentService adjustmentsvc.AdjustmentService,
+	statementService statementsvc.StatementService,
+) *Usecase {
+	return &Usecase{
+		userService:        userService,
+		balanceService:     balanceService,
+		ordersService:      ordersService,
+		withdrawalsService: withdrawalsService,
+		adjustmentService:  adjustmentService,
+		statementService:   statementService,
+	}
+}
+
+// FindUser возвращает пользователя и его баланс.
+func (usecase *Usecase) FindUser(ctx context.Context, ref model.UserRef) (_ model.UserSummary, err error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.FindUser", tracing.UserID(ref.ID))
+	defer func() { tracing.End(span, err) }()
+
+	user, err := usecase.resolve(ctx, ref)
+	if err != nil {
+		return model.UserSummary{}, err
+	}
+	balance, err := usecase.balanceService.GetBalance(ctx, user.ID)
+	if err != nil {
+		return model.UserSummary{}, err
+	}
+	return model.UserSummary{ID: user.ID, Login: user.Login, Balance: balance}, nil
+}
+
+// ListOrders возвращает заказы пользователя.
+func (usecase *Usecase) ListOrders(ctx context.Context, ref model.UserRef) (_ []ordermodel.Order, err error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.ListOrders", tracing.UserID(ref.ID))
+	defer func() { tracing.End(span, err) }()
+
+	user, err := usecase.resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.ordersService.LoadOrders(ctx, user.ID)
+}
+
+// ListWithdrawals возвращает списания пользователя (от новых к старым).
+func (usecase *Usecase) ListWithdrawals(ctx context.Context, ref model.UserRef) (_ []withdrawalmodel.Withdrawal, err error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.ListWithdrawals", tracing.UserID(ref.ID))
+	defer func() { tracing.End(span, err) }()
+
+	user, err := usecase.resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.withdrawalsService.ListWithdrawals(ctx, user.ID)
+}
+
+// RequeueOrder возвращает заказ в очередь воркера начислений.
+func (usecase *Usecase) RequeueOrder(ctx context.Context, orderNumber string) (_ ordermodel.Order, err error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.RequeueOrder", tracing.OrderNumber(orderNumber))
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.ordersService.RequeueOrder(ctx, orderNumber)
+}
+
+// AdjustBalance применяет ручную корректировку баланса пользователя.
+func (usecase *Usecase) AdjustBalance(
+	ctx context.Context,
+	ref model.UserRef,
+	amount decimal.Decimal,
+	reason, actor string,
+) (_ adjustmentmodel.Adjustment, err error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.AdjustBalance", tracing.UserID(ref.ID))
+	defer func() { tracing.End(span, err) }()
+
+	user, err := usecase.resolve(ctx, ref)
+	if err != nil {
+		return adjustmentmodel.Adjustment{}, err
+	}
+	return usecase.adjustmentService.Adjust(ctx, user.ID, amount, reason, actor)
+}
+
+// DumpUser собирает все данные пользователя: профиль, баланс, заказы, списания и выписку за всю историю.
+func (usecase *Usecase) DumpUser(ctx context.Context, ref model.UserRef) (_ model.UserDump, err error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.DumpUser", tracing.UserID(ref.ID))
+	defer func() { tracing.End(span, err) }()
+
+	summary, err := usecase.FindUser(ctx, ref)
+	if err != nil {
+		return model.UserDump{}, err
+	}
+	dump := model.UserDump{User: summary}
+	if dump.Orders, err = usecase.ordersService.LoadOrders(ctx, summary.ID); err != nil {
+		return model.UserDump{}, err
+	}
+	if dump.Withdrawals, err = usecase.withdrawalsService.ListWithdrawals(ctx, summary.ID); err != nil {
+		return model.UserDump{}, err
+	}
+	if dump.Statement, err = usecase.statementService.GetStatement(ctx, statementmodel.Query{UserID: summary.ID, Page: 1}); err != nil {
+		return model.UserDump{}, err
+	}
+	return dump, nil
+}
+
+// resolve находит пользователя по id или логину.
+func (usecase *Usecase) resolve(ctx context.Context, ref model.UserRef) (authmodel.User, error) {
+	if err := ref.Validate(); err != nil {
+		return authmodel.User{}, err
+	}
+	if ref.ID != 0 {
+		return usecase.userService.FindUserByID(ctx, ref.ID)
+	}
+	return usecase.userService.FindUserByLogin(ctx, ref.Login)
+}
+
+var _ usecase.AdminUsecase = (*Usecase)(nil)
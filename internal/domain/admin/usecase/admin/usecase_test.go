@@ -0,0 +1,181 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	accrualmodel "loyalty/internal/domain/accrual/model"
+	adjustmentmodel "loyalty/internal/domain/adjustment/model"
+	"loyalty/internal/domain/admin/model"
+	authmodel "loyalty/internal/domain/auth/model"
+	balancemodel "loyalty/internal/domain/balance/model"
+	ordermodel "loyalty/internal/domain/order/model"
+	statementmodel "loyalty/internal/domain/statement/model"
+	withdrawalmodel "loyalty/internal/domain/withdrawal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockUserService struct {
+	user authmodel.User
+}
+
+func (m *mockUserService) CreateUser(context.Context, string, []byte, int64) (authmodel.User, error) {
+	panic("not used")
+}
+
+func (m *mockUserService) FindUserByLogin(_ context.Context, login string) (authmodel.User, error) {
+	if login != m.user.Login {
+		return authmodel.User{}, authmodel.ErrNotFound
+	}
+	return m.user, nil
+}
+
+func (m *mockUserService) FindUserByID(_ context.Context, id int64) (authmodel.User, error) {
+	if id != m.user.ID {
+		return authmodel.User{}, authmodel.ErrNotFound
+	}
+	return m.user, nil
+}
+
+type mockBalanceService struct{}
+
+func (mockBalanceService) GetBalance(context.Context, int64) (balancemodel.Balance, error) {
+	return balancemodel.Balance{Current: decimal.NewFromInt(100), Withdrawn: decimal.NewFromInt(20)}, nil
+}
+
+type mockOrdersService struct {
+	requeued string
+}
+
+func (m *mockOrdersService) UploadOrder(context.Context, int64, string) error { return nil }
+
+func (m *mockOrdersService) LoadOrders(_ context.Context, userID int64) ([]ordermodel.Order, error) {
+	return []ordermodel.Order{{Number: "79927398713", UserID: userID, Status: ordermodel.StatusProcessed}}, nil
+}
+
+func (m *mockOrdersService) GetOrder(context.Context, string) (ordermodel.Order, error) {
+	return ordermodel.Order{}, ordermodel.ErrOrderNotFound
+}
+
+func (m *mockOrdersService) LoadHistory(context.Context, int64, string) ([]ordermodel.StatusChange, error) {
+	return nil, nil
+}
+
+func (m *mockOrdersService) UpdateFromAccrual(context.Context, string, ordermodel.Source, accrualmodel.Accrual) error {
+	return nil
+}
+
+func (m *mockOrdersService) RequeueOrder(_ context.Context, number string) (ordermodel.Order, error) {
+	m.requeued = number
+	return ordermodel.Order{Number: number, Status: ordermodel.StatusNew}, nil
+}
+
+type mockWithdrawalsService struct{}
+
+func (mockWithdrawalsService) Withdraw(context.Context, int64, string, decimal.Decimal) error {
+	return nil
+}
+
+func (mockWithdrawalsService) ListWithdrawals(_ context.Context, userID int64) ([]withdrawalmodel.Withdrawal, error) {
+	return []withdrawalmodel.Withdrawal{{UserID: userID, OrderNumber: "2377225624", Sum: decimal.NewFromInt(20)}}, nil
+}
+
+type mockAdjustmentService struct {
+	userID int64
+}
+
+func (m *mockAdjustmentService) Adjust(
+	_ context.Context,
+	userID int64,
+	amount decimal.Decimal,
+	reason, actor string,
+) (adjustmentmodel.Adjustment, error) {
+	m.userID = userID
+	return adjustmentmodel.Adjustment{ID: 1, UserID: userID, Amount: amount, Reason: reason, Actor: actor}, nil
+}
+
+type mockStatementService struct {
+	query statementmodel.Query
+}
+
+func (m *mockStatementService) GetStatement(_ context.Context, query statementmodel.Query) (statementmodel.Statement, error) {
+	m.query = query
+	return statementmodel.Statement{Query: query}, nil
+}
+
+func newTestUsecase() (*Usecase, *mockOrdersService, *mockAdjustmentService, *mockStatementService) {
+	orders := &mockOrdersService{}
+	adjustments := &mockAdjustmentService{}
+	statements := &mockStatementService{}
+	uc := NewUsecase(
+		&mockUserService{user: authmodel.User{ID: 7, Login: "alice"}},
+		mockBalanceService{},
+		orders,
+		mockWithdrawalsService{},
+		adjustments,
+		statements,
+	)
+	return uc, orders, adjustments, statements
+}
+
+func TestUsecase_FindUser(t *testing.T) {
+	uc, _, _, _ := newTestUsecase()
+
+	for _, ref := range []model.UserRef{{ID: 7}, {Login: "alice"}} {
+		summary, err := uc.FindUser(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("unexpected err for %+v: %v", ref, err)
+		}
+		if summary.ID != 7 || summary.Login != "alice" || !summary.Balance.Current.Equal(decimal.NewFromInt(100)) {
+			t.Fatalf("unexpected summary: %+v", summary)
+		}
+	}
+
+	if _, err := uc.FindUser(context.Background(), model.UserRef{Login: "bob"}); !errors.Is(err, authmodel.ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+	if _, err := uc.FindUser(context.Background(), model.UserRef{}); !errors.Is(err, model.ErrInvalidUserRef) {
+		t.Fatalf("want ErrInvalidUserRef, got %v", err)
+	}
+}
+
+func TestUsecase_AdjustBalance_ResolvesUser(t *testing.T) {
+	uc, _, adjustments, _ := newTestUsecase()
+
+	adjustment, err := uc.AdjustBalance(context.Background(), model.UserRef{Login: "alice"}, decimal.NewFromInt(50), "goodwill", "support")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if adjustments.userID != 7 || adjustment.Reason != "goodwill" {
+		t.Fatalf("unexpected adjustment: %+v", adjustment)
+	}
+}
+
+func TestUsecase_RequeueOrder(t *testing.T) {
+	uc, orders, _, _ := newTestUsecase()
+
+	order, err := uc.RequeueOrder(context.Background(), "79927398713")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if orders.requeued != "79927398713" || order.Status != ordermodel.StatusNew {
+		t.Fatalf("unexpected requeue: %q %+v", orders.requeued, order)
+	}
+}
+
+func TestUsecase_DumpUser(t *testing.T) {
+	uc, _, _, statements := newTestUsecase()
+
+	dump, err := uc.DumpUser(context.Background(), model.UserRef{ID: 7})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if dump.User.Login != "alice" || len(dump.Orders) != 1 || len(dump.Withdrawals) != 1 {
+		t.Fatalf("unexpected dump: %+v", dump)
+	}
+	if statements.query.UserID != 7 || statements.query.Page != 1 || statements.query.PageSize != 0 {
+		t.Fatalf("expected full-history statement query, got %+v", statements.query)
+	}
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	adjustmentmodel "loyalty/internal/domain/adjustment/model"
+	"loyalty/internal/domain/admin/model"
+	ordermodel "loyalty/internal/domain/order/model"
+	withdrawalmodel "loyalty/internal/domain/withdrawal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// AdminUsecase описывает сценарии поддержки: поиск пользователя, просмотр его операций,
+// повторная обработка заказа и ручные корректировки баланса.
+type AdminUsecase interface {
+	// FindUser возвращает пользователя и его баланс.
+	FindUser(ctx context.Context, ref model.UserRef) (model.UserSummary, error)
+
+	// ListOrders возвращает заказы пользователя.
+	ListOrders(ctx context.Context, ref model.UserRef) ([]ordermodel.Order, error)
+
+	// ListWithdrawals возвращает списания пользователя (от новых к старым).
+	ListWithdrawals(ctx context.Context, ref model.UserRef) ([]withdrawalmodel.Withdrawal, error)
+
+	// RequeueOrder возвращает заказ в очередь воркера начислений.
+	RequeueOrder(ctx context.Context, orderNumber string) (ordermodel.Order, error)
+
+	// AdjustBalance начисляет (amount > 0) или списывает (amount < 0) баллы с обязательной причиной.
+	AdjustBalance(ctx context.Context, ref model.UserRef, amount decimal.Decimal, reason, actor string) (adjustmentmodel.Adjustment, error)
+
+	// DumpUser собирает все данные пользователя: профиль, баланс, заказы, списания и выписку.
+	DumpUser(ctx context.Context, ref model.UserRef) (model.UserDump, error)
+}
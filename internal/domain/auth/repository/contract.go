@@ -5,8 +5,11 @@ import (
 	"loyalty/internal/domain/auth/model"
 )
 
-// UserRepository — конракт репозитория пользователей (создание и поиск по логину).
+// UserRepository — конракт репозитория пользователей (создание и поиск по логину или ID).
 type UserRepository interface {
-	Create(ctx context.Context, login string, passwordHash []byte) (model.User, error)
+	// Create создаёт пользователя и его счёт; referrerID (0 — без приглашения) привязывается
+	// как пригласивший в той же транзакции.
+	Create(ctx context.Context, login string, passwordHash []byte, referrerID int64) (model.User, error)
 	FindByLogin(ctx context.Context, login string) (model.User, error)
+	FindByID(ctx context.Context, id int64) (model.User, error)
 }
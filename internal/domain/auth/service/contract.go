@@ -23,6 +23,8 @@ type TokenService interface {
 
 // UserService инкапсулирует доступ к пользователям и их инварианты (например, нормализацию логина).
 type UserService interface {
-	CreateUser(ctx context.Context, login string, passwordHash []byte) (model.User, error)
+	// CreateUser создаёт пользователя; referrerID — пригласивший (0 — без приглашения).
+	CreateUser(ctx context.Context, login string, passwordHash []byte, referrerID int64) (model.User, error)
 	FindUserByLogin(ctx context.Context, login string) (model.User, error)
+	FindUserByID(ctx context.Context, id int64) (model.User, error)
 }
@@ -21,12 +21,12 @@ func NewUserService(repo repository.UserRepository) service.UserService {
 }
 
 // CreateUser нормализует логин, проверяет ограничения и создаёт пользователя в репозитории.
-func (service *userService) CreateUser(ctx context.Context, login string, passwordHash []byte) (model.User, error) {
+func (service *userService) CreateUser(ctx context.Context, login string, passwordHash []byte, referrerID int64) (model.User, error) {
 	normalized, err := normalizeLogin(login)
 	if err != nil {
 		return model.User{}, err
 	}
-	return service.repo.Create(ctx, normalized, passwordHash)
+	return service.repo.Create(ctx, normalized, passwordHash, referrerID)
 }
 
 // FindUserByLogin нормализует логин, проверяет ограничения и ищет пользователя в репозитории.
@@ -38,6 +38,14 @@ func (service *userService) FindUserByLogin(ctx context.Context, login string) (
 	return service.repo.FindByLogin(ctx, normalized)
 }
 
+// FindUserByID ищет пользователя по идентификатору.
+func (service *userService) FindUserByID(ctx context.Context, id int64) (model.User, error) {
+	if id <= 0 {
+		return model.User{}, model.ErrInvalidInput
+	}
+	return service.repo.FindByID(ctx, id)
+}
+
 func normalizeLogin(login string) (string, error) {
 	normalized := strings.TrimSpace(login)
 	if normalized == "" {
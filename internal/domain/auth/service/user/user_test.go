@@ -11,15 +11,20 @@ import (
 type mockRepo struct {
 	createFn func(ctx context.Context, login string, passwordHash []byte) (model.User, error)
 	findFn   func(ctx context.Context, login string) (model.User, error)
+	findIDFn func(ctx context.Context, id int64) (model.User, error)
 }
 
-func (m *mockRepo) Create(ctx context.Context, login string, passwordHash []byte) (model.User, error) {
+func (m *mockRepo) Create(ctx context.Context, login string, passwordHash []byte, _ int64) (model.User, error) {
 	return m.createFn(ctx, login, passwordHash)
 }
 func (m *mockRepo) FindByLogin(ctx context.Context, login string) (model.User, error) {
 	return m.findFn(ctx, login)
 }
 
+func (m *mockRepo) FindByID(ctx context.Context, id int64) (model.User, error) {
+	return m.findIDFn(ctx, id)
+}
+
 var _ repository.UserRepository = (*mockRepo)(nil)
 
 func TestUserService_Delegates(t *testing.T) {
@@ -38,6 +43,22 @@ func TestUserService_Delegates(t *testing.T) {
 		},
 	})
 
-	_, _ = svc.CreateUser(context.Background(), " alice ", []byte("h"))
+	_, _ = svc.CreateUser(context.Background(), " alice ", []byte("h"), 0)
 	_, _ = svc.FindUserByLogin(context.Background(), " alice ")
 }
+
+func TestUserService_FindUserByID(t *testing.T) {
+	svc := NewUserService(&mockRepo{
+		findIDFn: func(_ context.Context, id int64) (model.User, error) {
+			return model.User{ID: id, Login: "alice"}, nil
+		},
+	})
+
+	user, err := svc.FindUserByID(context.Background(), 7)
+	if err != nil || user.ID != 7 {
+		t.Fatalf("unexpected result: %+v, %v", user, err)
+	}
+	if _, err := svc.FindUserByID(context.Background(), 0); err != model.ErrInvalidInput {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
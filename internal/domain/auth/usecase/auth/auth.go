@@ -6,6 +6,8 @@ import (
 	"loyalty/internal/domain/auth/model"
 	"loyalty/internal/domain/auth/service"
 	uc "loyalty/internal/domain/auth/usecase"
+	referralsvc "loyalty/internal/domain/referral/service"
+	"loyalty/internal/tracing"
 	"time"
 )
 
@@ -14,24 +16,46 @@ type Usecase struct {
 	userService  service.UserService
 	authService  service.AuthService
 	tokenService service.TokenService
+	referrals    referralsvc.ReferralService
 }
 
 // NewUsecase создаёт usecase аутентификации с зависимостями на сервисы домена и токенов.
-func NewUsecase(userService service.UserService, authService service.AuthService, tokenService service.TokenService) *Usecase {
+// referrals может быть nil — тогда реферальный код при регистрации игнорируется.
+func NewUsecase(
+	userService service.UserService,
+	authService service.AuthService,
+	tokenService service.TokenService,
+	referrals referralsvc.ReferralService,
+) *Usecase {
 	return &Usecase{
 		userService:  userService,
 		authService:  authService,
 		tokenService: tokenService,
+		referrals:    referrals,
 	}
 }
 
 // Register регистрирует пользователя и возвращает access-token.
-func (usecase *Usecase) Register(ctx context.Context, login, password string) (token string, _ error) {
+// Реферальный код проверяется до создания пользователя: неизвестный код — ошибка регистрации;
+// приглашение сохраняется вместе с пользователем.
+func (usecase *Usecase) Register(ctx context.Context, login, password, referralCode string) (token string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.Register")
+	defer func() { tracing.End(span, err) }()
+
 	hash, err := usecase.authService.HashPassword(password)
 	if err != nil {
 		return "", err
 	}
-	user, err := usecase.userService.CreateUser(ctx, login, hash)
+
+	var referrerID int64
+	if referralCode != "" && usecase.referrals != nil {
+		referrerID, err = usecase.referrals.ResolveCode(ctx, referralCode)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	user, err := usecase.userService.CreateUser(ctx, login, hash, referrerID)
 	if err != nil {
 		return "", err
 	}
@@ -39,7 +63,10 @@ func (usecase *Usecase) Register(ctx context.Context, login, password string) (t
 }
 
 // Login аутентифицирует пользователя и возвращает access-token.
-func (usecase *Usecase) Login(ctx context.Context, login, password string) (token string, _ error) {
+func (usecase *Usecase) Login(ctx context.Context, login, password string) (token string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.Login")
+	defer func() { tracing.End(span, err) }()
+
 	user, err := usecase.userService.FindUserByLogin(ctx, login)
 	if err != nil {
 		return "", err
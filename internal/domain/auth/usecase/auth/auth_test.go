@@ -8,19 +8,25 @@ import (
 
 	"loyalty/internal/domain/auth/model"
 	"loyalty/internal/domain/auth/service"
+	referralmodel "loyalty/internal/domain/referral/model"
 )
 
 type mockUserService struct {
-	createFn func(ctx context.Context, login string, passwordHash []byte) (model.User, error)
-	findFn   func(ctx context.Context, login string) (model.User, error)
+	createFn   func(ctx context.Context, login string, passwordHash []byte) (model.User, error)
+	findFn     func(ctx context.Context, login string) (model.User, error)
+	referrerID int64
 }
 
-func (m *mockUserService) CreateUser(ctx context.Context, login string, passwordHash []byte) (model.User, error) {
+func (m *mockUserService) CreateUser(ctx context.Context, login string, passwordHash []byte, referrerID int64) (model.User, error) {
+	m.referrerID = referrerID
 	return m.createFn(ctx, login, passwordHash)
 }
 func (m *mockUserService) FindUserByLogin(ctx context.Context, login string) (model.User, error) {
 	return m.findFn(ctx, login)
 }
+func (m *mockUserService) FindUserByID(context.Context, int64) (model.User, error) {
+	panic("not used")
+}
 
 type mockAuthService struct {
 	hashPasswordFn    func(password string) ([]byte, error)
@@ -84,8 +90,8 @@ func TestUsecase_Register_HappyPath(t *testing.T) {
 		},
 	}
 
-	uc := NewUsecase(u, a, ts)
-	got, err := uc.Register(context.Background(), " alice ", "longenough10")
+	uc := NewUsecase(u, a, ts, nil)
+	got, err := uc.Register(context.Background(), " alice ", "longenough10", "")
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -114,7 +120,7 @@ func TestUsecase_Login_InvalidPasswordIsBadRequest(t *testing.T) {
 		},
 	}
 
-	uc := NewUsecase(u, a, &mockTokenService{issueFn: func(int64, string, time.Time) (string, error) { panic("not used") }})
+	uc := NewUsecase(u, a, &mockTokenService{issueFn: func(int64, string, time.Time) (string, error) { panic("not used") }}, nil)
 	_, err := uc.Login(context.Background(), "alice", "short")
 	if !errors.Is(err, model.ErrPasswordTooShort) {
 		t.Fatalf("expected ErrPasswordTooShort, got %v", err)
@@ -141,9 +147,77 @@ func TestUsecase_Login_WrongPasswordBecomesInvalidCreds(t *testing.T) {
 		},
 	}
 
-	uc := NewUsecase(u, a, &mockTokenService{issueFn: func(int64, string, time.Time) (string, error) { panic("not used") }})
+	uc := NewUsecase(u, a, &mockTokenService{issueFn: func(int64, string, time.Time) (string, error) { panic("not used") }}, nil)
 	_, err := uc.Login(context.Background(), "alice", "longenough11")
 	if !errors.Is(err, model.ErrInvalidCreds) {
 		t.Fatalf("expected ErrInvalidCreds, got %v", err)
 	}
 }
+
+type mockReferralService struct {
+	resolveFn func(ctx context.Context, code string) (int64, error)
+}
+
+func (m *mockReferralService) ResolveCode(ctx context.Context, code string) (int64, error) {
+	return m.resolveFn(ctx, code)
+}
+func (m *mockReferralService) Evaluate(context.Context, string) (*referralmodel.Reward, error) {
+	panic("not used")
+}
+func (m *mockReferralService) GetSummary(context.Context, int64) (referralmodel.Summary, error) {
+	panic("not used")
+}
+
+func TestUsecase_Register_WithReferralCodeAttachesReferrer(t *testing.T) {
+	t.Parallel()
+
+	u := &mockUserService{
+		createFn: func(_ context.Context, login string, _ []byte) (model.User, error) {
+			return model.User{ID: 9, Login: login}, nil
+		},
+		findFn: func(context.Context, string) (model.User, error) { panic("not used") },
+	}
+	a := &mockAuthService{
+		hashPasswordFn:    func(string) ([]byte, error) { return []byte("hash"), nil },
+		comparePasswordFn: func([]byte, string) error { panic("not used") },
+	}
+	ts := &mockTokenService{issueFn: func(int64, string, time.Time) (string, error) { return "token", nil }}
+	referrals := &mockReferralService{
+		resolveFn: func(_ context.Context, code string) (int64, error) {
+			if code != "ABC123" {
+				t.Fatalf("unexpected code %q", code)
+			}
+			return 3, nil
+		},
+	}
+
+	uc := NewUsecase(u, a, ts, referrals)
+	if _, err := uc.Register(context.Background(), "bob", "longenough10", "ABC123"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if u.referrerID != 3 {
+		t.Fatalf("want referrer 3 passed to CreateUser, got %d", u.referrerID)
+	}
+}
+
+func TestUsecase_Register_InvalidReferralCodeDoesNotCreateUser(t *testing.T) {
+	t.Parallel()
+
+	u := &mockUserService{
+		createFn: func(context.Context, string, []byte) (model.User, error) { panic("not used") },
+		findFn:   func(context.Context, string) (model.User, error) { panic("not used") },
+	}
+	a := &mockAuthService{
+		hashPasswordFn:    func(string) ([]byte, error) { return []byte("hash"), nil },
+		comparePasswordFn: func([]byte, string) error { panic("not used") },
+	}
+	referrals := &mockReferralService{
+		resolveFn: func(context.Context, string) (int64, error) { return 0, referralmodel.ErrInvalidReferralCode },
+	}
+
+	uc := NewUsecase(u, a, &mockTokenService{issueFn: func(int64, string, time.Time) (string, error) { panic("not used") }}, referrals)
+	_, err := uc.Register(context.Background(), "bob", "longenough10", "nope")
+	if !errors.Is(err, referralmodel.ErrInvalidReferralCode) {
+		t.Fatalf("expected ErrInvalidReferralCode, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import "context"
 
 // AuthUsecase описывает бизнес-сценарии аутентификации/регистрации пользователя.
 type AuthUsecase interface {
-	Register(ctx context.Context, login, password string) (token string, err error)
+	// Register регистрирует пользователя; referralCode необязателен (пустая строка — без приглашения).
+	Register(ctx context.Context, login, password, referralCode string) (token string, err error)
 	Login(ctx context.Context, login, password string) (token string, err error)
 }
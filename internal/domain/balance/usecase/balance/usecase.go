@@ -6,6 +6,7 @@ import (
 	"loyalty/internal/domain/balance/model"
 	balancesvc "loyalty/internal/domain/balance/service"
 	"loyalty/internal/domain/balance/usecase"
+	"loyalty/internal/tracing"
 )
 
 // Usecase — реализация usecase.BalanceUsecase.
@@ -19,7 +20,10 @@ func NewUsecase(balanceService balancesvc.BalanceService) *Usecase {
 }
 
 // GetBalance возвращает баланс пользователя.
-func (usecase *Usecase) GetBalance(ctx context.Context, userID int64) (model.Balance, error) {
+func (usecase *Usecase) GetBalance(ctx context.Context, userID int64) (_ model.Balance, err error) {
+	ctx, span := tracing.Start(ctx, "BalanceUsecase.GetBalance", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
 	return usecase.balanceService.GetBalance(ctx, userID)
 }
 
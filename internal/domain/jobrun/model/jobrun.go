@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidFilter возвращается при некорректных параметрах выборки запусков.
+var ErrInvalidFilter = errors.New("invalid job runs filter")
+
+// Status — состояние запуска фоновой задачи.
+type Status string
+
+const (
+	// StatusRunning — задача выполняется.
+	StatusRunning Status = "RUNNING"
+	// StatusSucceeded — задача завершилась без ошибки.
+	StatusSucceeded Status = "SUCCEEDED"
+	// StatusFailed — задача вернула ошибку.
+	StatusFailed Status = "FAILED"
+	// StatusTimedOut — задача не уложилась в свой таймаут.
+	StatusTimedOut Status = "TIMED_OUT"
+	// StatusCanceled — задача прервана остановкой сервиса.
+	StatusCanceled Status = "CANCELED"
+)
+
+// Run — запуск фоновой задачи планировщика.
+type Run struct {
+	ID        int64
+	Job       string
+	Status    Status
+	Error     string
+	StartedAt time.Time
+	// FinishedAt — nil, пока задача выполняется.
+	FinishedAt *time.Time
+}
+
+const (
+	// DefaultLimit — размер выборки запусков по умолчанию.
+	DefaultLimit = 50
+	// MaxLimit — максимальный размер выборки запусков.
+	MaxLimit = 1000
+)
+
+// Filter — параметры выборки запусков: последние Limit запусков, при непустом Job — только этой задачи.
+type Filter struct {
+	Job   string
+	Limit int
+}
+
+// Validate проверяет параметры выборки.
+func (filter Filter) Validate() error {
+	if filter.Limit < 1 || filter.Limit > MaxLimit {
+		return ErrInvalidFilter
+	}
+	return nil
+}
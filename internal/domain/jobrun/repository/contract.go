@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"loyalty/internal/domain/jobrun/model"
+)
+
+// JobRunRepository — порт журнала запусков фоновых задач.
+type JobRunRepository interface {
+	// Start записывает начало запуска задачи job в статусе RUNNING и возвращает его ID.
+	Start(ctx context.Context, job string, startedAt time.Time) (int64, error)
+	// Finish записывает итог запуска id.
+	Finish(ctx context.Context, id int64, finishedAt time.Time, status model.Status, errText string) error
+	// List возвращает запуски по фильтру, новые первыми.
+	List(ctx context.Context, filter model.Filter) ([]model.Run, error)
+	// DeleteBefore удаляет запуски, начатые раньше before, и возвращает их число.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"loyalty/internal/domain/jobrun/model"
+)
+
+// JobRunService содержит прикладную логику журнала запусков фоновых задач.
+type JobRunService interface {
+	// ListRuns возвращает последние запуски по фильтру.
+	ListRuns(ctx context.Context, filter model.Filter) ([]model.Run, error)
+	// Cleanup удаляет запуски старше retention и возвращает их число.
+	Cleanup(ctx context.Context, retention time.Duration) (int64, error)
+}
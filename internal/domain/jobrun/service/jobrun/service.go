@@ -0,0 +1,45 @@
+package jobrun
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"loyalty/internal/domain/jobrun/model"
+	jobrunrepo "loyalty/internal/domain/jobrun/repository"
+	jobrunsvc "loyalty/internal/domain/jobrun/service"
+)
+
+// Service — реализация jobrunsvc.JobRunService.
+type Service struct {
+	repo jobrunrepo.JobRunRepository
+	now  func() time.Time
+}
+
+// NewService создаёт сервис журнала запусков.
+func NewService(repo jobrunrepo.JobRunRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// ListRuns валидирует фильтр и возвращает запуски.
+func (service *Service) ListRuns(ctx context.Context, filter model.Filter) ([]model.Run, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	runs, err := service.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list job runs: %w", err)
+	}
+	return runs, nil
+}
+
+// Cleanup удаляет запуски, начатые раньше чем retention назад.
+func (service *Service) Cleanup(ctx context.Context, retention time.Duration) (int64, error) {
+	deleted, err := service.repo.DeleteBefore(ctx, service.now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("delete job runs: %w", err)
+	}
+	return deleted, nil
+}
+
+var _ jobrunsvc.JobRunService = (*Service)(nil)
@@ -0,0 +1,67 @@
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/jobrun/model"
+)
+
+type mockRepo struct {
+	runs   []model.Run
+	before time.Time
+	called bool
+}
+
+func (m *mockRepo) Start(context.Context, string, time.Time) (int64, error) { return 0, nil }
+
+func (m *mockRepo) Finish(context.Context, int64, time.Time, model.Status, string) error { return nil }
+
+func (m *mockRepo) List(context.Context, model.Filter) ([]model.Run, error) {
+	m.called = true
+	return m.runs, nil
+}
+
+func (m *mockRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	m.before = before
+	return 3, nil
+}
+
+func TestService_ListRuns_InvalidFilter(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo)
+
+	for _, limit := range []int{0, model.MaxLimit + 1} {
+		if _, err := svc.ListRuns(context.Background(), model.Filter{Limit: limit}); !errors.Is(err, model.ErrInvalidFilter) {
+			t.Fatalf("limit %d: want ErrInvalidFilter, got %v", limit, err)
+		}
+	}
+	if repo.called {
+		t.Fatalf("did not expect repo to be called")
+	}
+}
+
+func TestService_ListRuns(t *testing.T) {
+	repo := &mockRepo{runs: []model.Run{{ID: 1, Job: "job"}}}
+	runs, err := NewService(repo).ListRuns(context.Background(), model.Filter{Limit: model.DefaultLimit})
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("unexpected result: %v, %v", runs, err)
+	}
+}
+
+func TestService_Cleanup(t *testing.T) {
+	now := time.Date(2024, 6, 8, 3, 0, 0, 0, time.UTC)
+	repo := &mockRepo{}
+	svc := NewService(repo)
+	svc.now = func() time.Time { return now }
+
+	deleted, err := svc.Cleanup(context.Background(), 7*24*time.Hour)
+	if err != nil || deleted != 3 {
+		t.Fatalf("unexpected result: %d, %v", deleted, err)
+	}
+	if want := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC); !repo.before.Equal(want) {
+		t.Fatalf("deleted before %v, want %v", repo.before, want)
+	}
+}
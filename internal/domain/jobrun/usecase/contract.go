@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"loyalty/internal/domain/jobrun/model"
+)
+
+// JobRunUsecase описывает административный просмотр журнала запусков фоновых задач.
+type JobRunUsecase interface {
+	ListRuns(ctx context.Context, filter model.Filter) ([]model.Run, error)
+}
@@ -0,0 +1,30 @@
+package jobrun
+
+import (
+	"context"
+
+	"loyalty/internal/domain/jobrun/model"
+	jobrunsvc "loyalty/internal/domain/jobrun/service"
+	"loyalty/internal/domain/jobrun/usecase"
+	"loyalty/internal/tracing"
+)
+
+// Usecase — реализация usecase.JobRunUsecase.
+type Usecase struct {
+	jobRunService jobrunsvc.JobRunService
+}
+
+// NewUsecase создаёт usecase журнала запусков.
+func NewUsecase(jobRunService jobrunsvc.JobRunService) *Usecase {
+	return &Usecase{jobRunService: jobRunService}
+}
+
+// ListRuns возвращает последние запуски фоновых задач.
+func (usecase *Usecase) ListRuns(ctx context.Context, filter model.Filter) (_ []model.Run, err error) {
+	ctx, span := tracing.Start(ctx, "JobRunUsecase.ListRuns")
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.jobRunService.ListRuns(ctx, filter)
+}
+
+var _ usecase.JobRunUsecase = (*Usecase)(nil)
@@ -0,0 +1,44 @@
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/jobrun/model"
+)
+
+type mockJobRunService struct {
+	runs []model.Run
+	err  error
+}
+
+func (m *mockJobRunService) ListRuns(context.Context, model.Filter) ([]model.Run, error) {
+	return m.runs, m.err
+}
+
+func (m *mockJobRunService) Cleanup(context.Context, time.Duration) (int64, error) {
+	return 0, m.err
+}
+
+func TestUsecase_ListRuns(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     *mockJobRunService
+		wantErr bool
+	}{
+		{name: "success", svc: &mockJobRunService{runs: []model.Run{{ID: 1}}}},
+		{name: "service error", svc: &mockJobRunService{err: errors.New("db error")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUsecase(tt.svc)
+			_, err := uc.ListRuns(context.Background(), model.Filter{Limit: model.DefaultLimit})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListRuns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -13,6 +13,17 @@ var (
 	ErrOrderAlreadyUploaded = errors.New("order already uploaded by this user")
 	// ErrOrderAlreadyUploadedByAnother возвращается, если номер заказа уже был загружен другим пользователем.
 	ErrOrderAlreadyUploadedByAnother = errors.New("order already uploaded by another user")
+	// ErrOrderNotFound возвращается, если заказ с таким номером не загружен.
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrOrderAlreadyCredited возвращается при попытке повторно отправить в обработку заказ,
+	// начисление по которому уже зачислено на счёт.
+	ErrOrderAlreadyCredited = errors.New("order accrual already credited")
+	// ErrIllegalTransition возвращается при попытке перевести заказ в статус, недопустимый из текущего
+	// (например, PROCESSED обратно в NEW).
+	ErrIllegalTransition = errors.New("illegal order status transition")
+	// ErrAccrualConflict возвращается, если по уже зачисленному заказу пришёл результат
+	// с другой суммой начисления; сохранённая сумма не меняется.
+	ErrAccrualConflict = errors.New("order already credited with a different accrual")
 
 	// ErrAccrualRateLimited возвращается при превышении лимита запросов к сервису начислений (HTTP 429).
 	ErrAccrualRateLimited = errors.New("accrual rate limited")
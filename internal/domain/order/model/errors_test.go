@@ -90,3 +90,30 @@ func TestRateLimitError(t *testing.T) {
 		})
 	}
 }
+
+func TestStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusNew, StatusProcessing, true},
+		{StatusNew, StatusProcessed, true},
+		{StatusProcessing, StatusProcessing, true},
+		{StatusProcessing, StatusInvalid, true},
+		{StatusProcessing, StatusNew, true},
+		{StatusInvalid, StatusNew, true},
+		{StatusInvalid, StatusProcessed, false},
+		{StatusProcessed, StatusProcessed, true},
+		{StatusProcessed, StatusNew, false},
+		{StatusProcessed, StatusProcessing, false},
+		{StatusProcessed, StatusInvalid, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
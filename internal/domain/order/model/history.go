@@ -0,0 +1,67 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Source — кто изменил статус заказа.
+type Source string
+
+const (
+	// SourceUser — пользователь загрузил заказ.
+	SourceUser Source = "user"
+	// SourceWorker — воркер получил ответ системы accrual при опросе.
+	SourceWorker Source = "worker"
+	// SourceAdmin — поддержка вернула заказ в очередь.
+	SourceAdmin Source = "admin"
+	// SourceWebhook — система accrual сообщила результат обратным вызовом.
+	SourceWebhook Source = "webhook"
+)
+
+// transitions — допустимые переходы между разными статусами. PROCESSED финальный; в NEW заказ
+// возвращается только поддержкой, пока начисление по нему не зачислено.
+var transitions = map[Status][]Status{
+	StatusNew:        {StatusProcessing, StatusInvalid, StatusProcessed},
+	StatusProcessing: {StatusNew, StatusInvalid, StatusProcessed},
+	StatusInvalid:    {StatusNew},
+}
+
+// CanTransitionTo сообщает, допустим ли переход из status в next. Сохранение статуса допустимо всегда
+// (повторный ответ accrual с тем же статусом).
+func (status Status) CanTransitionTo(next Status) bool {
+	return status == next || slices.Contains(transitions[status], next)
+}
+
+// SameAccrual сообщает, совпадают ли суммы начисления (nil — начисления нет).
+func SameAccrual(a, b *decimal.Decimal) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// StatusUpdate — новый статус заказа по данным accrual.
+type StatusUpdate struct {
+	Status  Status
+	Accrual *decimal.Decimal
+	Source  Source
+	// Response — исходный ответ системы accrual (JSON), сохраняется в истории статусов.
+	Response json.RawMessage
+}
+
+// StatusChange — запись истории статусов заказа.
+type StatusChange struct {
+	Number string
+	// From — предыдущий статус; пустой для загрузки заказа.
+	From     Status
+	To       Status
+	Accrual  *decimal.Decimal
+	Source   Source
+	Response json.RawMessage
+	// ChangedAt — время перехода.
+	ChangedAt time.Time
+}
@@ -3,6 +3,9 @@ package model
 import (
 	"time"
 
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	referralmodel "loyalty/internal/domain/referral/model"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -28,3 +31,11 @@ type Order struct {
 	Accrual    *decimal.Decimal
 	UploadedAt time.Time
 }
+
+// Rewards — дополнительные зачисления, применяемые вместе с начислением по заказу.
+type Rewards struct {
+	// Promotions — бонусы по акциям автору заказа.
+	Promotions []promotionmodel.Bonus
+	// Referral — вознаграждение пригласившему автора заказа (nil, если не положено).
+	Referral *referralmodel.Reward
+}
@@ -4,21 +4,37 @@ import (
 	"context"
 
 	"loyalty/internal/domain/order/model"
-
-	"github.com/shopspring/decimal"
 )
 
 // OrdersRepository — порт репозитория заказов (загрузка, выдача и обновление статусов/начислений).
+// Каждая смена статуса записывается в историю статусов заказа в той же транзакции.
 type OrdersRepository interface {
 	// Create создаёт заказ со статусом NEW для пользователя.
 	Create(ctx context.Context, userID int64, number string) error
 
+	// Get возвращает заказ по номеру (ErrOrderNotFound, если заказа нет).
+	Get(ctx context.Context, number string) (model.Order, error)
+
 	// ListByUser возвращает список заказов пользователя
 	ListByUser(ctx context.Context, userID int64) ([]model.Order, error)
 
 	// ListPending возвращает все заказы, которые нужно проверить/обновить через accrual-сервис.
 	ListPending(ctx context.Context) ([]model.Order, error)
 
+	// ListHistory возвращает историю статусов заказа (от старых записей к новым). В истории только
+	// смены статуса: повторные ответы accrual с тем же статусом не записываются.
+	ListHistory(ctx context.Context, number string) ([]model.StatusChange, error)
+
+	// Requeue возвращает заказ в статус NEW, чтобы воркер заново запросил начисление
+	// (в истории — источник SourceAdmin). Заказ с уже зачисленным начислением не меняется
+	// (ErrOrderAlreadyCredited).
+	Requeue(ctx context.Context, number string) (model.Order, error)
+
 	// UpdateFromAccrual обновляет статус/начисление заказа по данным внешнего accrual-сервиса.
-	UpdateFromAccrual(ctx context.Context, number string, status model.Status, accrual *decimal.Decimal) error
+	// Переход, недопустимый из текущего статуса (Status.CanTransitionTo), отклоняется
+	// с ErrIllegalTransition; неизвестный заказ игнорируется. Повторный PROCESSED по зачисленному
+	// заказу ничего не записывает, а с другой суммой отклоняется с ErrAccrualConflict.
+	// Бонусы по акциям и реферальное вознаграждение зачисляются отдельными записями
+	// вместе с начислением (и так же идемпотентно).
+	UpdateFromAccrual(ctx context.Context, number string, update model.StatusUpdate, rewards model.Rewards) error
 }
@@ -26,9 +26,20 @@ type OrdersService interface {
 	// LoadOrders возвращает список заказов пользователя.
 	LoadOrders(ctx context.Context, userID int64) ([]model.Order, error)
 
-	// UpdateFromAccrual обновляет статус заказа по данным из системы accrual.
-	// Инкапсулирует бизнес-логику маппинга статусов и правила обновления.
-	UpdateFromAccrual(ctx context.Context, orderNumber string, accrualStatus accrualmodel.AccrualStatus, accrual *decimal.Decimal) error
+	// GetOrder возвращает заказ по номеру (ErrOrderNotFound, если заказа нет).
+	GetOrder(ctx context.Context, orderNumber string) (model.Order, error)
+
+	// LoadHistory возвращает историю статусов заказа пользователя (ErrOrderNotFound для чужого заказа).
+	LoadHistory(ctx context.Context, userID int64, orderNumber string) ([]model.StatusChange, error)
+
+	// UpdateFromAccrual обновляет статус заказа по ответу системы accrual, полученному из source.
+	// Инкапсулирует бизнес-логику маппинга статусов и правила обновления: недопустимый переход
+	// отклоняется с ErrIllegalTransition, неизвестный заказ — ErrOrderNotFound. Повторный PROCESSED
+	// по зачисленному заказу ничего не меняет, с другой суммой — ErrAccrualConflict.
+	UpdateFromAccrual(ctx context.Context, orderNumber string, source model.Source, response accrualmodel.Accrual) error
+
+	// RequeueOrder возвращает заказ в очередь воркера (статус NEW), если начисление по нему ещё не зачислено.
+	RequeueOrder(ctx context.Context, orderNumber string) (model.Order, error)
 }
 
 // AccrualService — порт внешнего сервиса расчёта начислений.
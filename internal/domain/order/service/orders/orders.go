@@ -2,12 +2,18 @@ package orders
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	accrualmodel "loyalty/internal/domain/accrual/model"
 	"loyalty/internal/domain/order/model"
 	ordersrepo "loyalty/internal/domain/order/repository"
 	orderssvc "loyalty/internal/domain/order/service"
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	promotionsvc "loyalty/internal/domain/promotion/service"
+	referralmodel "loyalty/internal/domain/referral/model"
+	referralsvc "loyalty/internal/domain/referral/service"
+	"loyalty/internal/tracing"
 
 	"github.com/shopspring/decimal"
 )
@@ -16,15 +22,26 @@ import (
 type Service struct {
 	repo            ordersrepo.OrdersRepository
 	numberValidator orderssvc.OrderNumberValidator
+	promotions      promotionsvc.PromotionService
+	referrals       referralsvc.ReferralService
 }
 
 // NewService создаёт прикладной сервис заказов.
-func NewService(repo ordersrepo.OrdersRepository, numberValidator orderssvc.OrderNumberValidator) *Service {
-	return &Service{repo: repo, numberValidator: numberValidator}
+// promotions и referrals могут быть nil — тогда бонусы по акциям и реферальные вознаграждения не рассчитываются.
+func NewService(
+	repo ordersrepo.OrdersRepository,
+	numberValidator orderssvc.OrderNumberValidator,
+	promotions promotionsvc.PromotionService,
+	referrals referralsvc.ReferralService,
+) *Service {
+	return &Service{repo: repo, numberValidator: numberValidator, promotions: promotions, referrals: referrals}
 }
 
 // UploadOrder валидирует/нормализует номер заказа и сохраняет его.
-func (service *Service) UploadOrder(ctx context.Context, userID int64, number string) error {
+func (service *Service) UploadOrder(ctx context.Context, userID int64, number string) (err error) {
+	ctx, span := tracing.Start(ctx, "OrdersService.UploadOrder", tracing.UserID(userID), tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
 	normalized, err := service.numberValidator.ValidateNumber(number)
 	if err != nil {
 		return model.ErrInvalidOrderNumber
@@ -33,29 +50,151 @@ func (service *Service) UploadOrder(ctx context.Context, userID int64, number st
 }
 
 // LoadOrders возвращает список заказов пользователя.
-func (service *Service) LoadOrders(ctx context.Context, userID int64) ([]model.Order, error) {
+func (service *Service) LoadOrders(ctx context.Context, userID int64) (_ []model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersService.LoadOrders", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
 	return service.repo.ListByUser(ctx, userID)
 }
 
-// UpdateFromAccrual обновляет статус заказа по данным из системы accrual.
+// GetOrder возвращает заказ по номеру.
+func (service *Service) GetOrder(ctx context.Context, orderNumber string) (_ model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersService.GetOrder", tracing.OrderNumber(orderNumber))
+	defer func() { tracing.End(span, err) }()
+
+	return service.repo.Get(ctx, orderNumber)
+}
+
+// LoadHistory валидирует номер заказа и возвращает историю его статусов, если заказ принадлежит пользователю.
+func (service *Service) LoadHistory(ctx context.Context, userID int64, orderNumber string) (_ []model.StatusChange, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersService.LoadHistory", tracing.UserID(userID), tracing.OrderNumber(orderNumber))
+	defer func() { tracing.End(span, err) }()
+
+	normalized, err := service.numberValidator.ValidateNumber(orderNumber)
+	if err != nil {
+		return nil, model.ErrInvalidOrderNumber
+	}
+	order, err := service.repo.Get(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	// Чужой заказ неотличим от незагруженного.
+	if order.UserID != userID {
+		return nil, model.ErrOrderNotFound
+	}
+	return service.repo.ListHistory(ctx, normalized)
+}
+
+// RequeueOrder валидирует номер заказа и возвращает заказ в очередь воркера.
+func (service *Service) RequeueOrder(ctx context.Context, orderNumber string) (_ model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersService.RequeueOrder", tracing.OrderNumber(orderNumber))
+	defer func() { tracing.End(span, err) }()
+
+	normalized, err := service.numberValidator.ValidateNumber(orderNumber)
+	if err != nil {
+		return model.Order{}, model.ErrInvalidOrderNumber
+	}
+	return service.repo.Requeue(ctx, normalized)
+}
+
+// UpdateFromAccrual обновляет статус заказа по ответу системы accrual.
 // Инкапсулирует бизнес-логику маппинга статусов и правила обновления.
 func (service *Service) UpdateFromAccrual(
 	ctx context.Context,
 	orderNumber string,
-	accrualStatus accrualmodel.AccrualStatus,
-	accrual *decimal.Decimal,
-) error {
-	// Маппим статус из accrual в доменный статус заказа
-	orderStatus := mapAccrualStatusToOrderStatus(accrualStatus)
+	source model.Source,
+	response accrualmodel.Accrual,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "OrdersService.UpdateFromAccrual", tracing.OrderNumber(orderNumber))
+	defer func() { tracing.End(span, err) }()
+
+	order, err := service.repo.Get(ctx, orderNumber)
+	if err != nil {
+		return fmt.Errorf("get order: %w", err)
+	}
+
+	// Маппим статус из accrual в доменный статус заказа и проверяем, что переход допустим
+	orderStatus := mapAccrualStatusToOrderStatus(response.Status)
+	if !order.Status.CanTransitionTo(orderStatus) {
+		return fmt.Errorf("%w: %s -> %s", model.ErrIllegalTransition, order.Status, orderStatus)
+	}
+	accrual := response.Accrual
+	if order.Status == model.StatusProcessed && orderStatus == model.StatusProcessed {
+		// Повторный результат по зачисленному заказу ничего не меняет; другая сумма — конфликт
+		if !model.SameAccrual(order.Accrual, accrual) {
+			return fmt.Errorf("%w: order %s", model.ErrAccrualConflict, orderNumber)
+		}
+		return nil
+	}
+
+	// Рассчитываем бонусы по акциям и реферальное вознаграждение до зачисления
+	bonuses, err := service.evaluatePromotions(ctx, orderNumber, orderStatus, accrual)
+	if err != nil {
+		return err
+	}
+	referral, err := service.evaluateReferral(ctx, orderNumber, orderStatus)
+	if err != nil {
+		return err
+	}
+	rewards := model.Rewards{Promotions: bonuses, Referral: referral}
 
 	// Обновляем заказ в репозитории
-	if err := service.repo.UpdateFromAccrual(ctx, orderNumber, orderStatus, accrual); err != nil {
+	update := model.StatusUpdate{Status: orderStatus, Accrual: accrual, Source: source, Response: rawResponse(response)}
+	if err := service.repo.UpdateFromAccrual(ctx, orderNumber, update, rewards); err != nil {
 		return fmt.Errorf("update order from accrual: %w", err)
 	}
 
 	return nil
 }
 
+// evaluatePromotions рассчитывает бонусы по акциям для заказа, получившего положительное начисление.
+func (service *Service) evaluatePromotions(
+	ctx context.Context,
+	orderNumber string,
+	orderStatus model.Status,
+	accrual *decimal.Decimal,
+) ([]promotionmodel.Bonus, error) {
+	if service.promotions == nil || orderStatus != model.StatusProcessed {
+		return nil, nil
+	}
+	if accrual == nil || accrual.LessThanOrEqual(decimal.Zero) {
+		return nil, nil
+	}
+	bonuses, err := service.promotions.Evaluate(ctx, orderNumber, *accrual)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate promotions: %w", err)
+	}
+	return bonuses, nil
+}
+
+// evaluateReferral рассчитывает вознаграждение пригласившего, когда заказ приглашённого становится PROCESSED.
+func (service *Service) evaluateReferral(
+	ctx context.Context,
+	orderNumber string,
+	orderStatus model.Status,
+) (*referralmodel.Reward, error) {
+	if service.referrals == nil || orderStatus != model.StatusProcessed {
+		return nil, nil
+	}
+	reward, err := service.referrals.Evaluate(ctx, orderNumber)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate referral: %w", err)
+	}
+	return reward, nil
+}
+
+// rawResponse возвращает исходное тело ответа accrual, а если его нет — ответ, сериализованный заново.
+func rawResponse(response accrualmodel.Accrual) json.RawMessage {
+	if len(response.Raw) > 0 {
+		return response.Raw
+	}
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
 // mapAccrualStatusToOrderStatus маппит статус из системы accrual в статус заказа.
 func mapAccrualStatusToOrderStatus(accrualStatus accrualmodel.AccrualStatus) model.Status {
 	switch accrualStatus {
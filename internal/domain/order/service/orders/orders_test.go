@@ -2,11 +2,10 @@ package orders
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"loyalty/internal/domain/order/model"
-
-	"github.com/shopspring/decimal"
 )
 
 type mockRepo struct {
@@ -14,6 +13,10 @@ type mockRepo struct {
 
 	gotUserID int64
 	gotNumber string
+
+	// order возвращается из Get (по умолчанию — заказ пользователя 10 в статусе NEW).
+	order   *model.Order
+	history []model.StatusChange
 }
 
 func (m *mockRepo) Create(ctx context.Context, userID int64, number string) error {
@@ -23,11 +26,29 @@ func (m *mockRepo) Create(ctx context.Context, userID int64, number string) erro
 	return nil
 }
 
+func (m *mockRepo) Get(_ context.Context, number string) (model.Order, error) {
+	if m.order == nil {
+		return model.Order{Number: number, UserID: 10, Status: model.StatusNew}, nil
+	}
+	if m.order.Number != number {
+		return model.Order{}, model.ErrOrderNotFound
+	}
+	return *m.order, nil
+}
+
 func (m *mockRepo) ListByUser(context.Context, int64) ([]model.Order, error) { return nil, nil }
 func (m *mockRepo) ListPending(context.Context) ([]model.Order, error)       { return nil, nil }
-func (m *mockRepo) UpdateFromAccrual(context.Context, string, model.Status, *decimal.Decimal) error {
+func (m *mockRepo) ListHistory(_ context.Context, number string) ([]model.StatusChange, error) {
+	m.gotNumber = number
+	return m.history, nil
+}
+func (m *mockRepo) UpdateFromAccrual(context.Context, string, model.StatusUpdate, model.Rewards) error {
 	return nil
 }
+func (m *mockRepo) Requeue(_ context.Context, number string) (model.Order, error) {
+	m.gotNumber = number
+	return model.Order{Number: number, Status: model.StatusNew}, nil
+}
 
 type mockNumberService struct {
 	normalized string
@@ -39,7 +60,7 @@ func (m *mockNumberService) ValidateNumber(string) (string, error) { return m.no
 func TestService_UploadOrder_CallsRepoWithNormalizedNumber(t *testing.T) {
 	repo := &mockRepo{}
 	num := &mockNumberService{normalized: "79927398713"}
-	svc := NewService(repo, num)
+	svc := NewService(repo, num, nil, nil)
 
 	if err := svc.UploadOrder(context.Background(), 10, " 79927398713 "); err != nil {
 		t.Fatalf("unexpected err: %v", err)
@@ -58,7 +79,7 @@ func TestService_UploadOrder_CallsRepoWithNormalizedNumber(t *testing.T) {
 func TestService_UploadOrder_InvalidNumber_ReturnsDomainErrorAndDoesNotCreate(t *testing.T) {
 	repo := &mockRepo{}
 	num := &mockNumberService{err: model.ErrInvalidOrderNumber}
-	svc := NewService(repo, num)
+	svc := NewService(repo, num, nil, nil)
 
 	err := svc.UploadOrder(context.Background(), 10, "bad")
 	if err == nil || err != model.ErrInvalidOrderNumber {
@@ -68,3 +89,40 @@ func TestService_UploadOrder_InvalidNumber_ReturnsDomainErrorAndDoesNotCreate(t
 		t.Fatalf("did not expect repo.Create to be called")
 	}
 }
+
+func TestService_RequeueOrder(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo, &mockNumberService{normalized: "79927398713"}, nil, nil)
+
+	order, err := svc.RequeueOrder(context.Background(), " 7992 7398 713 ")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if repo.gotNumber != "79927398713" || order.Status != model.StatusNew {
+		t.Fatalf("expected normalized number to be requeued, got %q %+v", repo.gotNumber, order)
+	}
+
+	svc = NewService(repo, &mockNumberService{err: errors.New("bad")}, nil, nil)
+	if _, err := svc.RequeueOrder(context.Background(), "123"); !errors.Is(err, model.ErrInvalidOrderNumber) {
+		t.Fatalf("expected ErrInvalidOrderNumber, got %v", err)
+	}
+}
+
+func TestService_LoadHistory(t *testing.T) {
+	owned := model.Order{Number: "79927398713", UserID: 10, Status: model.StatusProcessed}
+	repo := &mockRepo{order: &owned, history: []model.StatusChange{{Number: "79927398713", To: model.StatusNew}}}
+	svc := NewService(repo, &mockNumberService{normalized: "79927398713"}, nil, nil)
+
+	history, err := svc.LoadHistory(context.Background(), 10, " 79927398713 ")
+	if err != nil || len(history) != 1 || repo.gotNumber != "79927398713" {
+		t.Fatalf("LoadHistory() = %+v, %v (number %q)", history, err, repo.gotNumber)
+	}
+	if _, err := svc.LoadHistory(context.Background(), 11, "79927398713"); !errors.Is(err, model.ErrOrderNotFound) {
+		t.Fatalf("want ErrOrderNotFound for another user's order, got %v", err)
+	}
+
+	svc = NewService(repo, &mockNumberService{err: errors.New("bad")}, nil, nil)
+	if _, err := svc.LoadHistory(context.Background(), 10, "123"); !errors.Is(err, model.ErrInvalidOrderNumber) {
+		t.Fatalf("want ErrInvalidOrderNumber, got %v", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	accrualmodel "loyalty/internal/domain/accrual/model"
 	"loyalty/internal/domain/order/model"
+	promotionmodel "loyalty/internal/domain/promotion/model"
+	referralmodel "loyalty/internal/domain/referral/model"
 
 	"github.com/shopspring/decimal"
 )
@@ -55,9 +57,9 @@ func TestService_UpdateFromAccrual(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := &mockRepoWithError{updateErr: tt.repoErr}
-			svc := NewService(repo, &mockNumberValidator{})
+			svc := NewService(repo, &mockNumberValidator{}, nil, nil)
 
-			err := svc.UpdateFromAccrual(context.Background(), "123", tt.accrualStatus, tt.accrual)
+			err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, accrualmodel.Accrual{Order: "123", Status: tt.accrualStatus, Accrual: tt.accrual})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateFromAccrual() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -91,14 +93,91 @@ func TestMapAccrualStatusToOrderStatus(t *testing.T) {
 	}
 }
 
+type mockPromotions struct {
+	bonuses []promotionmodel.Bonus
+	err     error
+	calls   int
+}
+
+func (m *mockPromotions) Evaluate(context.Context, string, decimal.Decimal) ([]promotionmodel.Bonus, error) {
+	m.calls++
+	return m.bonuses, m.err
+}
+
+func (m *mockPromotions) CreateRule(context.Context, promotionmodel.Rule) (promotionmodel.Rule, error) {
+	return promotionmodel.Rule{}, nil
+}
+
+func (m *mockPromotions) GetRule(context.Context, int64) (promotionmodel.Rule, error) {
+	return promotionmodel.Rule{}, nil
+}
+
+func (m *mockPromotions) ListRules(context.Context) ([]promotionmodel.Rule, error) { return nil, nil }
+
+func (m *mockPromotions) UpdateRule(context.Context, promotionmodel.Rule) (promotionmodel.Rule, error) {
+	return promotionmodel.Rule{}, nil
+}
+
+func (m *mockPromotions) DeleteRule(context.Context, int64) error { return nil }
+
+func TestService_UpdateFromAccrual_PassesPromotionBonuses(t *testing.T) {
+	repo := &mockRepoWithError{}
+	promotions := &mockPromotions{bonuses: []promotionmodel.Bonus{{RuleID: 1, Amount: decimal.NewFromInt(50)}}}
+	svc := NewService(repo, &mockNumberValidator{}, promotions, nil)
+
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(100)}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(repo.gotRewards.Promotions) != 1 || repo.gotRewards.Promotions[0].RuleID != 1 {
+		t.Fatalf("expected bonuses to be passed to repo, got %+v", repo.gotRewards.Promotions)
+	}
+}
+
+func TestService_UpdateFromAccrual_SkipsPromotionsUntilProcessed(t *testing.T) {
+	promotions := &mockPromotions{}
+	svc := NewService(&mockRepoWithError{}, &mockNumberValidator{}, promotions, nil)
+
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessing}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(0)}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if promotions.calls != 0 {
+		t.Fatalf("did not expect promotions to be evaluated, got %d calls", promotions.calls)
+	}
+}
+
+func TestService_UpdateFromAccrual_PromotionErrorDoesNotUpdate(t *testing.T) {
+	repo := &mockRepoWithError{}
+	promotions := &mockPromotions{err: errors.New("db error")}
+	svc := NewService(repo, &mockNumberValidator{}, promotions, nil)
+
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(100)}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if repo.updateCalled {
+		t.Fatalf("did not expect repo.UpdateFromAccrual to be called")
+	}
+}
+
 type mockRepoWithError struct {
 	mockRepo
 	updateErr    error
 	updateCalled bool
+	gotUpdate    model.StatusUpdate
+	gotRewards   model.Rewards
 }
 
-func (m *mockRepoWithError) UpdateFromAccrual(ctx context.Context, number string, status model.Status, accrual *decimal.Decimal) error {
+func (m *mockRepoWithError) UpdateFromAccrual(
+	ctx context.Context,
+	number string,
+	update model.StatusUpdate,
+	rewards model.Rewards,
+) error {
 	m.updateCalled = true
+	m.gotUpdate = update
+	m.gotRewards = rewards
 	return m.updateErr
 }
 
@@ -112,3 +191,98 @@ func decimalPtr(v float64) *decimal.Decimal {
 	d := decimal.NewFromFloat(v)
 	return &d
 }
+
+type mockReferrals struct {
+	reward *referralmodel.Reward
+	err    error
+	calls  int
+}
+
+func (m *mockReferrals) ResolveCode(context.Context, string) (int64, error) { return 0, nil }
+func (m *mockReferrals) Evaluate(context.Context, string) (*referralmodel.Reward, error) {
+	m.calls++
+	return m.reward, m.err
+}
+
+func (m *mockReferrals) GetSummary(context.Context, int64) (referralmodel.Summary, error) {
+	return referralmodel.Summary{}, nil
+}
+
+func TestService_UpdateFromAccrual_PassesReferralReward(t *testing.T) {
+	repo := &mockRepoWithError{}
+	referrals := &mockReferrals{reward: &referralmodel.Reward{ReferrerID: 1, RefereeID: 2, Amount: decimal.NewFromInt(100)}}
+	svc := NewService(repo, &mockNumberValidator{}, nil, referrals)
+
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(0)}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if repo.gotRewards.Referral == nil || repo.gotRewards.Referral.ReferrerID != 1 {
+		t.Fatalf("expected referral reward to be passed to repo, got %+v", repo.gotRewards.Referral)
+	}
+}
+
+func TestService_UpdateFromAccrual_SkipsReferralUntilProcessed(t *testing.T) {
+	referrals := &mockReferrals{}
+	svc := NewService(&mockRepoWithError{}, &mockNumberValidator{}, nil, referrals)
+
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusInvalid}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if referrals.calls != 0 {
+		t.Fatalf("did not expect referral to be evaluated, got %d calls", referrals.calls)
+	}
+}
+
+func TestService_UpdateFromAccrual_PassesSourceAndRawResponse(t *testing.T) {
+	repo := &mockRepoWithError{}
+	svc := NewService(repo, &mockNumberValidator{}, nil, nil)
+
+	raw := []byte(`{"order":"123","status":"PROCESSING"}`)
+	response := accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessing, Raw: raw}
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWebhook, response); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if repo.gotUpdate.Status != model.StatusProcessing || repo.gotUpdate.Source != model.SourceWebhook || string(repo.gotUpdate.Response) != string(raw) {
+		t.Fatalf("unexpected update: %+v", repo.gotUpdate)
+	}
+
+	// Ответ без исходного тела сохраняется в истории сериализованным заново.
+	response = accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(5)}
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWorker, response); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if string(repo.gotUpdate.Response) != `{"order":"123","status":"PROCESSED","accrual":"5"}` {
+		t.Fatalf("unexpected response: %s", repo.gotUpdate.Response)
+	}
+}
+
+func TestService_UpdateFromAccrual_RejectsIllegalTransition(t *testing.T) {
+	processed := model.Order{Number: "123", UserID: 10, Status: model.StatusProcessed, Accrual: decimalPtr(5)}
+	repo := &mockRepoWithError{mockRepo: mockRepo{order: &processed}}
+	svc := NewService(repo, &mockNumberValidator{}, nil, nil)
+
+	response := accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusInvalid}
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWebhook, response); !errors.Is(err, model.ErrIllegalTransition) {
+		t.Fatalf("want ErrIllegalTransition, got %v", err)
+	}
+	if repo.updateCalled {
+		t.Fatalf("did not expect repo.UpdateFromAccrual to be called")
+	}
+
+	// Повтор финального статуса с той же суммой ничего не меняет, с другой — конфликт.
+	response = accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(5)}
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWebhook, response); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if repo.updateCalled {
+		t.Fatalf("did not expect repo.UpdateFromAccrual to be called for a duplicate result")
+	}
+	conflicting := accrualmodel.Accrual{Order: "123", Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(7)}
+	if err := svc.UpdateFromAccrual(context.Background(), "123", model.SourceWebhook, conflicting); !errors.Is(err, model.ErrAccrualConflict) {
+		t.Fatalf("want ErrAccrualConflict, got %v", err)
+	}
+
+	if err := svc.UpdateFromAccrual(context.Background(), "456", model.SourceWebhook, response); !errors.Is(err, model.ErrOrderNotFound) {
+		t.Fatalf("want ErrOrderNotFound for unknown order, got %v", err)
+	}
+}
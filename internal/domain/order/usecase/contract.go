@@ -13,4 +13,7 @@ type OrdersUsecase interface {
 
 	// LoadOrders ListOrders возвращает список заказов пользователя (от новых к старым).
 	LoadOrders(ctx context.Context, userID int64) ([]model.Order, error)
+
+	// LoadHistory возвращает историю статусов заказа пользователя (от старых записей к новым).
+	LoadHistory(ctx context.Context, userID int64, number string) ([]model.StatusChange, error)
 }
@@ -6,6 +6,7 @@ import (
 	"loyalty/internal/domain/order/model"
 	orderssvc "loyalty/internal/domain/order/service"
 	"loyalty/internal/domain/order/usecase"
+	"loyalty/internal/tracing"
 )
 
 // Usecase — реализация usecase.OrdersUsecase.
@@ -19,13 +20,27 @@ func NewUsecase(ordersService orderssvc.OrdersService) *Usecase {
 }
 
 // UploadOrder загружает номер заказа пользователя.
-func (usecase *Usecase) UploadOrder(ctx context.Context, userID int64, number string) error {
+func (usecase *Usecase) UploadOrder(ctx context.Context, userID int64, number string) (err error) {
+	ctx, span := tracing.Start(ctx, "OrdersUsecase.UploadOrder", tracing.UserID(userID), tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
 	return usecase.ordersService.UploadOrder(ctx, userID, number)
 }
 
 // LoadOrders ListOrders возвращает список заказов пользователя (от новых к старым).
-func (usecase *Usecase) LoadOrders(ctx context.Context, userID int64) ([]model.Order, error) {
+func (usecase *Usecase) LoadOrders(ctx context.Context, userID int64) (_ []model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersUsecase.LoadOrders", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
 	return usecase.ordersService.LoadOrders(ctx, userID)
 }
 
+// LoadHistory возвращает историю статусов заказа пользователя (от старых записей к новым).
+func (usecase *Usecase) LoadHistory(ctx context.Context, userID int64, number string) (_ []model.StatusChange, err error) {
+	ctx, span := tracing.Start(ctx, "OrdersUsecase.LoadHistory", tracing.UserID(userID), tracing.OrderNumber(number))
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.ordersService.LoadHistory(ctx, userID, number)
+}
+
 var _ usecase.OrdersUsecase = (*Usecase)(nil)
@@ -7,14 +7,15 @@ import (
 
 	accrualmodel "loyalty/internal/domain/accrual/model"
 	ordersmodel "loyalty/internal/domain/order/model"
-
-	"github.com/shopspring/decimal"
 )
 
 type mockOrdersService struct {
 	uploadErr error
 	orders    []ordersmodel.Order
 	loadErr   error
+
+	history    []ordersmodel.StatusChange
+	historyErr error
 }
 
 func (m *mockOrdersService) UploadOrder(ctx context.Context, userID int64, number string) error {
@@ -28,10 +29,22 @@ func (m *mockOrdersService) LoadOrders(ctx context.Context, userID int64) ([]ord
 	return m.orders, nil
 }
 
-func (m *mockOrdersService) UpdateFromAccrual(ctx context.Context, orderNumber string, accrualStatus accrualmodel.AccrualStatus, accrual *decimal.Decimal) error {
+func (m *mockOrdersService) GetOrder(context.Context, string) (ordersmodel.Order, error) {
+	return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+}
+
+func (m *mockOrdersService) LoadHistory(ctx context.Context, userID int64, orderNumber string) ([]ordersmodel.StatusChange, error) {
+	return m.history, m.historyErr
+}
+
+func (m *mockOrdersService) UpdateFromAccrual(ctx context.Context, orderNumber string, source ordersmodel.Source, response accrualmodel.Accrual) error {
 	return nil
 }
 
+func (m *mockOrdersService) RequeueOrder(ctx context.Context, orderNumber string) (ordersmodel.Order, error) {
+	return ordersmodel.Order{}, nil
+}
+
 func TestUsecase_UploadOrder(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -109,3 +122,16 @@ func TestUsecase_LoadOrders(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_LoadHistory(t *testing.T) {
+	svc := &mockOrdersService{history: []ordersmodel.StatusChange{{Number: "123", To: ordersmodel.StatusNew}}}
+	history, err := NewUsecase(svc).LoadHistory(context.Background(), 1, "123")
+	if err != nil || len(history) != 1 {
+		t.Fatalf("LoadHistory() = %+v, %v", history, err)
+	}
+
+	svc = &mockOrdersService{historyErr: ordersmodel.ErrOrderNotFound}
+	if _, err := NewUsecase(svc).LoadHistory(context.Background(), 1, "123"); !errors.Is(err, ordersmodel.ErrOrderNotFound) {
+		t.Fatalf("want ErrOrderNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidRule возвращается при нарушении ограничений правила акции.
+	ErrInvalidRule = errors.New("invalid promotion rule")
+	// ErrRuleNotFound возвращается, если правило акции не найдено.
+	ErrRuleNotFound = errors.New("promotion rule not found")
+)
+
+// EffectType — тип эффекта правила акции.
+type EffectType string
+
+const (
+	// EffectMultiplier — бонус равен начислению, умноженному на (Value - 1): Value=2 удваивает начисление.
+	EffectMultiplier EffectType = "MULTIPLIER"
+	// EffectFixed — фиксированный бонус в размере Value баллов.
+	EffectFixed EffectType = "FIXED"
+)
+
+// Conditions — условия применения правила. Пустое условие не ограничивает применение.
+type Conditions struct {
+	// Weekdays — дни недели загрузки заказа (UTC).
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+	// FirstOrder — только для самого первого загруженного заказа пользователя.
+	FirstOrder bool `json:"first_order,omitempty"`
+	// Tiers — уровни лояльности пользователя.
+	Tiers []string `json:"tiers,omitempty"`
+	// MinAccrual — минимальная сумма начисления от системы расчёта.
+	MinAccrual *decimal.Decimal `json:"min_accrual,omitempty"`
+}
+
+// Effect — эффект правила акции.
+type Effect struct {
+	Type  EffectType
+	Value decimal.Decimal
+}
+
+// Rule — правило акции: окно действия, условия и эффект.
+type Rule struct {
+	ID         int64
+	Name       string
+	Active     bool
+	ValidFrom  *time.Time
+	ValidTo    *time.Time
+	Conditions Conditions
+	Effect     Effect
+	CreatedAt  time.Time
+}
+
+// Facts — данные о заказе, по которым проверяются условия правил.
+type Facts struct {
+	UserID      int64
+	OrderNumber string
+	UploadedAt  time.Time
+	FirstOrder  bool
+	Tier        string
+	Accrual     decimal.Decimal
+}
+
+// Bonus — бонусное начисление по правилу акции, зачисляемое отдельной записью.
+type Bonus struct {
+	RuleID      int64
+	RuleName    string
+	UserID      int64
+	OrderNumber string
+	Amount      decimal.Decimal
+	CreatedAt   time.Time
+}
+
+// Validate проверяет ограничения правила.
+func (rule Rule) Validate() error {
+	if rule.Name == "" {
+		return ErrInvalidRule
+	}
+	if rule.ValidFrom != nil && rule.ValidTo != nil && !rule.ValidTo.After(*rule.ValidFrom) {
+		return ErrInvalidRule
+	}
+	for _, weekday := range rule.Conditions.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return ErrInvalidRule
+		}
+	}
+	if rule.Conditions.MinAccrual != nil && rule.Conditions.MinAccrual.IsNegative() {
+		return ErrInvalidRule
+	}
+	switch rule.Effect.Type {
+	case EffectMultiplier:
+		if rule.Effect.Value.LessThanOrEqual(decimal.NewFromInt(1)) {
+			return ErrInvalidRule
+		}
+	case EffectFixed:
+		if rule.Effect.Value.LessThanOrEqual(decimal.Zero) {
+			return ErrInvalidRule
+		}
+	default:
+		return ErrInvalidRule
+	}
+	return nil
+}
+
+// Matches проверяет, применимо ли правило к заказу.
+// Окно действия и день недели проверяются по времени загрузки заказа.
+func (rule Rule) Matches(facts Facts) bool {
+	if !rule.Active {
+		return false
+	}
+	if rule.ValidFrom != nil && facts.UploadedAt.Before(*rule.ValidFrom) {
+		return false
+	}
+	if rule.ValidTo != nil && !facts.UploadedAt.Before(*rule.ValidTo) {
+		return false
+	}
+	conditions := rule.Conditions
+	if len(conditions.Weekdays) > 0 && !containsWeekday(conditions.Weekdays, facts.UploadedAt.UTC().Weekday()) {
+		return false
+	}
+	if conditions.FirstOrder && !facts.FirstOrder {
+		return false
+	}
+	if len(conditions.Tiers) > 0 && !containsString(conditions.Tiers, facts.Tier) {
+		return false
+	}
+	if conditions.MinAccrual != nil && facts.Accrual.LessThan(*conditions.MinAccrual) {
+		return false
+	}
+	return true
+}
+
+// Apply рассчитывает сумму бонуса для начисления.
+func (effect Effect) Apply(accrual decimal.Decimal) decimal.Decimal {
+	switch effect.Type {
+	case EffectMultiplier:
+		return accrual.Mul(effect.Value.Sub(decimal.NewFromInt(1))).Round(4)
+	case EffectFixed:
+		return effect.Value
+	default:
+		return decimal.Zero
+	}
+}
+
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, candidate := range weekdays {
+		if candidate == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+func TestRule_Validate(t *testing.T) {
+	valid := Rule{Name: "weekend", Effect: Effect{Type: EffectMultiplier, Value: decimal.NewFromInt(2)}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	negative := decimal.NewFromInt(-1)
+	tests := []struct {
+		name   string
+		mutate func(rule *Rule)
+	}{
+		{"empty name", func(rule *Rule) { rule.Name = "" }},
+		{"unknown effect", func(rule *Rule) { rule.Effect.Type = "PERCENT" }},
+		{"multiplier not above one", func(rule *Rule) { rule.Effect.Value = decimal.NewFromInt(1) }},
+		{"fixed not positive", func(rule *Rule) { rule.Effect = Effect{Type: EffectFixed, Value: decimal.Zero} }},
+		{"window reversed", func(rule *Rule) {
+			rule.ValidFrom = timePtr(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			rule.ValidTo = timePtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		}},
+		{"bad weekday", func(rule *Rule) { rule.Conditions.Weekdays = []time.Weekday{7} }},
+		{"negative min accrual", func(rule *Rule) { rule.Conditions.MinAccrual = &negative }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := valid
+			tt.mutate(&rule)
+			if err := rule.Validate(); !errors.Is(err, ErrInvalidRule) {
+				t.Fatalf("want %v, got %v", ErrInvalidRule, err)
+			}
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	saturday := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	hundred := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name  string
+		rule  Rule
+		facts Facts
+		want  bool
+	}{
+		{"inactive", Rule{Active: false}, Facts{UploadedAt: saturday}, false},
+		{"no conditions", Rule{Active: true}, Facts{UploadedAt: monday}, true},
+		{"before window", Rule{Active: true, ValidFrom: timePtr(monday)}, Facts{UploadedAt: saturday}, false},
+		{"after window", Rule{Active: true, ValidTo: timePtr(saturday)}, Facts{UploadedAt: monday}, false},
+		{"inside window", Rule{Active: true, ValidFrom: timePtr(saturday), ValidTo: timePtr(monday)}, Facts{UploadedAt: saturday}, true},
+		{
+			"weekend on saturday",
+			Rule{Active: true, Conditions: Conditions{Weekdays: []time.Weekday{time.Saturday, time.Sunday}}},
+			Facts{UploadedAt: saturday},
+			true,
+		},
+		{
+			"weekend on monday",
+			Rule{Active: true, Conditions: Conditions{Weekdays: []time.Weekday{time.Saturday, time.Sunday}}},
+			Facts{UploadedAt: monday},
+			false,
+		},
+		{"first order", Rule{Active: true, Conditions: Conditions{FirstOrder: true}}, Facts{FirstOrder: true}, true},
+		{"not first order", Rule{Active: true, Conditions: Conditions{FirstOrder: true}}, Facts{FirstOrder: false}, false},
+		{"tier match", Rule{Active: true, Conditions: Conditions{Tiers: []string{"GOLD"}}}, Facts{Tier: "GOLD"}, true},
+		{"tier mismatch", Rule{Active: true, Conditions: Conditions{Tiers: []string{"GOLD"}}}, Facts{Tier: "BASE"}, false},
+		{"min accrual met", Rule{Active: true, Conditions: Conditions{MinAccrual: &hundred}}, Facts{Accrual: hundred}, true},
+		{"min accrual not met", Rule{Active: true, Conditions: Conditions{MinAccrual: &hundred}}, Facts{Accrual: decimal.NewFromInt(99)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.facts); got != tt.want {
+				t.Fatalf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffect_Apply(t *testing.T) {
+	accrual := decimal.NewFromInt(150)
+	if got := (Effect{Type: EffectMultiplier, Value: decimal.NewFromInt(2)}).Apply(accrual); !got.Equal(accrual) {
+		t.Fatalf("double points bonus = %s, want %s", got, accrual)
+	}
+	if got := (Effect{Type: EffectMultiplier, Value: decimal.RequireFromString("1.5")}).Apply(accrual); !got.Equal(decimal.NewFromInt(75)) {
+		t.Fatalf("x1.5 bonus = %s, want 75", got)
+	}
+	if got := (Effect{Type: EffectFixed, Value: decimal.NewFromInt(500)}).Apply(accrual); !got.Equal(decimal.NewFromInt(500)) {
+		t.Fatalf("fixed bonus = %s, want 500", got)
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"loyalty/internal/domain/promotion/model"
+)
+
+// RuleRepository — порт репозитория правил акций.
+type RuleRepository interface {
+	// Create сохраняет новое правило и возвращает его с присвоенным ID.
+	Create(ctx context.Context, rule model.Rule) (model.Rule, error)
+
+	// Get возвращает правило по ID или model.ErrRuleNotFound.
+	Get(ctx context.Context, id int64) (model.Rule, error)
+
+	// List возвращает все правила (от новых к старым).
+	List(ctx context.Context) ([]model.Rule, error)
+
+	// ListActive возвращает активные правила.
+	ListActive(ctx context.Context) ([]model.Rule, error)
+
+	// Update обновляет правило или возвращает model.ErrRuleNotFound.
+	Update(ctx context.Context, rule model.Rule) (model.Rule, error)
+
+	// Delete удаляет правило или возвращает model.ErrRuleNotFound.
+	// Ранее начисленные бонусы сохраняются (с названием правила).
+	Delete(ctx context.Context, id int64) error
+
+	// OrderFacts возвращает данные о заказе для проверки условий правил.
+	OrderFacts(ctx context.Context, orderNumber string) (model.Facts, error)
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"loyalty/internal/domain/promotion/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// PromotionService содержит прикладную логику акций: управление правилами и расчёт бонусов.
+type PromotionService interface {
+	// Evaluate возвращает бонусы по всем применимым к заказу правилам.
+	Evaluate(ctx context.Context, orderNumber string, accrual decimal.Decimal) ([]model.Bonus, error)
+
+	CreateRule(ctx context.Context, rule model.Rule) (model.Rule, error)
+	GetRule(ctx context.Context, id int64) (model.Rule, error)
+	ListRules(ctx context.Context) ([]model.Rule, error)
+	UpdateRule(ctx context.Context, rule model.Rule) (model.Rule, error)
+	DeleteRule(ctx context.Context, id int64) error
+}
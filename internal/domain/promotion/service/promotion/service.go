@@ -0,0 +1,110 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"loyalty/internal/domain/promotion/model"
+	promotionrepo "loyalty/internal/domain/promotion/repository"
+	promotionsvc "loyalty/internal/domain/promotion/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// Service — реализация promotionsvc.PromotionService.
+type Service struct {
+	repo promotionrepo.RuleRepository
+	now  func() time.Time
+}
+
+// NewService создаёт прикладной сервис акций.
+func NewService(repo promotionrepo.RuleRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Evaluate возвращает бонусы по всем применимым к заказу правилам (бонусы суммируются).
+func (service *Service) Evaluate(ctx context.Context, orderNumber string, accrual decimal.Decimal) ([]model.Bonus, error) {
+	rules, err := service.repo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list active promotions: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	facts, err := service.repo.OrderFacts(ctx, orderNumber)
+	if err != nil {
+		return nil, fmt.Errorf("order facts: %w", err)
+	}
+	if facts.UserID == 0 {
+		return nil, nil
+	}
+	facts.Accrual = accrual
+
+	now := service.now()
+	var bonuses []model.Bonus
+	for _, rule := range rules {
+		if !rule.Matches(facts) {
+			continue
+		}
+		amount := rule.Effect.Apply(accrual)
+		if amount.LessThanOrEqual(decimal.Zero) {
+			continue
+		}
+		bonuses = append(bonuses, model.Bonus{
+			RuleID:      rule.ID,
+			RuleName:    rule.Name,
+			UserID:      facts.UserID,
+			OrderNumber: orderNumber,
+			Amount:      amount,
+			CreatedAt:   now,
+		})
+	}
+	return bonuses, nil
+}
+
+// CreateRule нормализует, валидирует и сохраняет правило.
+func (service *Service) CreateRule(ctx context.Context, rule model.Rule) (model.Rule, error) {
+	rule = normalizeRule(rule)
+	if err := rule.Validate(); err != nil {
+		return model.Rule{}, err
+	}
+	return service.repo.Create(ctx, rule)
+}
+
+// GetRule возвращает правило по ID.
+func (service *Service) GetRule(ctx context.Context, id int64) (model.Rule, error) {
+	return service.repo.Get(ctx, id)
+}
+
+// ListRules возвращает все правила.
+func (service *Service) ListRules(ctx context.Context) ([]model.Rule, error) {
+	return service.repo.List(ctx)
+}
+
+// UpdateRule нормализует, валидирует и обновляет правило.
+func (service *Service) UpdateRule(ctx context.Context, rule model.Rule) (model.Rule, error) {
+	rule = normalizeRule(rule)
+	if err := rule.Validate(); err != nil {
+		return model.Rule{}, err
+	}
+	return service.repo.Update(ctx, rule)
+}
+
+// DeleteRule удаляет правило.
+func (service *Service) DeleteRule(ctx context.Context, id int64) error {
+	return service.repo.Delete(ctx, id)
+}
+
+func normalizeRule(rule model.Rule) model.Rule {
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Effect.Type = model.EffectType(strings.ToUpper(strings.TrimSpace(string(rule.Effect.Type))))
+	for i, tier := range rule.Conditions.Tiers {
+		rule.Conditions.Tiers[i] = strings.ToUpper(strings.TrimSpace(tier))
+	}
+	return rule
+}
+
+var _ promotionsvc.PromotionService = (*Service)(nil)
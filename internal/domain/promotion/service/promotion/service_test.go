@@ -0,0 +1,128 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/promotion/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockRuleRepo struct {
+	rules    []model.Rule
+	facts    model.Facts
+	listErr  error
+	factsErr error
+	created  *model.Rule
+}
+
+func (m *mockRuleRepo) Create(ctx context.Context, rule model.Rule) (model.Rule, error) {
+	rule.ID = 1
+	m.created = &rule
+	return rule, nil
+}
+func (m *mockRuleRepo) Get(context.Context, int64) (model.Rule, error) {
+	return model.Rule{}, model.ErrRuleNotFound
+}
+func (m *mockRuleRepo) List(context.Context) ([]model.Rule, error) { return m.rules, nil }
+func (m *mockRuleRepo) ListActive(context.Context) ([]model.Rule, error) {
+	return m.rules, m.listErr
+}
+func (m *mockRuleRepo) Update(ctx context.Context, rule model.Rule) (model.Rule, error) {
+	return rule, nil
+}
+func (m *mockRuleRepo) Delete(context.Context, int64) error { return nil }
+func (m *mockRuleRepo) OrderFacts(context.Context, string) (model.Facts, error) {
+	return m.facts, m.factsErr
+}
+
+func TestService_Evaluate_StacksMatchingRules(t *testing.T) {
+	saturday := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &mockRuleRepo{
+		facts: model.Facts{UserID: 7, UploadedAt: saturday, FirstOrder: true, Tier: "BASE"},
+		rules: []model.Rule{
+			{
+				ID: 1, Name: "weekend", Active: true,
+				Conditions: model.Conditions{Weekdays: []time.Weekday{time.Saturday, time.Sunday}},
+				Effect:     model.Effect{Type: model.EffectMultiplier, Value: decimal.NewFromInt(2)},
+			},
+			{
+				ID: 2, Name: "welcome", Active: true,
+				Conditions: model.Conditions{FirstOrder: true},
+				Effect:     model.Effect{Type: model.EffectFixed, Value: decimal.NewFromInt(50)},
+			},
+			{
+				ID: 3, Name: "gold", Active: true,
+				Conditions: model.Conditions{Tiers: []string{"GOLD"}},
+				Effect:     model.Effect{Type: model.EffectMultiplier, Value: decimal.NewFromInt(3)},
+			},
+		},
+	}
+	svc := NewService(repo)
+
+	bonuses, err := svc.Evaluate(context.Background(), "123", decimal.NewFromInt(100))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bonuses) != 2 {
+		t.Fatalf("expected 2 bonuses, got %+v", bonuses)
+	}
+	if bonuses[0].RuleID != 1 || !bonuses[0].Amount.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("unexpected weekend bonus: %+v", bonuses[0])
+	}
+	if bonuses[1].RuleID != 2 || !bonuses[1].Amount.Equal(decimal.NewFromInt(50)) {
+		t.Fatalf("unexpected welcome bonus: %+v", bonuses[1])
+	}
+	if bonuses[0].UserID != 7 || bonuses[0].OrderNumber != "123" || bonuses[0].RuleName != "weekend" {
+		t.Fatalf("bonus is not traceable: %+v", bonuses[0])
+	}
+}
+
+func TestService_Evaluate_NoActiveRules(t *testing.T) {
+	repo := &mockRuleRepo{factsErr: errors.New("must not be called")}
+	svc := NewService(repo)
+
+	bonuses, err := svc.Evaluate(context.Background(), "123", decimal.NewFromInt(100))
+	if err != nil || bonuses != nil {
+		t.Fatalf("expected no bonuses and no error, got %v, %v", bonuses, err)
+	}
+}
+
+func TestService_Evaluate_PropagatesErrors(t *testing.T) {
+	repoErr := errors.New("db error")
+	active := []model.Rule{{ID: 1, Name: "r", Active: true}}
+
+	for _, repo := range []*mockRuleRepo{{listErr: repoErr}, {rules: active, factsErr: repoErr}} {
+		if _, err := NewService(repo).Evaluate(context.Background(), "123", decimal.NewFromInt(1)); !errors.Is(err, repoErr) {
+			t.Fatalf("want %v, got %v", repoErr, err)
+		}
+	}
+}
+
+func TestService_CreateRule_NormalizesAndValidates(t *testing.T) {
+	repo := &mockRuleRepo{}
+	svc := NewService(repo)
+
+	rule, err := svc.CreateRule(context.Background(), model.Rule{
+		Name:       "  gold boost ",
+		Conditions: model.Conditions{Tiers: []string{" gold"}},
+		Effect:     model.Effect{Type: "multiplier", Value: decimal.RequireFromString("1.5")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if rule.Name != "gold boost" || rule.Effect.Type != model.EffectMultiplier || rule.Conditions.Tiers[0] != "GOLD" {
+		t.Fatalf("rule was not normalized: %+v", rule)
+	}
+
+	repo.created = nil
+	if _, err := svc.CreateRule(context.Background(), model.Rule{Name: "bad"}); !errors.Is(err, model.ErrInvalidRule) {
+		t.Fatalf("want %v, got %v", model.ErrInvalidRule, err)
+	}
+	if repo.created != nil {
+		t.Fatalf("did not expect invalid rule to be saved")
+	}
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"loyalty/internal/domain/promotion/model"
+)
+
+// PromotionUsecase описывает административные сценарии управления правилами акций.
+type PromotionUsecase interface {
+	CreateRule(ctx context.Context, rule model.Rule) (model.Rule, error)
+	GetRule(ctx context.Context, id int64) (model.Rule, error)
+	ListRules(ctx context.Context) ([]model.Rule, error)
+	UpdateRule(ctx context.Context, rule model.Rule) (model.Rule, error)
+	DeleteRule(ctx context.Context, id int64) error
+}
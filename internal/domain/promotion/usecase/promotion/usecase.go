@@ -0,0 +1,62 @@
+package promotion
+
+import (
+	"context"
+
+	"loyalty/internal/domain/promotion/model"
+	promotionsvc "loyalty/internal/domain/promotion/service"
+	"loyalty/internal/domain/promotion/usecase"
+	"loyalty/internal/tracing"
+)
+
+// Usecase — реализация usecase.PromotionUsecase.
+type Usecase struct {
+	promotionService promotionsvc.PromotionService
+}
+
+// NewUsecase создаёт usecase управления акциями.
+func NewUsecase(promotionService promotionsvc.PromotionService) *Usecase {
+	return &Usecase{promotionService: promotionService}
+}
+
+// CreateRule создаёт правило акции.
+func (usecase *Usecase) CreateRule(ctx context.Context, rule model.Rule) (_ model.Rule, err error) {
+	ctx, span := tracing.Start(ctx, "PromotionUsecase.CreateRule")
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.promotionService.CreateRule(ctx, rule)
+}
+
+// GetRule возвращает правило акции по ID.
+func (usecase *Usecase) GetRule(ctx context.Context, id int64) (_ model.Rule, err error) {
+	ctx, span := tracing.Start(ctx, "PromotionUsecase.GetRule")
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.promotionService.GetRule(ctx, id)
+}
+
+// ListRules возвращает все правила акций.
+func (usecase *Usecase) ListRules(ctx context.Context) (_ []model.Rule, err error) {
+	ctx, span := tracing.Start(ctx, "PromotionUsecase.ListRules")
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.promotionService.ListRules(ctx)
+}
+
+// UpdateRule обновляет правило акции.
+func (usecase *Usecase) UpdateRule(ctx context.Context, rule model.Rule) (_ model.Rule, err error) {
+	ctx, span := tracing.Start(ctx, "PromotionUsecase.UpdateRule")
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.promotionService.UpdateRule(ctx, rule)
+}
+
+// DeleteRule удаляет правило акции.
+func (usecase *Usecase) DeleteRule(ctx context.Context, id int64) (err error) {
+	ctx, span := tracing.Start(ctx, "PromotionUsecase.DeleteRule")
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.promotionService.DeleteRule(ctx, id)
+}
+
+var _ usecase.PromotionUsecase = (*Usecase)(nil)
@@ -0,0 +1,62 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"loyalty/internal/domain/promotion/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockPromotionService struct {
+	err     error
+	deleted int64
+}
+
+func (m *mockPromotionService) Evaluate(context.Context, string, decimal.Decimal) ([]model.Bonus, error) {
+	return nil, m.err
+}
+func (m *mockPromotionService) CreateRule(ctx context.Context, rule model.Rule) (model.Rule, error) {
+	return rule, m.err
+}
+func (m *mockPromotionService) GetRule(ctx context.Context, id int64) (model.Rule, error) {
+	return model.Rule{ID: id}, m.err
+}
+func (m *mockPromotionService) ListRules(context.Context) ([]model.Rule, error) { return nil, m.err }
+func (m *mockPromotionService) UpdateRule(ctx context.Context, rule model.Rule) (model.Rule, error) {
+	return rule, m.err
+}
+func (m *mockPromotionService) DeleteRule(ctx context.Context, id int64) error {
+	m.deleted = id
+	return m.err
+}
+
+func TestUsecase_DelegatesToService(t *testing.T) {
+	svc := &mockPromotionService{}
+	uc := NewUsecase(svc)
+
+	rule, err := uc.GetRule(context.Background(), 5)
+	if err != nil || rule.ID != 5 {
+		t.Fatalf("GetRule() = %+v, %v", rule, err)
+	}
+	if err := uc.DeleteRule(context.Background(), 5); err != nil || svc.deleted != 5 {
+		t.Fatalf("DeleteRule() err = %v, deleted = %d", err, svc.deleted)
+	}
+}
+
+func TestUsecase_PropagatesErrors(t *testing.T) {
+	svcErr := errors.New("db error")
+	uc := NewUsecase(&mockPromotionService{err: svcErr})
+
+	if _, err := uc.CreateRule(context.Background(), model.Rule{}); !errors.Is(err, svcErr) {
+		t.Fatalf("CreateRule() want %v, got %v", svcErr, err)
+	}
+	if _, err := uc.ListRules(context.Background()); !errors.Is(err, svcErr) {
+		t.Fatalf("ListRules() want %v, got %v", svcErr, err)
+	}
+	if _, err := uc.UpdateRule(context.Background(), model.Rule{}); !errors.Is(err, svcErr) {
+		t.Fatalf("UpdateRule() want %v, got %v", svcErr, err)
+	}
+}
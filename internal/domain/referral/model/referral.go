@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidReferralCode возвращается, если реферальный код не найден или имеет неверный формат.
+	ErrInvalidReferralCode = errors.New("invalid referral code")
+	// ErrSelfReferral возвращается при попытке пригласить самого себя.
+	ErrSelfReferral = errors.New("self referral is not allowed")
+)
+
+// maxCodeLength — максимальная длина реферального кода во входных данных.
+const maxCodeLength = 32
+
+// Policy — параметры реферальной программы.
+type Policy struct {
+	// Bonus — сумма, зачисляемая пригласившему за каждого приглашённого.
+	Bonus decimal.Decimal
+	// MaxRewards — максимальное количество вознаграждений на одного пригласившего.
+	MaxRewards int
+}
+
+// Referral — приглашённый пользователь.
+type Referral struct {
+	RefereeID    int64
+	RefereeLogin string
+	CreatedAt    time.Time
+	Rewarded     bool
+}
+
+// Candidate — данные для решения о вознаграждении пригласившего по заказу приглашённого.
+type Candidate struct {
+	ReferrerID int64
+	RefereeID  int64
+	// FirstProcessed — заказ является первым обработанным заказом приглашённого.
+	FirstProcessed bool
+	// Rewarded — вознаграждение за приглашённого уже начислено.
+	Rewarded bool
+	// ReferrerRewards — количество вознаграждений, уже полученных пригласившим.
+	ReferrerRewards int
+}
+
+// Reward — вознаграждение пригласившего, зачисляемое вместе с начислением по заказу приглашённого.
+type Reward struct {
+	ReferrerID  int64
+	RefereeID   int64
+	OrderNumber string
+	Amount      decimal.Decimal
+	// MaxRewards — лимит вознаграждений пригласившего, перепроверяется при записи.
+	MaxRewards int
+	CreatedAt  time.Time
+}
+
+// Bonus — начисленное пригласившему вознаграждение.
+type Bonus struct {
+	RefereeLogin string
+	OrderNumber  string
+	Amount       decimal.Decimal
+	CreatedAt    time.Time
+}
+
+// Summary — реферальный код пользователя, приглашённые и полученные вознаграждения.
+type Summary struct {
+	Code      string
+	Referrals []Referral
+	Bonuses   []Bonus
+	Total     decimal.Decimal
+}
+
+// NormalizeCode приводит код к каноничному виду (trim + верхний регистр) и проверяет длину.
+func NormalizeCode(code string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if normalized == "" || len(normalized) > maxCodeLength {
+		return "", ErrInvalidReferralCode
+	}
+	return normalized, nil
+}
+
+// Eligible сообщает, положено ли пригласившему вознаграждение по кандидату.
+func (candidate Candidate) Eligible(policy Policy) bool {
+	if candidate.ReferrerID == 0 || candidate.ReferrerID == candidate.RefereeID {
+		return false
+	}
+	if !candidate.FirstProcessed || candidate.Rewarded {
+		return false
+	}
+	if policy.Bonus.LessThanOrEqual(decimal.Zero) {
+		return false
+	}
+	return policy.MaxRewards <= 0 || candidate.ReferrerRewards < policy.MaxRewards
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestNormalizeCode(t *testing.T) {
+	got, err := NormalizeCode("  ab12cd ")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got != "AB12CD" {
+		t.Fatalf("want %q, got %q", "AB12CD", got)
+	}
+
+	if _, err := NormalizeCode("   "); !errors.Is(err, ErrInvalidReferralCode) {
+		t.Fatalf("want ErrInvalidReferralCode, got %v", err)
+	}
+}
+
+func TestCandidate_Eligible(t *testing.T) {
+	policy := Policy{Bonus: decimal.NewFromInt(100), MaxRewards: 2}
+	base := Candidate{ReferrerID: 1, RefereeID: 2, FirstProcessed: true}
+
+	tests := []struct {
+		name      string
+		candidate func(Candidate) Candidate
+		policy    Policy
+		want      bool
+	}{
+		{name: "eligible", candidate: func(c Candidate) Candidate { return c }, policy: policy, want: true},
+		{name: "no referrer", candidate: func(c Candidate) Candidate { c.ReferrerID = 0; return c }, policy: policy},
+		{name: "self referral", candidate: func(c Candidate) Candidate { c.RefereeID = 1; return c }, policy: policy},
+		{name: "not first order", candidate: func(c Candidate) Candidate { c.FirstProcessed = false; return c }, policy: policy},
+		{name: "already rewarded", candidate: func(c Candidate) Candidate { c.Rewarded = true; return c }, policy: policy},
+		{name: "cap reached", candidate: func(c Candidate) Candidate { c.ReferrerRewards = 2; return c }, policy: policy},
+		{
+			name:      "no cap",
+			candidate: func(c Candidate) Candidate { c.ReferrerRewards = 100; return c },
+			policy:    Policy{Bonus: decimal.NewFromInt(100)},
+			want:      true,
+		},
+		{name: "zero bonus", candidate: func(c Candidate) Candidate { return c }, policy: Policy{MaxRewards: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.candidate(base).Eligible(tt.policy); got != tt.want {
+				t.Fatalf("Eligible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
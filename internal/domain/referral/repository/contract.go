@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"loyalty/internal/domain/referral/model"
+)
+
+// ReferralRepository — порт хранилища реферальной программы.
+type ReferralRepository interface {
+	// Code возвращает реферальный код пользователя.
+	Code(ctx context.Context, userID int64) (string, error)
+
+	// FindReferrer возвращает ID владельца кода или model.ErrInvalidReferralCode.
+	FindReferrer(ctx context.Context, code string) (int64, error)
+
+	// Candidate возвращает данные о приглашении для автора заказа (ReferrerID == 0, если приглашения нет).
+	Candidate(ctx context.Context, orderNumber string) (model.Candidate, error)
+
+	// ListReferrals возвращает приглашённых пользователем (новые первыми).
+	ListReferrals(ctx context.Context, referrerID int64) ([]model.Referral, error)
+
+	// ListBonuses возвращает вознаграждения пользователя за приглашения (новые первыми).
+	ListBonuses(ctx context.Context, referrerID int64) ([]model.Bonus, error)
+}
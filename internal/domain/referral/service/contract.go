@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"loyalty/internal/domain/referral/model"
+)
+
+// ReferralService — доменный сервис реферальной программы.
+type ReferralService interface {
+	// ResolveCode возвращает ID владельца реферального кода.
+	ResolveCode(ctx context.Context, code string) (referrerID int64, err error)
+
+	// Evaluate возвращает вознаграждение пригласившего по заказу или nil, если оно не положено.
+	Evaluate(ctx context.Context, orderNumber string) (*model.Reward, error)
+
+	// GetSummary возвращает код пользователя, приглашённых и полученные вознаграждения.
+	GetSummary(ctx context.Context, userID int64) (model.Summary, error)
+}
@@ -0,0 +1,83 @@
+package referral
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"loyalty/internal/domain/referral/model"
+	referralrepo "loyalty/internal/domain/referral/repository"
+	referralsvc "loyalty/internal/domain/referral/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// Service — реализация referralsvc.ReferralService.
+type Service struct {
+	repo   referralrepo.ReferralRepository
+	policy model.Policy
+	now    func() time.Time
+}
+
+// NewService создаёт прикладной сервис реферальной программы.
+func NewService(repo referralrepo.ReferralRepository, policy model.Policy) *Service {
+	return &Service{repo: repo, policy: policy, now: time.Now}
+}
+
+// ResolveCode нормализует код и возвращает ID его владельца.
+func (service *Service) ResolveCode(ctx context.Context, code string) (int64, error) {
+	normalized, err := model.NormalizeCode(code)
+	if err != nil {
+		return 0, err
+	}
+	return service.repo.FindReferrer(ctx, normalized)
+}
+
+// Evaluate возвращает вознаграждение пригласившего, если заказ — первый обработанный заказ приглашённого,
+// вознаграждение за него ещё не начислялось и лимит пригласившего не исчерпан.
+func (service *Service) Evaluate(ctx context.Context, orderNumber string) (*model.Reward, error) {
+	if service.policy.Bonus.LessThanOrEqual(decimal.Zero) {
+		return nil, nil
+	}
+
+	candidate, err := service.repo.Candidate(ctx, orderNumber)
+	if err != nil {
+		return nil, fmt.Errorf("referral candidate: %w", err)
+	}
+	if !candidate.Eligible(service.policy) {
+		return nil, nil
+	}
+
+	return &model.Reward{
+		ReferrerID:  candidate.ReferrerID,
+		RefereeID:   candidate.RefereeID,
+		OrderNumber: orderNumber,
+		Amount:      service.policy.Bonus,
+		MaxRewards:  service.policy.MaxRewards,
+		CreatedAt:   service.now(),
+	}, nil
+}
+
+// GetSummary возвращает реферальный код пользователя, приглашённых и полученные вознаграждения.
+func (service *Service) GetSummary(ctx context.Context, userID int64) (model.Summary, error) {
+	code, err := service.repo.Code(ctx, userID)
+	if err != nil {
+		return model.Summary{}, fmt.Errorf("referral code: %w", err)
+	}
+	referrals, err := service.repo.ListReferrals(ctx, userID)
+	if err != nil {
+		return model.Summary{}, fmt.Errorf("list referrals: %w", err)
+	}
+	bonuses, err := service.repo.ListBonuses(ctx, userID)
+	if err != nil {
+		return model.Summary{}, fmt.Errorf("list referral bonuses: %w", err)
+	}
+
+	total := decimal.Zero
+	for _, bonus := range bonuses {
+		total = total.Add(bonus.Amount)
+	}
+	return model.Summary{Code: code, Referrals: referrals, Bonuses: bonuses, Total: total}, nil
+}
+
+var _ referralsvc.ReferralService = (*Service)(nil)
@@ -0,0 +1,113 @@
+package referral
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/referral/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockRepo struct {
+	codes     map[string]int64
+	candidate model.Candidate
+	bonuses   []model.Bonus
+	err       error
+}
+
+func (m *mockRepo) Code(context.Context, int64) (string, error) { return "CODE", m.err }
+
+func (m *mockRepo) FindReferrer(_ context.Context, code string) (int64, error) {
+	id, ok := m.codes[code]
+	if !ok {
+		return 0, model.ErrInvalidReferralCode
+	}
+	return id, nil
+}
+
+func (m *mockRepo) Candidate(context.Context, string) (model.Candidate, error) {
+	return m.candidate, m.err
+}
+
+func (m *mockRepo) ListReferrals(context.Context, int64) ([]model.Referral, error) { return nil, m.err }
+
+func (m *mockRepo) ListBonuses(context.Context, int64) ([]model.Bonus, error) {
+	return m.bonuses, m.err
+}
+
+func testPolicy() model.Policy {
+	return model.Policy{Bonus: decimal.NewFromInt(100), MaxRewards: 3}
+}
+
+func TestService_ResolveCode_NormalizesCode(t *testing.T) {
+	svc := NewService(&mockRepo{codes: map[string]int64{"ABC123": 7}}, testPolicy())
+
+	id, err := svc.ResolveCode(context.Background(), " abc123 ")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if id != 7 {
+		t.Fatalf("want referrer 7, got %d", id)
+	}
+
+	if _, err := svc.ResolveCode(context.Background(), "missing"); !errors.Is(err, model.ErrInvalidReferralCode) {
+		t.Fatalf("want ErrInvalidReferralCode, got %v", err)
+	}
+}
+
+func TestService_Evaluate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("eligible", func(t *testing.T) {
+		repo := &mockRepo{candidate: model.Candidate{ReferrerID: 1, RefereeID: 2, FirstProcessed: true, ReferrerRewards: 1}}
+		svc := NewService(repo, testPolicy())
+		svc.now = func() time.Time { return now }
+
+		reward, err := svc.Evaluate(context.Background(), "123")
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if reward == nil {
+			t.Fatalf("expected reward")
+		}
+		if reward.ReferrerID != 1 || reward.RefereeID != 2 || reward.OrderNumber != "123" {
+			t.Fatalf("unexpected reward: %+v", reward)
+		}
+		if !reward.Amount.Equal(decimal.NewFromInt(100)) || reward.MaxRewards != 3 || !reward.CreatedAt.Equal(now) {
+			t.Fatalf("unexpected reward: %+v", reward)
+		}
+	})
+
+	t.Run("not eligible", func(t *testing.T) {
+		repo := &mockRepo{candidate: model.Candidate{ReferrerID: 1, RefereeID: 2, FirstProcessed: true, Rewarded: true}}
+		reward, err := NewService(repo, testPolicy()).Evaluate(context.Background(), "123")
+		if err != nil || reward != nil {
+			t.Fatalf("want nil reward, got %+v, err %v", reward, err)
+		}
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo := &mockRepo{err: errors.New("db error")}
+		if _, err := NewService(repo, testPolicy()).Evaluate(context.Background(), "123"); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
+
+func TestService_GetSummary_SumsBonuses(t *testing.T) {
+	repo := &mockRepo{bonuses: []model.Bonus{{Amount: decimal.NewFromInt(100)}, {Amount: decimal.NewFromInt(50)}}}
+
+	summary, err := NewService(repo, testPolicy()).GetSummary(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if summary.Code != "CODE" {
+		t.Fatalf("want code %q, got %q", "CODE", summary.Code)
+	}
+	if !summary.Total.Equal(decimal.NewFromInt(150)) {
+		t.Fatalf("want total 150, got %s", summary.Total)
+	}
+}
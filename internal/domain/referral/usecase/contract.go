@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"loyalty/internal/domain/referral/model"
+)
+
+// ReferralUsecase описывает пользовательские сценарии реферальной программы.
+type ReferralUsecase interface {
+	GetSummary(ctx context.Context, userID int64) (model.Summary, error)
+}
@@ -0,0 +1,30 @@
+package referral
+
+import (
+	"context"
+
+	"loyalty/internal/domain/referral/model"
+	referralsvc "loyalty/internal/domain/referral/service"
+	"loyalty/internal/domain/referral/usecase"
+	"loyalty/internal/tracing"
+)
+
+// Usecase — реализация usecase.ReferralUsecase.
+type Usecase struct {
+	referralService referralsvc.ReferralService
+}
+
+// NewUsecase создаёт usecase реферальной программы.
+func NewUsecase(referralService referralsvc.ReferralService) *Usecase {
+	return &Usecase{referralService: referralService}
+}
+
+// GetSummary возвращает реферальный код пользователя, приглашённых и вознаграждения.
+func (usecase *Usecase) GetSummary(ctx context.Context, userID int64) (_ model.Summary, err error) {
+	ctx, span := tracing.Start(ctx, "ReferralUsecase.GetSummary", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.referralService.GetSummary(ctx, userID)
+}
+
+var _ usecase.ReferralUsecase = (*Usecase)(nil)
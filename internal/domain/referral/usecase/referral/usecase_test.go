@@ -0,0 +1,52 @@
+package referral
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"loyalty/internal/domain/referral/model"
+)
+
+type mockReferralService struct {
+	summary model.Summary
+	err     error
+}
+
+func (m *mockReferralService) ResolveCode(context.Context, string) (int64, error) { return 0, m.err }
+func (m *mockReferralService) Evaluate(context.Context, string) (*model.Reward, error) {
+	return nil, m.err
+}
+
+func (m *mockReferralService) GetSummary(context.Context, int64) (model.Summary, error) {
+	return m.summary, m.err
+}
+
+func TestUsecase_GetSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     *mockReferralService
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			svc:     &mockReferralService{summary: model.Summary{Code: "ABC"}},
+			wantErr: false,
+		},
+		{
+			name:    "service error",
+			svc:     &mockReferralService{err: errors.New("db error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUsecase(tt.svc)
+			_, err := uc.GetSummary(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSummary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
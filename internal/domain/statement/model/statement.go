@@ -0,0 +1,111 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidQuery возвращается при некорректных параметрах выписки (диапазон дат, пагинация).
+var ErrInvalidQuery = errors.New("invalid statement query")
+
+// ErrBalanceMismatch возвращается сверкой, если баланс хотя бы одного счёта расходится с суммой операций.
+var ErrBalanceMismatch = errors.New("balance mismatch")
+
+const (
+	// DefaultPageSize — размер страницы выписки по умолчанию.
+	DefaultPageSize = 50
+	// MaxPageSize — максимальный размер страницы выписки.
+	MaxPageSize = 1000
+)
+
+// EntryType — тип операции в выписке.
+type EntryType string
+
+const (
+	// EntryAccrual — начисление по заказу (с учётом множителя уровня).
+	EntryAccrual EntryType = "ACCRUAL"
+	// EntryPromotionBonus — бонус по акции.
+	EntryPromotionBonus EntryType = "PROMOTION_BONUS"
+	// EntryReferralBonus — вознаграждение за приглашённого пользователя.
+	EntryReferralBonus EntryType = "REFERRAL_BONUS"
+	// EntryTransferIn — входящий перевод.
+	EntryTransferIn EntryType = "TRANSFER_IN"
+	// EntryTransferOut — исходящий перевод.
+	EntryTransferOut EntryType = "TRANSFER_OUT"
+	// EntryWithdrawal — списание в счёт оплаты заказа.
+	EntryWithdrawal EntryType = "WITHDRAWAL"
+	// EntryAdjustment — ручная корректировка баланса поддержкой (знак суммы задаёт направление).
+	EntryAdjustment EntryType = "ADJUSTMENT"
+)
+
+// Query — параметры выписки: полуинтервал [From, To) и страница.
+type Query struct {
+	UserID int64
+	From   *time.Time
+	To     *time.Time
+	// Page — номер страницы, начиная с 1.
+	Page int
+	// PageSize — размер страницы; 0 — все операции диапазона.
+	PageSize int
+}
+
+// Validate проверяет диапазон дат и параметры пагинации.
+func (query Query) Validate() error {
+	if query.UserID <= 0 || query.Page < 1 || query.PageSize < 0 || query.PageSize > MaxPageSize {
+		return ErrInvalidQuery
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return ErrInvalidQuery
+	}
+	return nil
+}
+
+// Offset возвращает количество операций, пропускаемых до текущей страницы.
+func (query Query) Offset() int {
+	if query.PageSize == 0 {
+		return 0
+	}
+	return (query.Page - 1) * query.PageSize
+}
+
+// Entry — операция выписки со знаковой суммой и остатком после неё.
+type Entry struct {
+	Type EntryType
+	// Reference — номер заказа (если операция с ним связана).
+	Reference string
+	// Description — уточнение: название акции, логин второй стороны перевода или приглашённого,
+	// причина ручной корректировки.
+	Description string
+	Amount      decimal.Decimal
+	Balance     decimal.Decimal
+	At          time.Time
+}
+
+// Summary — итоги выписки за диапазон.
+type Summary struct {
+	OpeningBalance decimal.Decimal
+	ClosingBalance decimal.Decimal
+	TotalCredit    decimal.Decimal
+	TotalDebit     decimal.Decimal
+	// Count — количество операций в диапазоне (без учёта пагинации).
+	Count int
+}
+
+// Statement — выписка по счёту пользователя.
+type Statement struct {
+	Query       Query
+	Summary     Summary
+	Entries     []Entry
+	GeneratedAt time.Time
+}
+
+// Mismatch — расхождение баланса счёта с суммой операций по нему.
+type Mismatch struct {
+	UserID int64
+	// Ledger — сумма всех операций выписки.
+	Ledger decimal.Decimal
+	// Current — текущий баланс счёта.
+	Current decimal.Decimal
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQuery_Validate(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name    string
+		query   Query
+		wantErr bool
+	}{
+		{name: "valid", query: Query{UserID: 1, From: &from, To: &to, Page: 1, PageSize: 50}},
+		{name: "no range, all entries", query: Query{UserID: 1, Page: 1}},
+		{name: "reversed range", query: Query{UserID: 1, From: &to, To: &from, Page: 1, PageSize: 50}, wantErr: true},
+		{name: "empty range", query: Query{UserID: 1, From: &from, To: &from, Page: 1, PageSize: 50}, wantErr: true},
+		{name: "zero page", query: Query{UserID: 1, Page: 0, PageSize: 50}, wantErr: true},
+		{name: "page size too large", query: Query{UserID: 1, Page: 1, PageSize: MaxPageSize + 1}, wantErr: true},
+		{name: "no user", query: Query{Page: 1, PageSize: 50}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.wantErr && !errors.Is(err, ErrInvalidQuery) {
+				t.Fatalf("want ErrInvalidQuery, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+		})
+	}
+}
+
+func TestQuery_Offset(t *testing.T) {
+	if got := (Query{Page: 3, PageSize: 20}).Offset(); got != 40 {
+		t.Fatalf("want 40, got %d", got)
+	}
+	if got := (Query{Page: 3}).Offset(); got != 0 {
+		t.Fatalf("want 0 without pagination, got %d", got)
+	}
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"loyalty/internal/domain/statement/model"
+)
+
+// StatementRepository — порт чтения операций по счёту для выписки.
+type StatementRepository interface {
+	// Statement возвращает страницу операций (по возрастанию времени, с остатком после каждой)
+	// и итоги за диапазон, согласованные между собой.
+	Statement(ctx context.Context, query model.Query) ([]model.Entry, model.Summary, error)
+}
+
+// ReconciliationRepository — порт сверки балансов счетов с операциями.
+type ReconciliationRepository interface {
+	// Mismatches возвращает счета, баланс которых не равен сумме операций, в одном снимке данных.
+	Mismatches(ctx context.Context) ([]model.Mismatch, error)
+}
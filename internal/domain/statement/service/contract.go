@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"loyalty/internal/domain/statement/model"
+)
+
+// StatementService формирует выписку по счёту пользователя.
+type StatementService interface {
+	GetStatement(ctx context.Context, query model.Query) (model.Statement, error)
+}
+
+// ReconciliationService сверяет балансы счетов с операциями по ним.
+type ReconciliationService interface {
+	// Reconcile возвращает найденные расхождения; если они есть, ошибка оборачивает model.ErrBalanceMismatch.
+	Reconcile(ctx context.Context) ([]model.Mismatch, error)
+}
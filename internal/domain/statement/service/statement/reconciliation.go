@@ -0,0 +1,34 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+
+	"loyalty/internal/domain/statement/model"
+	statementrepo "loyalty/internal/domain/statement/repository"
+	statementsvc "loyalty/internal/domain/statement/service"
+)
+
+// ReconciliationService — реализация statementsvc.ReconciliationService.
+type ReconciliationService struct {
+	repo statementrepo.ReconciliationRepository
+}
+
+// NewReconciliationService создаёт сервис сверки балансов.
+func NewReconciliationService(repo statementrepo.ReconciliationRepository) *ReconciliationService {
+	return &ReconciliationService{repo: repo}
+}
+
+// Reconcile ищет счета, баланс которых расходится с выпиской.
+func (service *ReconciliationService) Reconcile(ctx context.Context) ([]model.Mismatch, error) {
+	mismatches, err := service.repo.Mismatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find balance mismatches: %w", err)
+	}
+	if len(mismatches) > 0 {
+		return mismatches, fmt.Errorf("%w: %d accounts, first user_id=%d", model.ErrBalanceMismatch, len(mismatches), mismatches[0].UserID)
+	}
+	return nil, nil
+}
+
+var _ statementsvc.ReconciliationService = (*ReconciliationService)(nil)
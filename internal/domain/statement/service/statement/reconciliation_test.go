@@ -0,0 +1,43 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"loyalty/internal/domain/statement/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockReconciliationRepo struct {
+	mismatches []model.Mismatch
+	err        error
+}
+
+func (m *mockReconciliationRepo) Mismatches(context.Context) ([]model.Mismatch, error) {
+	return m.mismatches, m.err
+}
+
+func TestReconciliationService_Reconcile(t *testing.T) {
+	svc := NewReconciliationService(&mockReconciliationRepo{})
+	if mismatches, err := svc.Reconcile(context.Background()); err != nil || len(mismatches) != 0 {
+		t.Fatalf("expected no mismatches, got %v, %v", mismatches, err)
+	}
+
+	mismatch := model.Mismatch{UserID: 7, Ledger: decimal.NewFromInt(100), Current: decimal.NewFromInt(90)}
+	svc = NewReconciliationService(&mockReconciliationRepo{mismatches: []model.Mismatch{mismatch}})
+	mismatches, err := svc.Reconcile(context.Background())
+	if !errors.Is(err, model.ErrBalanceMismatch) {
+		t.Fatalf("want ErrBalanceMismatch, got %v", err)
+	}
+	if len(mismatches) != 1 || mismatches[0].UserID != 7 {
+		t.Fatalf("unexpected mismatches: %+v", mismatches)
+	}
+
+	repoErr := errors.New("db down")
+	svc = NewReconciliationService(&mockReconciliationRepo{err: repoErr})
+	if _, err := svc.Reconcile(context.Background()); !errors.Is(err, repoErr) {
+		t.Fatalf("want repo error, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"loyalty/internal/domain/statement/model"
+	statementrepo "loyalty/internal/domain/statement/repository"
+	statementsvc "loyalty/internal/domain/statement/service"
+)
+
+// Service — реализация statementsvc.StatementService.
+type Service struct {
+	repo statementrepo.StatementRepository
+	now  func() time.Time
+}
+
+// NewService создаёт прикладной сервис выписок.
+func NewService(repo statementrepo.StatementRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// GetStatement валидирует параметры и формирует выписку.
+func (service *Service) GetStatement(ctx context.Context, query model.Query) (model.Statement, error) {
+	if err := query.Validate(); err != nil {
+		return model.Statement{}, err
+	}
+
+	entries, summary, err := service.repo.Statement(ctx, query)
+	if err != nil {
+		return model.Statement{}, fmt.Errorf("load statement: %w", err)
+	}
+	return model.Statement{
+		Query:       query,
+		Summary:     summary,
+		Entries:     entries,
+		GeneratedAt: service.now(),
+	}, nil
+}
+
+var _ statementsvc.StatementService = (*Service)(nil)
@@ -0,0 +1,62 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/statement/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockRepo struct {
+	entries []model.Entry
+	summary model.Summary
+	err     error
+	called  bool
+}
+
+func (m *mockRepo) Statement(context.Context, model.Query) ([]model.Entry, model.Summary, error) {
+	m.called = true
+	return m.entries, m.summary, m.err
+}
+
+func TestService_GetStatement_InvalidQuery(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo)
+
+	if _, err := svc.GetStatement(context.Background(), model.Query{UserID: 1}); !errors.Is(err, model.ErrInvalidQuery) {
+		t.Fatalf("want ErrInvalidQuery, got %v", err)
+	}
+	if repo.called {
+		t.Fatalf("did not expect repo to be called")
+	}
+}
+
+func TestService_GetStatement(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	repo := &mockRepo{
+		entries: []model.Entry{{Type: model.EntryAccrual, Amount: decimal.NewFromInt(100), Balance: decimal.NewFromInt(100)}},
+		summary: model.Summary{ClosingBalance: decimal.NewFromInt(100), Count: 1},
+	}
+	svc := NewService(repo)
+	svc.now = func() time.Time { return now }
+
+	got, err := svc.GetStatement(context.Background(), model.Query{UserID: 1, Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got.Entries) != 1 || got.Summary.Count != 1 || !got.GeneratedAt.Equal(now) {
+		t.Fatalf("unexpected statement: %+v", got)
+	}
+}
+
+func TestService_GetStatement_RepoError(t *testing.T) {
+	svc := NewService(&mockRepo{err: errors.New("db error")})
+
+	if _, err := svc.GetStatement(context.Background(), model.Query{UserID: 1, Page: 1}); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"loyalty/internal/domain/statement/model"
+)
+
+// StatementUsecase описывает сценарий получения выписки по счёту.
+type StatementUsecase interface {
+	GetStatement(ctx context.Context, query model.Query) (model.Statement, error)
+}
@@ -0,0 +1,30 @@
+package statement
+
+import (
+	"context"
+
+	"loyalty/internal/domain/statement/model"
+	statementsvc "loyalty/internal/domain/statement/service"
+	"loyalty/internal/domain/statement/usecase"
+	"loyalty/internal/tracing"
+)
+
+// Usecase — реализация usecase.StatementUsecase.
+type Usecase struct {
+	statementService statementsvc.StatementService
+}
+
+// NewUsecase создаёт usecase выписок.
+func NewUsecase(statementService statementsvc.StatementService) *Usecase {
+	return &Usecase{statementService: statementService}
+}
+
+// GetStatement возвращает выписку по счёту пользователя.
+func (usecase *Usecase) GetStatement(ctx context.Context, query model.Query) (_ model.Statement, err error) {
+	ctx, span := tracing.Start(ctx, "StatementUsecase.GetStatement", tracing.UserID(query.UserID))
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.statementService.GetStatement(ctx, query)
+}
+
+var _ usecase.StatementUsecase = (*Usecase)(nil)
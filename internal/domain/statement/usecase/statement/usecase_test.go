@@ -0,0 +1,39 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"loyalty/internal/domain/statement/model"
+)
+
+type mockStatementService struct {
+	statement model.Statement
+	err       error
+}
+
+func (m *mockStatementService) GetStatement(context.Context, model.Query) (model.Statement, error) {
+	return m.statement, m.err
+}
+
+func TestUsecase_GetStatement(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     *mockStatementService
+		wantErr bool
+	}{
+		{name: "success", svc: &mockStatementService{statement: model.Statement{Entries: []model.Entry{{}}}}},
+		{name: "service error", svc: &mockStatementService{err: errors.New("db error")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUsecase(tt.svc)
+			_, err := uc.GetStatement(context.Background(), model.Query{UserID: 1, Page: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetStatement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
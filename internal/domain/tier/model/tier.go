@@ -0,0 +1,110 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Level — название уровня (тира) программы лояльности.
+type Level string
+
+const (
+	// LevelBase — базовый уровень, назначается пользователю, не достигшему ни одного порога.
+	LevelBase Level = "BASE"
+	// LevelSilver — серебряный уровень.
+	LevelSilver Level = "SILVER"
+	// LevelGold — золотой уровень.
+	LevelGold Level = "GOLD"
+	// LevelPlatinum — платиновый уровень.
+	LevelPlatinum Level = "PLATINUM"
+)
+
+// ErrInvalidRules возвращается при некорректном наборе правил уровней
+// (пустое имя, отрицательный порог, неположительный множитель, дубликаты).
+var ErrInvalidRules = errors.New("invalid tier rules")
+
+// Rule — правило уровня: порог начислений за скользящее окно и множитель начислений.
+type Rule struct {
+	Level      Level
+	Threshold  decimal.Decimal
+	Multiplier decimal.Decimal
+}
+
+// Rules — упорядоченный по возрастанию порога набор правил уровней.
+type Rules []Rule
+
+// BaseRule возвращает правило базового уровня (нулевой порог, множитель 1).
+func BaseRule() Rule {
+	return Rule{Level: LevelBase, Threshold: decimal.Zero, Multiplier: decimal.NewFromInt(1)}
+}
+
+// NewRules валидирует правила и сортирует их по возрастанию порога.
+func NewRules(rules []Rule) (Rules, error) {
+	seen := make(map[Level]struct{}, len(rules))
+	out := make(Rules, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Level == "" || rule.Level == LevelBase {
+			return nil, ErrInvalidRules
+		}
+		if rule.Threshold.LessThanOrEqual(decimal.Zero) || rule.Multiplier.LessThanOrEqual(decimal.Zero) {
+			return nil, ErrInvalidRules
+		}
+		if _, ok := seen[rule.Level]; ok {
+			return nil, ErrInvalidRules
+		}
+		seen[rule.Level] = struct{}{}
+		out = append(out, rule)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Threshold.LessThan(out[j].Threshold) })
+	for i := 1; i < len(out); i++ {
+		if out[i].Threshold.Equal(out[i-1].Threshold) {
+			return nil, ErrInvalidRules
+		}
+	}
+	return out, nil
+}
+
+// Resolve возвращает уровень, соответствующий сумме начислений, и следующий уровень (nil для максимального).
+func (rules Rules) Resolve(total decimal.Decimal) (current Rule, next *Rule) {
+	current = BaseRule()
+	for i := range rules {
+		if total.LessThan(rules[i].Threshold) {
+			next = &rules[i]
+			return current, next
+		}
+		current = rules[i]
+	}
+	return current, nil
+}
+
+// Multiplier возвращает множитель начислений для уровня (1, если уровень неизвестен).
+func (rules Rules) Multiplier(level Level) decimal.Decimal {
+	for _, rule := range rules {
+		if rule.Level == level {
+			return rule.Multiplier
+		}
+	}
+	return decimal.NewFromInt(1)
+}
+
+// UserTier — рассчитанный уровень пользователя.
+type UserTier struct {
+	UserID         int64
+	Level          Level
+	Multiplier     decimal.Decimal
+	RollingAccrual decimal.Decimal
+	RecalculatedAt time.Time
+}
+
+// Profile — уровень пользователя и прогресс до следующего уровня.
+type Profile struct {
+	Tier   UserTier
+	Window time.Duration
+	// Next — следующий уровень; nil, если пользователь уже на максимальном.
+	Next *Rule
+	// ToNext — сколько баллов осталось начислить для перехода на следующий уровень.
+	ToNext decimal.Decimal
+}
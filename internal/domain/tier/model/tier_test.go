@@ -0,0 +1,105 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func testRules(t *testing.T) Rules {
+	t.Helper()
+	rules, err := NewRules([]Rule{
+		{Level: LevelGold, Threshold: decimal.NewFromInt(5000), Multiplier: decimal.RequireFromString("1.1")},
+		{Level: LevelSilver, Threshold: decimal.NewFromInt(1000), Multiplier: decimal.NewFromInt(1)},
+		{Level: LevelPlatinum, Threshold: decimal.NewFromInt(15000), Multiplier: decimal.RequireFromString("1.25")},
+	})
+	if err != nil {
+		t.Fatalf("NewRules: %v", err)
+	}
+	return rules
+}
+
+func TestNewRules_SortsByThreshold(t *testing.T) {
+	rules := testRules(t)
+	want := []Level{LevelSilver, LevelGold, LevelPlatinum}
+	for i, level := range want {
+		if rules[i].Level != level {
+			t.Fatalf("rules[%d] = %s, want %s", i, rules[i].Level, level)
+		}
+	}
+}
+
+func TestNewRules_Invalid(t *testing.T) {
+	one := decimal.NewFromInt(1)
+	tests := []struct {
+		name  string
+		rules []Rule
+	}{
+		{"empty level", []Rule{{Level: "", Threshold: one, Multiplier: one}}},
+		{"base level", []Rule{{Level: LevelBase, Threshold: one, Multiplier: one}}},
+		{"zero threshold", []Rule{{Level: LevelSilver, Threshold: decimal.Zero, Multiplier: one}}},
+		{"zero multiplier", []Rule{{Level: LevelSilver, Threshold: one, Multiplier: decimal.Zero}}},
+		{"duplicate level", []Rule{
+			{Level: LevelSilver, Threshold: one, Multiplier: one},
+			{Level: LevelSilver, Threshold: decimal.NewFromInt(2), Multiplier: one},
+		}},
+		{"duplicate threshold", []Rule{
+			{Level: LevelSilver, Threshold: one, Multiplier: one},
+			{Level: LevelGold, Threshold: one, Multiplier: one},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRules(tt.rules); !errors.Is(err, ErrInvalidRules) {
+				t.Fatalf("want %v, got %v", ErrInvalidRules, err)
+			}
+		})
+	}
+}
+
+func TestRules_Resolve(t *testing.T) {
+	rules := testRules(t)
+	tests := []struct {
+		total    int64
+		want     Level
+		wantNext Level
+	}{
+		{0, LevelBase, LevelSilver},
+		{999, LevelBase, LevelSilver},
+		{1000, LevelSilver, LevelGold},
+		{4999, LevelSilver, LevelGold},
+		{5000, LevelGold, LevelPlatinum},
+		{15000, LevelPlatinum, ""},
+		{100000, LevelPlatinum, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.want), func(t *testing.T) {
+			current, next := rules.Resolve(decimal.NewFromInt(tt.total))
+			if current.Level != tt.want {
+				t.Fatalf("Resolve(%d) = %s, want %s", tt.total, current.Level, tt.want)
+			}
+			if tt.wantNext == "" {
+				if next != nil {
+					t.Fatalf("Resolve(%d) next = %s, want nil", tt.total, next.Level)
+				}
+				return
+			}
+			if next == nil || next.Level != tt.wantNext {
+				t.Fatalf("Resolve(%d) next = %v, want %s", tt.total, next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestRules_Multiplier(t *testing.T) {
+	rules := testRules(t)
+	if got := rules.Multiplier(LevelGold); !got.Equal(decimal.RequireFromString("1.1")) {
+		t.Fatalf("Multiplier(GOLD) = %s, want 1.1", got)
+	}
+	if got := rules.Multiplier(LevelBase); !got.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("Multiplier(BASE) = %s, want 1", got)
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"loyalty/internal/domain/tier/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// TierRepository — порт репозитория уровней пользователей.
+type TierRepository interface {
+	// RollingAccrual возвращает сумму начислений пользователя по заказам, обработанным начиная с since.
+	RollingAccrual(ctx context.Context, userID int64, since time.Time) (decimal.Decimal, error)
+
+	// Save сохраняет рассчитанный уровень пользователя (upsert).
+	Save(ctx context.Context, tier model.UserTier) error
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"loyalty/internal/domain/tier/model"
+)
+
+// TierService содержит прикладную логику расчёта уровней пользователей.
+type TierService interface {
+	// Recalculate пересчитывает уровень пользователя по начислениям за скользящее окно и сохраняет его.
+	Recalculate(ctx context.Context, userID int64) (model.UserTier, error)
+
+	// GetProfile возвращает актуальный уровень пользователя и прогресс до следующего уровня, ничего не сохраняя.
+	GetProfile(ctx context.Context, userID int64) (model.Profile, error)
+}
@@ -0,0 +1,77 @@
+package tier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"loyalty/internal/domain/tier/model"
+	tierrepo "loyalty/internal/domain/tier/repository"
+	tiersvc "loyalty/internal/domain/tier/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// Service — реализация tiersvc.TierService.
+type Service struct {
+	repo   tierrepo.TierRepository
+	rules  model.Rules
+	window time.Duration
+	now    func() time.Time
+}
+
+// NewService создаёт прикладной сервис уровней с заданными правилами и длиной скользящего окна.
+func NewService(repo tierrepo.TierRepository, rules model.Rules, window time.Duration) *Service {
+	return &Service{
+		repo:   repo,
+		rules:  rules,
+		window: window,
+		now:    time.Now,
+	}
+}
+
+// Recalculate пересчитывает уровень пользователя по начислениям за скользящее окно и сохраняет его.
+func (service *Service) Recalculate(ctx context.Context, userID int64) (model.UserTier, error) {
+	tier, _, err := service.calculate(ctx, userID)
+	if err != nil {
+		return model.UserTier{}, err
+	}
+	if err := service.repo.Save(ctx, tier); err != nil {
+		return model.UserTier{}, fmt.Errorf("save tier: %w", err)
+	}
+	return tier, nil
+}
+
+// GetProfile рассчитывает уровень пользователя на текущий момент (уровень может понизиться по мере
+// сдвига окна) и возвращает его вместе с прогрессом до следующего уровня. Рассчитанный уровень
+// не сохраняется: сохранённый уровень обновляет Recalculate при зачислении начислений.
+func (service *Service) GetProfile(ctx context.Context, userID int64) (model.Profile, error) {
+	tier, next, err := service.calculate(ctx, userID)
+	if err != nil {
+		return model.Profile{}, err
+	}
+
+	profile := model.Profile{Tier: tier, Window: service.window, Next: next, ToNext: decimal.Zero}
+	if next != nil {
+		profile.ToNext = next.Threshold.Sub(tier.RollingAccrual)
+	}
+	return profile, nil
+}
+
+func (service *Service) calculate(ctx context.Context, userID int64) (model.UserTier, *model.Rule, error) {
+	now := service.now()
+	total, err := service.repo.RollingAccrual(ctx, userID, now.Add(-service.window))
+	if err != nil {
+		return model.UserTier{}, nil, fmt.Errorf("rolling accrual: %w", err)
+	}
+	current, next := service.rules.Resolve(total)
+	return model.UserTier{
+		UserID:         userID,
+		Level:          current.Level,
+		Multiplier:     current.Multiplier,
+		RollingAccrual: total,
+		RecalculatedAt: now,
+	}, next, nil
+}
+
+var _ tiersvc.TierService = (*Service)(nil)
@@ -0,0 +1,119 @@
+package tier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/tier/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockTierRepo struct {
+	total    decimal.Decimal
+	err      error
+	saveErr  error
+	gotSince time.Time
+	saved    *model.UserTier
+}
+
+func (m *mockTierRepo) RollingAccrual(ctx context.Context, userID int64, since time.Time) (decimal.Decimal, error) {
+	m.gotSince = since
+	return m.total, m.err
+}
+
+func (m *mockTierRepo) Save(ctx context.Context, tier model.UserTier) error {
+	m.saved = &tier
+	return m.saveErr
+}
+
+func newTestService(t *testing.T, repo *mockTierRepo) *Service {
+	t.Helper()
+	rules, err := model.NewRules([]model.Rule{
+		{Level: model.LevelSilver, Threshold: decimal.NewFromInt(1000), Multiplier: decimal.NewFromInt(1)},
+		{Level: model.LevelGold, Threshold: decimal.NewFromInt(5000), Multiplier: decimal.RequireFromString("1.1")},
+	})
+	if err != nil {
+		t.Fatalf("NewRules: %v", err)
+	}
+	svc := NewService(repo, rules, 90*24*time.Hour)
+	svc.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+func TestService_Recalculate_SavesResolvedTier(t *testing.T) {
+	repo := &mockTierRepo{total: decimal.NewFromInt(6000)}
+	svc := newTestService(t, repo)
+
+	tier, err := svc.Recalculate(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if tier.Level != model.LevelGold {
+		t.Fatalf("want %s, got %s", model.LevelGold, tier.Level)
+	}
+	if !tier.Multiplier.Equal(decimal.RequireFromString("1.1")) {
+		t.Fatalf("want multiplier 1.1, got %s", tier.Multiplier)
+	}
+	if repo.saved == nil || repo.saved.UserID != 7 {
+		t.Fatalf("expected tier to be saved for user 7, got %+v", repo.saved)
+	}
+	wantSince := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	if !repo.gotSince.Equal(wantSince) {
+		t.Fatalf("want since %v, got %v", wantSince, repo.gotSince)
+	}
+}
+
+func TestService_Recalculate_PropagatesErrors(t *testing.T) {
+	repoErr := errors.New("db error")
+
+	svc := newTestService(t, &mockTierRepo{err: repoErr})
+	if _, err := svc.Recalculate(context.Background(), 1); !errors.Is(err, repoErr) {
+		t.Fatalf("want %v, got %v", repoErr, err)
+	}
+
+	repo := &mockTierRepo{total: decimal.Zero, saveErr: repoErr}
+	svc = newTestService(t, repo)
+	if _, err := svc.Recalculate(context.Background(), 1); !errors.Is(err, repoErr) {
+		t.Fatalf("want %v, got %v", repoErr, err)
+	}
+}
+
+func TestService_GetProfile_ProgressToNext(t *testing.T) {
+	repo := &mockTierRepo{total: decimal.NewFromInt(1200)}
+	svc := newTestService(t, repo)
+
+	profile, err := svc.GetProfile(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if profile.Tier.Level != model.LevelSilver {
+		t.Fatalf("want %s, got %s", model.LevelSilver, profile.Tier.Level)
+	}
+	if profile.Next == nil || profile.Next.Level != model.LevelGold {
+		t.Fatalf("want next %s, got %v", model.LevelGold, profile.Next)
+	}
+	if !profile.ToNext.Equal(decimal.NewFromInt(3800)) {
+		t.Fatalf("want to_next 3800, got %s", profile.ToNext)
+	}
+	if repo.saved != nil {
+		t.Fatalf("GetProfile must not save the tier, got %+v", repo.saved)
+	}
+}
+
+func TestService_GetProfile_TopTier(t *testing.T) {
+	svc := newTestService(t, &mockTierRepo{total: decimal.NewFromInt(9000)})
+
+	profile, err := svc.GetProfile(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if profile.Next != nil {
+		t.Fatalf("expected no next tier, got %v", profile.Next)
+	}
+	if !profile.ToNext.IsZero() {
+		t.Fatalf("want to_next 0, got %s", profile.ToNext)
+	}
+}
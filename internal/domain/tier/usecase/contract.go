@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"loyalty/internal/domain/tier/model"
+)
+
+// TierUsecase описывает сценарии просмотра уровня пользователя.
+type TierUsecase interface {
+	// GetProfile возвращает уровень пользователя и прогресс до следующего уровня.
+	GetProfile(ctx context.Context, userID int64) (model.Profile, error)
+}
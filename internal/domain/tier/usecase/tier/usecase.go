@@ -0,0 +1,30 @@
+package tier
+
+import (
+	"context"
+
+	"loyalty/internal/domain/tier/model"
+	tiersvc "loyalty/internal/domain/tier/service"
+	"loyalty/internal/domain/tier/usecase"
+	"loyalty/internal/tracing"
+)
+
+// Usecase — реализация usecase.TierUsecase.
+type Usecase struct {
+	tierService tiersvc.TierService
+}
+
+// NewUsecase создаёт usecase уровней.
+func NewUsecase(tierService tiersvc.TierService) *Usecase {
+	return &Usecase{tierService: tierService}
+}
+
+// GetProfile возвращает уровень пользователя и прогресс до следующего уровня.
+func (usecase *Usecase) GetProfile(ctx context.Context, userID int64) (_ model.Profile, err error) {
+	ctx, span := tracing.Start(ctx, "TierUsecase.GetProfile", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.tierService.GetProfile(ctx, userID)
+}
+
+var _ usecase.TierUsecase = (*Usecase)(nil)
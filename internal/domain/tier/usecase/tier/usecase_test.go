@@ -0,0 +1,51 @@
+package tier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"loyalty/internal/domain/tier/model"
+)
+
+type mockTierService struct {
+	profile model.Profile
+	err     error
+}
+
+func (m *mockTierService) Recalculate(ctx context.Context, userID int64) (model.UserTier, error) {
+	return m.profile.Tier, m.err
+}
+
+func (m *mockTierService) GetProfile(ctx context.Context, userID int64) (model.Profile, error) {
+	return m.profile, m.err
+}
+
+func TestUsecase_GetProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     *mockTierService
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			svc:     &mockTierService{profile: model.Profile{Tier: model.UserTier{UserID: 1, Level: model.LevelGold}}},
+			wantErr: false,
+		},
+		{
+			name:    "service error",
+			svc:     &mockTierService{err: errors.New("db error")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUsecase(tt.svc)
+			_, err := uc.GetProfile(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
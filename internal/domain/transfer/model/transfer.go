@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidTransferSum возвращается, если сумма перевода некорректна (<= 0).
+	ErrInvalidTransferSum = errors.New("invalid transfer sum")
+	// ErrRecipientNotFound возвращается, если получатель перевода не найден.
+	ErrRecipientNotFound = errors.New("recipient not found")
+	// ErrSelfTransfer возвращается при попытке перевести баллы самому себе.
+	ErrSelfTransfer = errors.New("self transfer is not allowed")
+	// ErrDailyLimitExceeded возвращается при превышении суточного лимита переводов.
+	ErrDailyLimitExceeded = errors.New("daily transfer limit exceeded")
+)
+
+// Limits — суточные ограничения на исходящие переводы пользователя.
+type Limits struct {
+	// MaxSum — максимальная сумма исходящих переводов за сутки (0 — без ограничения).
+	MaxSum decimal.Decimal
+	// MaxCount — максимальное количество исходящих переводов за сутки (0 — без ограничения).
+	MaxCount int
+}
+
+// Allows сообщает, укладывается ли очередной перевод sum в лимиты с учётом уже совершённых за сутки.
+func (limits Limits) Allows(spent decimal.Decimal, count int, sum decimal.Decimal) bool {
+	if limits.MaxCount > 0 && count+1 > limits.MaxCount {
+		return false
+	}
+	if limits.MaxSum.GreaterThan(decimal.Zero) && spent.Add(sum).GreaterThan(limits.MaxSum) {
+		return false
+	}
+	return true
+}
+
+// Request — запрос на перевод баллов между пользователями.
+type Request struct {
+	SenderID       int64
+	RecipientLogin string
+	Sum            decimal.Decimal
+	Limits         Limits
+	// DayStart — начало текущих суток, с которого считаются лимиты.
+	DayStart time.Time
+	Now      time.Time
+}
+
+// Transfer — совершённый перевод баллов.
+type Transfer struct {
+	ID             int64
+	SenderID       int64
+	RecipientID    int64
+	RecipientLogin string
+	Sum            decimal.Decimal
+	CreatedAt      time.Time
+}
+
+// TransactionType — тип операции в истории счёта.
+type TransactionType string
+
+const (
+	// TransactionWithdrawal — списание баллов в счёт оплаты заказа.
+	TransactionWithdrawal TransactionType = "WITHDRAWAL"
+	// TransactionTransferOut — исходящий перевод другому пользователю.
+	TransactionTransferOut TransactionType = "TRANSFER_OUT"
+	// TransactionTransferIn — входящий перевод от другого пользователя.
+	TransactionTransferIn TransactionType = "TRANSFER_IN"
+)
+
+// Transaction — операция в единой истории списаний и переводов пользователя.
+type Transaction struct {
+	Type TransactionType
+	// Order — номер заказа (только для списаний).
+	Order string
+	// Counterparty — логин второй стороны перевода (только для переводов).
+	Counterparty string
+	Sum          decimal.Decimal
+	ProcessedAt  time.Time
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestLimits_Allows(t *testing.T) {
+	limits := Limits{MaxSum: decimal.NewFromInt(1000), MaxCount: 3}
+
+	tests := []struct {
+		name   string
+		limits Limits
+		spent  int64
+		count  int
+		sum    int64
+		want   bool
+	}{
+		{name: "within limits", limits: limits, spent: 500, count: 1, sum: 500, want: true},
+		{name: "sum exceeded", limits: limits, spent: 900, count: 1, sum: 101},
+		{name: "count exceeded", limits: limits, spent: 0, count: 3, sum: 1},
+		{name: "no limits", limits: Limits{}, spent: 1_000_000, count: 100, sum: 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.limits.Allows(decimal.NewFromInt(tt.spent), tt.count, decimal.NewFromInt(tt.sum))
+			if got != tt.want {
+				t.Fatalf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
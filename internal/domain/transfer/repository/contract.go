@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"loyalty/internal/domain/transfer/model"
+)
+
+// TransferRepository — порт хранилища переводов между пользователями.
+type TransferRepository interface {
+	// Transfer атомарно переводит баллы: блокирует оба счёта в порядке возрастания user_id,
+	// проверяет суточные лимиты и баланс отправителя и записывает перевод.
+	Transfer(ctx context.Context, request model.Request) (model.Transfer, error)
+
+	// History возвращает списания и переводы пользователя (от новых к старым).
+	History(ctx context.Context, userID int64) ([]model.Transaction, error)
+}
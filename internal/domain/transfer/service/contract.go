@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"loyalty/internal/domain/transfer/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// TransferService содержит прикладную логику переводов баллов между пользователями.
+type TransferService interface {
+	// Transfer переводит sum баллов пользователю с логином recipientLogin.
+	Transfer(ctx context.Context, senderID int64, recipientLogin string, sum decimal.Decimal) (model.Transfer, error)
+
+	// History возвращает единую историю списаний и переводов пользователя (от новых к старым).
+	History(ctx context.Context, userID int64) ([]model.Transaction, error)
+}
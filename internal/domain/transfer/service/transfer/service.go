@@ -0,0 +1,58 @@
+package transfer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"loyalty/internal/domain/transfer/model"
+	transferrepo "loyalty/internal/domain/transfer/repository"
+	transfersvc "loyalty/internal/domain/transfer/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// Service — реализация transfersvc.TransferService.
+type Service struct {
+	repo   transferrepo.TransferRepository
+	limits model.Limits
+	now    func() time.Time
+}
+
+// NewService создаёт прикладной сервис переводов с суточными лимитами.
+func NewService(repo transferrepo.TransferRepository, limits model.Limits) *Service {
+	return &Service{repo: repo, limits: limits, now: time.Now}
+}
+
+// Transfer валидирует запрос и переводит баллы. Сутки для лимитов считаются по UTC.
+func (service *Service) Transfer(
+	ctx context.Context,
+	senderID int64,
+	recipientLogin string,
+	sum decimal.Decimal,
+) (model.Transfer, error) {
+	if sum.LessThanOrEqual(decimal.Zero) {
+		return model.Transfer{}, model.ErrInvalidTransferSum
+	}
+	recipientLogin = strings.TrimSpace(recipientLogin)
+	if recipientLogin == "" {
+		return model.Transfer{}, model.ErrRecipientNotFound
+	}
+
+	now := service.now().UTC()
+	return service.repo.Transfer(ctx, model.Request{
+		SenderID:       senderID,
+		RecipientLogin: recipientLogin,
+		Sum:            sum,
+		Limits:         service.limits,
+		DayStart:       now.Truncate(24 * time.Hour),
+		Now:            now,
+	})
+}
+
+// History возвращает единую историю списаний и переводов пользователя.
+func (service *Service) History(ctx context.Context, userID int64) ([]model.Transaction, error) {
+	return service.repo.History(ctx, userID)
+}
+
+var _ transfersvc.TransferService = (*Service)(nil)
@@ -0,0 +1,68 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/transfer/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockRepo struct {
+	called  bool
+	request model.Request
+	err     error
+}
+
+func (m *mockRepo) Transfer(_ context.Context, request model.Request) (model.Transfer, error) {
+	m.called = true
+	m.request = request
+	return model.Transfer{SenderID: request.SenderID, Sum: request.Sum}, m.err
+}
+
+func (m *mockRepo) History(context.Context, int64) ([]model.Transaction, error) { return nil, m.err }
+
+func TestService_Transfer_InvalidSum(t *testing.T) {
+	repo := &mockRepo{}
+	svc := NewService(repo, model.Limits{})
+
+	for _, sum := range []decimal.Decimal{decimal.Zero, decimal.NewFromInt(-5)} {
+		if _, err := svc.Transfer(context.Background(), 1, "bob", sum); !errors.Is(err, model.ErrInvalidTransferSum) {
+			t.Fatalf("want ErrInvalidTransferSum for %s, got %v", sum, err)
+		}
+	}
+	if repo.called {
+		t.Fatalf("did not expect repo.Transfer to be called")
+	}
+}
+
+func TestService_Transfer_EmptyRecipient(t *testing.T) {
+	svc := NewService(&mockRepo{}, model.Limits{})
+
+	if _, err := svc.Transfer(context.Background(), 1, "  ", decimal.NewFromInt(5)); !errors.Is(err, model.ErrRecipientNotFound) {
+		t.Fatalf("want ErrRecipientNotFound, got %v", err)
+	}
+}
+
+func TestService_Transfer_PassesLimitsAndDayStart(t *testing.T) {
+	repo := &mockRepo{}
+	limits := model.Limits{MaxSum: decimal.NewFromInt(1000), MaxCount: 5}
+	svc := NewService(repo, limits)
+	svc.now = func() time.Time { return time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC) }
+
+	if _, err := svc.Transfer(context.Background(), 1, " bob ", decimal.NewFromInt(50)); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if repo.request.RecipientLogin != "bob" || repo.request.SenderID != 1 {
+		t.Fatalf("unexpected request: %+v", repo.request)
+	}
+	if repo.request.Limits.MaxCount != 5 || !repo.request.Limits.MaxSum.Equal(limits.MaxSum) {
+		t.Fatalf("unexpected limits: %+v", repo.request.Limits)
+	}
+	if want := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC); !repo.request.DayStart.Equal(want) {
+		t.Fatalf("want day start %v, got %v", want, repo.request.DayStart)
+	}
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"loyalty/internal/domain/transfer/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// TransferUsecase описывает сценарии переводов баллов и единой истории операций.
+type TransferUsecase interface {
+	// Transfer переводит баллы другому пользователю по логину.
+	Transfer(ctx context.Context, senderID int64, recipientLogin string, sum decimal.Decimal) (model.Transfer, error)
+
+	// ListTransactions возвращает списания и переводы пользователя (от новых к старым).
+	ListTransactions(ctx context.Context, userID int64) ([]model.Transaction, error)
+}
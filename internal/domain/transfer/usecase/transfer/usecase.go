@@ -0,0 +1,45 @@
+package transfer
+
+import (
+	"context"
+
+	"loyalty/internal/domain/transfer/model"
+	transfersvc "loyalty/internal/domain/transfer/service"
+	"loyalty/internal/domain/transfer/usecase"
+	"loyalty/internal/tracing"
+
+	"github.com/shopspring/decimal"
+)
+
+// Usecase — реализация usecase.TransferUsecase.
+type Usecase struct {
+	transferService transfersvc.TransferService
+}
+
+// NewUsecase создаёт usecase переводов.
+func NewUsecase(transferService transfersvc.TransferService) *Usecase {
+	return &Usecase{transferService: transferService}
+}
+
+// Transfer переводит баллы другому пользователю по логину.
+func (usecase *Usecase) Transfer(
+	ctx context.Context,
+	senderID int64,
+	recipientLogin string,
+	sum decimal.Decimal,
+) (_ model.Transfer, err error) {
+	ctx, span := tracing.Start(ctx, "TransferUsecase.Transfer", tracing.UserID(senderID))
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.transferService.Transfer(ctx, senderID, recipientLogin, sum)
+}
+
+// ListTransactions возвращает списания и переводы пользователя (от новых к старым).
+func (usecase *Usecase) ListTransactions(ctx context.Context, userID int64) (_ []model.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "TransferUsecase.ListTransactions", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
+	return usecase.transferService.History(ctx, userID)
+}
+
+var _ usecase.TransferUsecase = (*Usecase)(nil)
@@ -0,0 +1,58 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"loyalty/internal/domain/transfer/model"
+
+	"github.com/shopspring/decimal"
+)
+
+type mockTransferService struct {
+	transfer model.Transfer
+	history  []model.Transaction
+	err      error
+}
+
+func (m *mockTransferService) Transfer(context.Context, int64, string, decimal.Decimal) (model.Transfer, error) {
+	return m.transfer, m.err
+}
+
+func (m *mockTransferService) History(context.Context, int64) ([]model.Transaction, error) {
+	return m.history, m.err
+}
+
+func TestUsecase_Transfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     *mockTransferService
+		wantErr bool
+	}{
+		{name: "success", svc: &mockTransferService{transfer: model.Transfer{ID: 1}}},
+		{name: "insufficient funds", svc: &mockTransferService{err: errors.New("insufficient funds")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUsecase(tt.svc)
+			_, err := uc.Transfer(context.Background(), 1, "bob", decimal.NewFromInt(10))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transfer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsecase_ListTransactions(t *testing.T) {
+	uc := NewUsecase(&mockTransferService{history: []model.Transaction{{Type: model.TransactionWithdrawal}}})
+
+	got, err := uc.ListTransactions(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].Type != model.TransactionWithdrawal {
+		t.Fatalf("unexpected history: %+v", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	withdrawalsmodel "loyalty/internal/domain/withdrawal/model"
 	withdrawalssvc "loyalty/internal/domain/withdrawal/service"
 	"loyalty/internal/domain/withdrawal/usecase"
+	"loyalty/internal/tracing"
 
 	"github.com/shopspring/decimal"
 )
@@ -30,7 +31,10 @@ func NewUsecase(
 }
 
 // Withdraw списывает баллы в счёт оплаты заказа.
-func (usecase *Usecase) Withdraw(ctx context.Context, userID int64, orderNumber string, sum decimal.Decimal) error {
+func (usecase *Usecase) Withdraw(ctx context.Context, userID int64, orderNumber string, sum decimal.Decimal) (err error) {
+	ctx, span := tracing.Start(ctx, "WithdrawalsUsecase.Withdraw", tracing.UserID(userID), tracing.OrderNumber(orderNumber))
+	defer func() { tracing.End(span, err) }()
+
 	normalized, err := usecase.orderNumberValidator.ValidateNumber(orderNumber)
 	if err != nil {
 		return ordersmodel.ErrInvalidOrderNumber
@@ -39,7 +43,10 @@ func (usecase *Usecase) Withdraw(ctx context.Context, userID int64, orderNumber
 }
 
 // ListWithdrawals возвращает список списаний пользователя (от новых к старым).
-func (usecase *Usecase) ListWithdrawals(ctx context.Context, userID int64) ([]withdrawalsmodel.Withdrawal, error) {
+func (usecase *Usecase) ListWithdrawals(ctx context.Context, userID int64) (_ []withdrawalsmodel.Withdrawal, err error) {
+	ctx, span := tracing.Start(ctx, "WithdrawalsUsecase.ListWithdrawals", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+
 	return usecase.withdrawalsService.ListWithdrawals(ctx, userID)
 }
 
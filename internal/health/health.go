@@ -0,0 +1,205 @@
+// Package health содержит проверки готовности сервиса (readiness) и их сводный отчёт.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status — результат проверки.
+type Status string
+
+const (
+	// StatusOK — зависимость работает штатно.
+	StatusOK Status = "ok"
+	// StatusWarn — зависимость деградировала, но сервис может обслуживать запросы.
+	StatusWarn Status = "warn"
+	// StatusFail — зависимость недоступна.
+	StatusFail Status = "fail"
+)
+
+// shutdownCheck — имя псевдо-проверки, сообщающей о graceful shutdown.
+const shutdownCheck = "shutdown"
+
+// Result — результат одной проверки.
+type Result struct {
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Check — именованная проверка зависимости.
+// Неуспешная критичная проверка делает сервис неготовым; некритичная только попадает в отчёт.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      func(ctx context.Context) Result
+}
+
+// Report — сводный отчёт о готовности.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready сообщает, готов ли сервис принимать трафик.
+func (report Report) Ready() bool {
+	return report.Status != StatusFail
+}
+
+// Probe выполняет проверки готовности и хранит признак завершения работы.
+// nil-probe считается всегда готовым.
+type Probe struct {
+	checks       []Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewProbe создаёт probe с таймаутом на каждую проверку.
+func NewProbe(timeout time.Duration, checks ...Check) *Probe {
+	return &Probe{checks: checks, timeout: timeout}
+}
+
+// Shutdown переводит сервис в состояние «не готов», чтобы балансировщик перестал направлять трафик.
+func (probe *Probe) Shutdown() {
+	if probe == nil {
+		return
+	}
+	probe.shuttingDown.Store(true)
+}
+
+// Ready выполняет все проверки параллельно и возвращает сводный отчёт.
+func (probe *Probe) Ready(ctx context.Context) Report {
+	if probe == nil {
+		return Report{Status: StatusOK, Checks: map[string]Result{}}
+	}
+	results := make([]Result, len(probe.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range probe.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = probe.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(probe.checks)+1)}
+	for i, check := range probe.checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+
+		switch {
+		case result.Status == StatusFail && check.Critical:
+			report.Status = StatusFail
+		case result.Status != StatusOK && report.Status == StatusOK:
+			report.Status = StatusWarn
+		}
+	}
+
+	if probe.shuttingDown.Load() {
+		report.Status = StatusFail
+		report.Checks[shutdownCheck] = Result{Status: StatusFail, Detail: "shutting down"}
+	}
+	return report
+}
+
+func (probe *Probe) run(ctx context.Context, check Check) (result Result) {
+	checkCtx, cancel := context.WithTimeout(ctx, probe.timeout)
+	defer cancel()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = Result{Status: StatusFail, Detail: fmt.Sprintf("panic: %v", recovered)}
+		}
+	}()
+	return check.Run(checkCtx)
+}
+
+// Pinger — зависимость, доступность которой проверяется пингом (например, *sql.DB).
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// DatabaseCheck проверяет доступность БД.
+func DatabaseCheck(db Pinger) Check {
+	return Check{
+		Name:     "database",
+		Critical: true,
+		Run: func(ctx context.Context) Result {
+			if err := db.PingContext(ctx); err != nil {
+				return Result{Status: StatusFail, Detail: err.Error()}
+			}
+			return Result{Status: StatusOK}
+		},
+	}
+}
+
+// MigrationCheck проверяет, что схема БД находится на ожидаемой версии и не помечена как dirty.
+func MigrationCheck(version func(ctx context.Context) (uint, bool, error), expected uint) Check {
+	return Check{
+		Name:     "migrations",
+		Critical: true,
+		Run: func(ctx context.Context) Result {
+			current, dirty, err := version(ctx)
+			switch {
+			case err != nil:
+				return Result{Status: StatusFail, Detail: err.Error()}
+			case dirty:
+				return Result{Status: StatusFail, Detail: fmt.Sprintf("version %d is dirty", current)}
+			case current != expected:
+				return Result{Status: StatusFail, Detail: fmt.Sprintf("version %d, expected %d", current, expected)}
+			}
+			return Result{Status: StatusOK, Detail: fmt.Sprintf("version %d", current)}
+		},
+	}
+}
+
+// HeartbeatCheck сообщает, как давно фоновый процесс последний раз отмечался.
+// Проверка некритичная: остановка воркера не мешает обслуживать HTTP-запросы.
+func HeartbeatCheck(name string, heartbeat func() time.Time, maxAge time.Duration) Check {
+	return Check{
+		Name: name,
+		Run: func(context.Context) Result {
+			last := heartbeat()
+			if last.IsZero() {
+				return Result{Status: StatusWarn, Detail: "no heartbeat yet"}
+			}
+			age := time.Since(last).Round(time.Second)
+			if age > maxAge {
+				return Result{Status: StatusWarn, Detail: fmt.Sprintf("last heartbeat %s ago", age)}
+			}
+			return Result{Status: StatusOK, Detail: fmt.Sprintf("last heartbeat %s ago", age)}
+		},
+	}
+}
+
+// StandbyCheck оборачивает проверку процесса, который работает только на активном инстансе (лидере):
+// пока active ложно, проверка не выполняется и сообщает ok с пометкой standby.
+func StandbyCheck(check Check, active func() bool) Check {
+	run := check.Run
+	check.Run = func(ctx context.Context) Result {
+		if !active() {
+			return Result{Status: StatusOK, Detail: "standby"}
+		}
+		return run(ctx)
+	}
+	return check
+}
+
+// BreakerCheck сообщает состояние circuit breaker; открытый breaker — предупреждение.
+func BreakerCheck(name string, state func() string) Check {
+	return Check{
+		Name: name,
+		Run: func(context.Context) Result {
+			current := state()
+			if current == "open" {
+				return Result{Status: StatusWarn, Detail: current}
+			}
+			return Result{Status: StatusOK, Detail: current}
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func staticCheck(name string, critical bool, status Status) Check {
+	return Check{
+		Name:     name,
+		Critical: critical,
+		Run:      func(context.Context) Result { return Result{Status: status} },
+	}
+}
+
+func TestProbe_Ready_Aggregation(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []Check
+		want   Status
+	}{
+		{"no checks", nil, StatusOK},
+		{"all ok", []Check{staticCheck("db", true, StatusOK), staticCheck("worker", false, StatusOK)}, StatusOK},
+		{"non-critical warn", []Check{staticCheck("db", true, StatusOK), staticCheck("worker", false, StatusWarn)}, StatusWarn},
+		{"non-critical fail", []Check{staticCheck("worker", false, StatusFail)}, StatusWarn},
+		{"critical fail", []Check{staticCheck("db", true, StatusFail), staticCheck("worker", false, StatusWarn)}, StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewProbe(time.Second, tt.checks...).Ready(context.Background())
+			if report.Status != tt.want {
+				t.Fatalf("want %s, got %s (%v)", tt.want, report.Status, report.Checks)
+			}
+			if len(report.Checks) != len(tt.checks) {
+				t.Fatalf("want %d checks in report, got %d", len(tt.checks), len(report.Checks))
+			}
+		})
+	}
+}
+
+func TestProbe_Shutdown(t *testing.T) {
+	probe := NewProbe(time.Second, staticCheck("db", true, StatusOK))
+	probe.Shutdown()
+
+	report := probe.Ready(context.Background())
+	if report.Ready() {
+		t.Fatalf("expected not ready after shutdown, got %+v", report)
+	}
+	if report.Checks[shutdownCheck].Status != StatusFail {
+		t.Fatalf("expected shutdown check in report, got %+v", report.Checks)
+	}
+}
+
+func TestProbe_NilIsReady(t *testing.T) {
+	var probe *Probe
+	probe.Shutdown()
+	if report := probe.Ready(context.Background()); !report.Ready() {
+		t.Fatalf("expected nil probe to be ready, got %+v", report)
+	}
+}
+
+func TestProbe_RecoversPanicAndAppliesTimeout(t *testing.T) {
+	probe := NewProbe(10*time.Millisecond,
+		Check{Name: "panics", Critical: true, Run: func(context.Context) Result { panic("boom") }},
+		Check{Name: "slow", Run: func(ctx context.Context) Result {
+			<-ctx.Done()
+			return Result{Status: StatusFail, Detail: ctx.Err().Error()}
+		}},
+	)
+
+	report := probe.Ready(context.Background())
+	if report.Checks["panics"].Status != StatusFail || report.Status != StatusFail {
+		t.Fatalf("expected panic to fail critical check, got %+v", report)
+	}
+	if report.Checks["slow"].Detail != context.DeadlineExceeded.Error() {
+		t.Fatalf("expected slow check to hit timeout, got %+v", report.Checks["slow"])
+	}
+}
+
+type pingerFunc func(ctx context.Context) error
+
+func (fn pingerFunc) PingContext(ctx context.Context) error { return fn(ctx) }
+
+func TestDatabaseCheck(t *testing.T) {
+	ok := DatabaseCheck(pingerFunc(func(context.Context) error { return nil })).Run(context.Background())
+	if ok.Status != StatusOK {
+		t.Fatalf("want ok, got %+v", ok)
+	}
+	failed := DatabaseCheck(pingerFunc(func(context.Context) error { return errors.New("down") })).Run(context.Background())
+	if failed.Status != StatusFail || failed.Detail != "down" {
+		t.Fatalf("want fail, got %+v", failed)
+	}
+}
+
+func TestMigrationCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		version uint
+		dirty   bool
+		err     error
+		want    Status
+	}{
+		{"up to date", 5, false, nil, StatusOK},
+		{"behind", 4, false, nil, StatusFail},
+		{"dirty", 5, true, nil, StatusFail},
+		{"error", 0, false, errors.New("no table"), StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := MigrationCheck(func(context.Context) (uint, bool, error) {
+				return tt.version, tt.dirty, tt.err
+			}, 5)
+			if got := check.Run(context.Background()); got.Status != tt.want {
+				t.Fatalf("want %s, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHeartbeatCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		last time.Time
+		want Status
+	}{
+		{"never", time.Time{}, StatusWarn},
+		{"fresh", time.Now(), StatusOK},
+		{"stale", time.Now().Add(-time.Hour), StatusWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := HeartbeatCheck("worker", func() time.Time { return tt.last }, time.Minute)
+			if got := check.Run(context.Background()); got.Status != tt.want {
+				t.Fatalf("want %s, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBreakerCheck(t *testing.T) {
+	for state, want := range map[string]Status{"closed": StatusOK, "half-open": StatusOK, "open": StatusWarn} {
+		check := BreakerCheck("breaker", func() string { return state })
+		if got := check.Run(context.Background()); got.Status != want || got.Detail != state {
+			t.Errorf("state %s: want %s, got %+v", state, want, got)
+		}
+	}
+}
+
+func TestStandbyCheck(t *testing.T) {
+	active := false
+	check := StandbyCheck(HeartbeatCheck("worker", func() time.Time { return time.Time{} }, time.Minute),
+		func() bool { return active })
+
+	if got := check.Run(context.Background()); got.Status != StatusOK || got.Detail != "standby" {
+		t.Fatalf("want ok standby on a follower, got %+v", got)
+	}
+
+	active = true
+	if got := check.Run(context.Background()); got.Status != StatusWarn {
+		t.Fatalf("want wrapped check result on the leader, got %+v", got)
+	}
+}
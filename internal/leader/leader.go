@@ -0,0 +1,201 @@
+// Package leader выбирает среди реплик сервиса одного лидера, на котором работают фоновые задачи-синглтоны.
+package leader
+
+import (
+	"context"
+	"loyalty/internal/logger"
+	"loyalty/internal/metrics"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// releaseTimeout — таймаут освобождения блокировки при остановке.
+const releaseTimeout = 5 * time.Second
+
+// Lock — распределённая блокировка лидерства (например, advisory lock PostgreSQL).
+type Lock interface {
+	// TryAcquire пытается взять блокировку без ожидания; true — блокировка у этого инстанса.
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check проверяет, что взятая блокировка всё ещё удерживается; ошибка означает потерю лидерства,
+	// после неё следующий TryAcquire берёт блокировку заново. TryAcquire и Check вызываются с дедлайном
+	// interval выборщика и должны прерываться по ctx, в том числе при зависшем соединении.
+	Check(ctx context.Context) error
+	// Release отпускает блокировку.
+	Release(ctx context.Context) error
+}
+
+// Job — фоновая задача-синглтон: запускается с ctx и возвращает канал, закрываемый после её остановки
+// (сигнатура совпадает с accrualworker.Worker.Start).
+type Job func(ctx context.Context) <-chan struct{}
+
+// singleton — зарегистрированная задача-синглтон.
+type singleton struct {
+	name string
+	job  Job
+}
+
+// Elector периодически пытается стать лидером, а став им — продлевает (проверяет) блокировку.
+// Задачи-синглтоны запускаются только на лидере и останавливаются при потере лидерства или остановке.
+type Elector struct {
+	lock     Lock
+	interval time.Duration
+
+	mu         sync.Mutex
+	singletons []singleton
+	onChange   []func(leader bool)
+
+	leader atomic.Bool
+}
+
+// NewElector создаёт выборщика, который раз в interval пытается взять блокировку или проверяет уже взятую.
+func NewElector(lock Lock, interval time.Duration) *Elector {
+	return &Elector{lock: lock, interval: interval}
+}
+
+// Singleton регистрирует задачу, которая работает только на лидере. Вызывается до Start.
+func (elector *Elector) Singleton(name string, job Job) {
+	elector.mu.Lock()
+	defer elector.mu.Unlock()
+	elector.singletons = append(elector.singletons, singleton{name: name, job: job})
+}
+
+// OnChange регистрирует колбэк смены роли: true — инстанс стал лидером, false — перестал.
+// Колбэки вызываются синхронно из цикла выборов. Вызывается до Start.
+func (elector *Elector) OnChange(fn func(leader bool)) {
+	elector.mu.Lock()
+	defer elector.mu.Unlock()
+	elector.onChange = append(elector.onChange, fn)
+}
+
+// IsLeader сообщает, является ли инстанс лидером.
+func (elector *Elector) IsLeader() bool {
+	return elector.leader.Load()
+}
+
+// Start запускает выборы в фоне и возвращает канал, который закрывается после остановки.
+// Отмена ctx останавливает задачи-синглтоны, дожидается их завершения и освобождает блокировку,
+// чтобы лидером сразу мог стать другой инстанс. Если инстанс завершится аварийно, блокировку
+// освобождает сама БД вместе с соединением, и лидер сменится на следующей попытке другой реплики.
+func (elector *Elector) Start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.run(ctx)
+	}()
+	return done
+}
+
+func (elector *Elector) run(ctx context.Context) {
+	ctx = logger.With(ctx, "component", "leader_elector")
+	zerolog.Ctx(ctx).Info().Dur("interval", elector.interval).Msg("leader election started")
+	metrics.LeaderStatus.Set(0)
+
+	ticker := time.NewTicker(elector.interval)
+	defer ticker.Stop()
+
+	var current *term
+	defer func() {
+		if current == nil {
+			return
+		}
+		elector.stepDown(ctx, current)
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+		defer cancel()
+		if err := elector.lock.Release(releaseCtx); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("failed to release leader lock")
+			return
+		}
+		zerolog.Ctx(ctx).Info().Msg("leader lock released")
+	}()
+
+	for {
+		if current == nil {
+			current = elector.tryLead(ctx)
+		} else if err := elector.check(ctx); err != nil && ctx.Err() == nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("leadership lost")
+			elector.stepDown(ctx, current)
+			current = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check проверяет блокировку не дольше interval: зависшее соединение (например, полуоткрытое
+// после сбоя сети) считается потерей лидерства, иначе задачи продолжили бы работать, пока
+// блокировку берёт другой инстанс.
+func (elector *Elector) check(ctx context.Context) error {
+	checkCtx, cancel := context.WithTimeout(ctx, elector.interval)
+	defer cancel()
+	return elector.lock.Check(checkCtx)
+}
+
+// term — срок лидерства: контекст задач-синглтонов и каналы их завершения.
+type term struct {
+	cancel context.CancelFunc
+	done   []<-chan struct{}
+}
+
+// tryLead пытается взять блокировку (не дольше interval) и при успехе запускает задачи-синглтоны.
+func (elector *Elector) tryLead(ctx context.Context) *term {
+	acquireCtx, cancel := context.WithTimeout(ctx, elector.interval)
+	held, err := elector.lock.TryAcquire(acquireCtx)
+	cancel()
+	if err != nil {
+		if ctx.Err() == nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("failed to acquire leader lock")
+		}
+		return nil
+	}
+	if !held {
+		return nil
+	}
+
+	elector.mu.Lock()
+	singletons := slices.Clone(elector.singletons)
+	elector.mu.Unlock()
+
+	jobsCtx, cancel := context.WithCancel(ctx)
+	current := &term{cancel: cancel}
+	for _, s := range singletons {
+		current.done = append(current.done, s.job(jobsCtx))
+		zerolog.Ctx(ctx).Info().Str("job", s.name).Msg("singleton job started")
+	}
+	elector.setLeader(ctx, true)
+	return current
+}
+
+// stepDown останавливает задачи-синглтоны и ждёт их завершения.
+func (elector *Elector) stepDown(ctx context.Context, current *term) {
+	current.cancel()
+	for _, done := range current.done {
+		<-done
+	}
+	elector.setLeader(ctx, false)
+}
+
+func (elector *Elector) setLeader(ctx context.Context, leader bool) {
+	elector.leader.Store(leader)
+	if leader {
+		metrics.LeaderStatus.Set(1)
+		zerolog.Ctx(ctx).Info().Msg("became leader")
+	} else {
+		metrics.LeaderStatus.Set(0)
+		zerolog.Ctx(ctx).Info().Msg("stepped down as leader")
+	}
+
+	elector.mu.Lock()
+	callbacks := slices.Clone(elector.onChange)
+	elector.mu.Unlock()
+	for _, fn := range callbacks {
+		fn(leader)
+	}
+}
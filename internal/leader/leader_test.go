@@ -0,0 +1,220 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLock — блокировка, состоянием которой управляет тест.
+type fakeLock struct {
+	mu        sync.Mutex
+	available bool
+	held      bool
+	lost      bool
+	released  bool
+}
+
+func (l *fakeLock) TryAcquire(context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.available {
+		return false, nil
+	}
+	l.available = false
+	l.held = true
+	l.lost = false
+	return true, nil
+}
+
+func (l *fakeLock) Check(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.lost {
+		l.held = false
+		return errors.New("connection lost")
+	}
+	return nil
+}
+
+func (l *fakeLock) Release(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held = false
+	l.released = true
+	return nil
+}
+
+func (l *fakeLock) set(fn func(l *fakeLock)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn(l)
+}
+
+// recordingJob отмечает запуски и остановки задачи.
+type recordingJob struct {
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func newRecordingJob() *recordingJob {
+	return &recordingJob{started: make(chan struct{}, 10), stopped: make(chan struct{}, 10)}
+}
+
+func (j *recordingJob) start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	j.started <- struct{}{}
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		j.stopped <- struct{}{}
+	}()
+	return done
+}
+
+func waitSignal(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for %s", what)
+	}
+}
+
+func TestElector_RunsSingletonsOnlyOnLeader(t *testing.T) {
+	lock := &fakeLock{}
+	job := newRecordingJob()
+	elector := NewElector(lock, 5*time.Millisecond)
+	elector.Singleton("job", job.start)
+
+	changes := make(chan bool, 10)
+	elector.OnChange(func(leader bool) { changes <- leader })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := elector.Start(ctx)
+
+	select {
+	case <-job.started:
+		t.Fatal("job started on a follower")
+	case <-time.After(30 * time.Millisecond):
+	}
+	if elector.IsLeader() {
+		t.Fatal("follower reported as leader")
+	}
+
+	lock.set(func(l *fakeLock) { l.available = true })
+	waitSignal(t, job.started, "job start on leader")
+	if got := <-changes; !got || !elector.IsLeader() {
+		t.Fatal("expected leadership to be reported")
+	}
+
+	cancel()
+	waitSignal(t, done, "elector stop")
+	waitSignal(t, job.stopped, "job stop")
+	if got := <-changes; got || elector.IsLeader() {
+		t.Fatal("expected step down on stop")
+	}
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+	if !lock.released {
+		t.Fatal("leader lock must be released on stop")
+	}
+}
+
+func TestElector_StopsSingletonsWhenLeadershipLost(t *testing.T) {
+	lock := &fakeLock{available: true}
+	job := newRecordingJob()
+	elector := NewElector(lock, 5*time.Millisecond)
+	elector.Singleton("job", job.start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := elector.Start(ctx)
+
+	waitSignal(t, job.started, "job start")
+
+	lock.set(func(l *fakeLock) { l.lost = true })
+	waitSignal(t, job.stopped, "job stop after leadership loss")
+	deadline := time.After(time.Second)
+	for elector.IsLeader() {
+		select {
+		case <-deadline:
+			t.Fatal("elector still reports leadership after losing the lock")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Блокировка снова свободна — инстанс опять становится лидером и перезапускает задачи.
+	lock.set(func(l *fakeLock) { l.available = true })
+	waitSignal(t, job.started, "job restart after re-election")
+
+	cancel()
+	waitSignal(t, done, "elector stop")
+}
+
+// hangingLock — блокировка на зависшем соединении: после hang вызовы ждут отмены ctx.
+type hangingLock struct {
+	fakeLock
+	hang     chan struct{}
+	attempts chan struct{}
+}
+
+func (l *hangingLock) TryAcquire(ctx context.Context) (bool, error) {
+	select {
+	case l.attempts <- struct{}{}:
+	default:
+	}
+	select {
+	case <-l.hang:
+		<-ctx.Done()
+		return false, ctx.Err()
+	default:
+		return l.fakeLock.TryAcquire(ctx)
+	}
+}
+
+func (l *hangingLock) Check(ctx context.Context) error {
+	select {
+	case <-l.hang:
+		<-ctx.Done()
+		return ctx.Err()
+	default:
+		return l.fakeLock.Check(ctx)
+	}
+}
+
+func TestElector_StepsDownWhenLockCheckHangs(t *testing.T) {
+	lock := &hangingLock{fakeLock: fakeLock{available: true}, hang: make(chan struct{}), attempts: make(chan struct{}, 1)}
+	job := newRecordingJob()
+	elector := NewElector(lock, 10*time.Millisecond)
+	elector.Singleton("job", job.start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := elector.Start(ctx)
+
+	waitSignal(t, job.started, "job start")
+	<-lock.attempts
+
+	close(lock.hang)
+	waitSignal(t, job.stopped, "job stop after hanging lock check")
+	deadline := time.After(time.Second)
+	for elector.IsLeader() {
+		select {
+		case <-deadline:
+			t.Fatal("elector still reports leadership after a hanging lock check")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Зависшие попытки взять блокировку тоже прерываются, и выборы продолжаются.
+	waitSignal(t, lock.attempts, "acquire attempt after step down")
+	waitSignal(t, lock.attempts, "next acquire attempt")
+
+	cancel()
+	waitSignal(t, done, "elector stop")
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/rs/zerolog"
+)
+
+// RequestIDField — имя поля с идентификатором HTTP-запроса в записях лога.
+const RequestIDField = "request_id"
+
+type requestIDKey struct{}
+
+// NewID генерирует случайный идентификатор корреляции (16 hex-символов).
+func NewID() string {
+	var raw [8]byte
+	_, _ = rand.Read(raw[:])
+	return hex.EncodeToString(raw[:])
+}
+
+// With возвращает контекст с дочерним логгером zerolog.Ctx(ctx), дополненным полем key.
+// Логгер привязан к ctx, поэтому его записи содержат trace_id активного спана (см. TraceHook).
+func With(ctx context.Context, key string, value string) context.Context {
+	child := zerolog.Ctx(ctx).With().Str(key, value).Ctx(ctx).Logger()
+	return child.WithContext(ctx)
+}
+
+// WithRequestID сохраняет идентификатор запроса в контексте вместе с логгером, пишущим его в поле request_id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return With(ctx, RequestIDField, requestID)
+}
+
+// RequestID возвращает идентификатор запроса из контекста или пустую строку.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
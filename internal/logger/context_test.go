@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestWith_AddsFieldsToContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+	ctx := base.WithContext(context.Background())
+
+	ctx = With(ctx, "batch_id", "b1")
+	ctx = With(ctx, "order", "79927398713")
+	zerolog.Ctx(ctx).Info().Msg("processed")
+
+	out := buf.String()
+	if !strings.Contains(out, `"batch_id":"b1"`) || !strings.Contains(out, `"order":"79927398713"`) {
+		t.Fatalf("expected correlation fields, got %s", out)
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-1")
+	if got := RequestID(ctx); got != "req-1" {
+		t.Fatalf("want req-1, got %q", got)
+	}
+	if got := RequestID(context.Background()); got != "" {
+		t.Fatalf("want empty request id, got %q", got)
+	}
+}
+
+func TestNewID_Unique(t *testing.T) {
+	first, second := NewID(), NewID()
+	if len(first) != 16 || first == second {
+		t.Fatalf("unexpected ids %q, %q", first, second)
+	}
+}
@@ -11,17 +11,30 @@ import (
 
 // InitLogger инициализирует глобальный логгер zerolog.
 // Уровень логирования задаётся параметром logLevel (debug|info|warn|error).
+// Глобальный логгер становится логгером по умолчанию для zerolog.Ctx, если в контексте нет своего.
 func InitLogger(logLevel string) error {
 	zerolog.TimeFieldFormat = time.RFC3339Nano
-	log.Logger = log.Output(os.Stderr).With().Timestamp().Logger()
+	log.Logger = log.Output(os.Stderr).With().Timestamp().Logger().Hook(TraceHook{})
+	zerolog.DefaultContextLogger = &log.Logger
 
 	if logLevel != "" {
-		level, err := zerolog.ParseLevel(strings.ToLower(logLevel))
+		return SetLevel(logLevel)
+	}
+
+	return nil
+}
+
+// SetLevel меняет глобальный уровень логирования; пустая строка возвращает уровень по умолчанию (debug).
+// Безопасен для вызова во время работы (используется при перезагрузке конфигурации).
+func SetLevel(logLevel string) error {
+	level := zerolog.DebugLevel
+	if logLevel != "" {
+		parsed, err := zerolog.ParseLevel(strings.ToLower(logLevel))
 		if err != nil {
 			return err
 		}
-		zerolog.SetGlobalLevel(level)
+		level = parsed
 	}
-
+	zerolog.SetGlobalLevel(level)
 	return nil
 }
@@ -1,6 +1,10 @@
 package logger
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
 
 func TestInitLogger_Default(t *testing.T) {
 	if err := InitLogger(""); err != nil {
@@ -13,3 +17,28 @@ func TestInitLogger_InvalidLevel(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestSetLevel(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+
+	if err := SetLevel("WARN"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if zerolog.GlobalLevel() != zerolog.WarnLevel {
+		t.Fatalf("want warn, got %v", zerolog.GlobalLevel())
+	}
+
+	if err := SetLevel("loud"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if zerolog.GlobalLevel() != zerolog.WarnLevel {
+		t.Fatalf("invalid level must not change current level, got %v", zerolog.GlobalLevel())
+	}
+
+	if err := SetLevel(""); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if zerolog.GlobalLevel() != zerolog.DebugLevel {
+		t.Fatalf("want debug, got %v", zerolog.GlobalLevel())
+	}
+}
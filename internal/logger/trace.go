@@ -0,0 +1,21 @@
+package logger
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHook добавляет в запись trace_id и span_id активного спана.
+// Работает для событий, которым передан контекст (Event.Ctx или логгер из zerolog.Ctx).
+type TraceHook struct{}
+
+// Run реализует zerolog.Hook.
+func (TraceHook) Run(event *zerolog.Event, _ zerolog.Level, _ string) {
+	spanCtx := trace.SpanContextFromContext(event.GetCtx())
+	if !spanCtx.IsValid() {
+		return
+	}
+	event.
+		Str("trace_id", spanCtx.TraceID().String()).
+		Str("span_id", spanCtx.SpanID().String())
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceHook_AddsTraceIDs(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(TraceHook{})
+	logger.Info().Ctx(ctx).Msg("with span")
+
+	out := buf.String()
+	if !strings.Contains(out, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`) ||
+		!strings.Contains(out, `"span_id":"00f067aa0ba902b7"`) {
+		t.Fatalf("expected trace ids in log line, got %s", out)
+	}
+}
+
+func TestTraceHook_SkipsWithoutSpan(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(TraceHook{})
+	logger.Info().Msg("no span")
+
+	if strings.Contains(buf.String(), "trace_id") {
+		t.Fatalf("unexpected trace_id in %s", buf.String())
+	}
+}
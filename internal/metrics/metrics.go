@@ -0,0 +1,194 @@
+// Package metrics содержит Prometheus-метрики сервиса и реестр, через который они отдаются на /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "loyalty"
+
+// Registry — реестр метрик сервиса. Отдельный от prometheus.DefaultRegisterer,
+// чтобы на /metrics попадали только явно зарегистрированные коллекторы.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestDuration — длительность HTTP-запросов по методу, шаблону маршрута и статусу.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// RateLimitRejections — число запросов, отклонённых rate limiter'ом, по маршруту.
+	RateLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "ratelimit_rejections_total",
+		Help:      "Number of HTTP requests rejected by the rate limiter.",
+	}, []string{"route"})
+
+	// WorkerBatchSize — размер батча заказов, выбранных воркером за один проход.
+	WorkerBatchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "accrual_worker",
+		Name:      "batch_size",
+		Help:      "Number of pending orders picked up by the accrual worker per batch.",
+		Buckets:   []float64{0, 1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	})
+
+	// WorkerPendingOrders — число заказов, ожидающих обработки, на момент последнего опроса.
+	WorkerPendingOrders = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "accrual_worker",
+		Name:      "pending_orders",
+		Help:      "Number of pending orders seen by the accrual worker on the last poll.",
+	})
+
+	// WorkerOrderOutcomes — результаты обработки заказов воркером (статус accrual или вид ошибки).
+	WorkerOrderOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "accrual_worker",
+		Name:      "order_outcomes_total",
+		Help:      "Outcomes of accrual worker order processing by accrual status or error kind.",
+	}, []string{"outcome"})
+
+	// WorkerPoolSize — текущий размер пула обработчиков воркера accrual.
+	WorkerPoolSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "accrual_worker",
+		Name:      "pool_size",
+		Help:      "Current number of accrual worker order handlers.",
+	})
+
+	// WorkerInFlightOrders — заказы, переданные обработчикам воркера или ожидающие передачи.
+	WorkerInFlightOrders = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "accrual_worker",
+		Name:      "in_flight_orders",
+		Help:      "Orders currently handed to or waiting for an accrual worker handler.",
+	})
+
+	// AccrualRequestDuration — длительность вызовов GetOrderAccrual по провайдеру accrual и результату.
+	AccrualRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "accrual",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of GetOrderAccrual calls by accrual provider and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider", "result"})
+
+	// AccrualRateLimit — текущий адаптивный лимит запросов к провайдеру accrual в минуту.
+	AccrualRateLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "accrual",
+		Name:      "rate_limit_rpm",
+		Help:      "Current adaptive client-side rate limit per accrual provider, requests per minute.",
+	}, []string{"provider"})
+
+	// AccrualRetries — повторы запросов к провайдеру accrual после временных ошибок.
+	AccrualRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "accrual",
+		Name:      "retries_total",
+		Help:      "Accrual requests retried after transient errors, by provider.",
+	}, []string{"provider"})
+
+	// AccrualCallbackItems — результаты из обратных вызовов accrual по исходу применения
+	// ("applied" или код ошибки API).
+	AccrualCallbackItems = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "accrual",
+		Name:      "callback_items_total",
+		Help:      "Accrual callback results by outcome (applied or API error code).",
+	}, []string{"result"})
+
+	// LeaderStatus — является ли инстанс лидером для задач-синглтонов: 1 — да, 0 — нет.
+	LeaderStatus = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "leader",
+		Name:      "is_leader",
+		Help:      "Whether this instance holds the leader lock for singleton jobs (1) or not (0).",
+	})
+
+	// SchedulerJobRuns — завершённые запуски задач планировщика по задаче и статусу.
+	SchedulerJobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_runs_total",
+		Help:      "Finished scheduled job runs by job and status.",
+	}, []string{"job", "status"})
+
+	// SchedulerJobDuration — длительность запусков задач планировщика.
+	SchedulerJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_duration_seconds",
+		Help:      "Duration of scheduled job runs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"job"})
+
+	// SchedulerJobSkips — запуски, пропущенные из-за того, что предыдущий запуск задачи ещё выполняется.
+	SchedulerJobSkips = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "job_skips_total",
+		Help:      "Scheduled job runs skipped because the previous run was still in progress.",
+	}, []string{"job"})
+
+	// BreakerState — текущее состояние circuit breaker'а: 0 — closed, 1 — half-open, 2 — open.
+	BreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "breaker",
+		Name:      "state",
+		Help:      "Current circuit breaker state (0 = closed, 1 = half-open, 2 = open).",
+	}, []string{"name"})
+
+	// BreakerTransitions — переходы circuit breaker'а между состояниями.
+	BreakerTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "breaker",
+		Name:      "transitions_total",
+		Help:      "Circuit breaker state transitions.",
+	}, []string{"name", "from", "to"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		RateLimitRejections,
+		WorkerBatchSize,
+		WorkerPendingOrders,
+		WorkerOrderOutcomes,
+		WorkerPoolSize,
+		WorkerInFlightOrders,
+		AccrualRequestDuration,
+		AccrualRateLimit,
+		AccrualRetries,
+		AccrualCallbackItems,
+		LeaderStatus,
+		SchedulerJobRuns,
+		SchedulerJobDuration,
+		SchedulerJobSkips,
+		BreakerState,
+		BreakerTransitions,
+	)
+}
+
+// RegisterDB регистрирует метрики пула соединений (sql.DB.Stats) под именем dbName.
+func RegisterDB(db *sql.DB, dbName string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler возвращает HTTP-обработчик, отдающий метрики в формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
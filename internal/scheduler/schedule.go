@@ -0,0 +1,223 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule возвращается при некорректном cron-выражении или интервале.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule определяет моменты запуска задачи.
+type Schedule interface {
+	// Next возвращает ближайший момент запуска строго после after.
+	Next(after time.Time) time.Time
+}
+
+// intervalSchedule запускает задачу через фиксированный (или вычисляемый при каждом запуске) интервал.
+type intervalSchedule func() time.Duration
+
+// Every возвращает расписание с запуском раз в interval.
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule(func() time.Duration { return interval })
+}
+
+// EveryFunc возвращает расписание, интервал которого берётся из interval при каждом расчёте
+// следующего запуска; так задача подхватывает интервал, изменённый на лету.
+func EveryFunc(interval func() time.Duration) Schedule {
+	return intervalSchedule(interval)
+}
+
+func (schedule intervalSchedule) Next(after time.Time) time.Time {
+	interval := schedule()
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return after.Add(interval)
+}
+
+// cronSchedule — расписание из пяти полей cron: минута, час, день месяца, месяц, день недели.
+// Каждое поле хранится битовой маской допустимых значений.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny и dowAny — поле начинается с «*»; если ограничены оба дня, достаточно совпадения любого
+	// из них (как в классическом cron).
+	domAny, dowAny bool
+}
+
+// cronField — допустимый диапазон значений поля cron.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// cronSearchLimit ограничивает поиск следующего запуска для выражений, которые никогда не срабатывают
+// (например, «0 0 30 2 *»).
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// Parse разбирает расписание: cron-выражение из пяти полей («*/5 * * * *», «0 3 * * 1-5»; поддерживаются
+// списки, диапазоны и шаги; 0 и 7 в дне недели — воскресенье), «@every <duration>», «@hourly»,
+// «@daily» («@midnight»), «@weekly» или «@monthly». Время cron считается в часовом поясе момента,
+// переданного в Next.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%w %q: interval must be a positive duration", ErrInvalidSchedule, spec)
+		}
+		return Every(interval), nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w %q: expected %d fields, got %d", ErrInvalidSchedule, spec, len(cronFields), len(fields))
+	}
+	var (
+		masks    [5]uint64
+		wildcard [5]bool
+	)
+	for i, field := range fields {
+		mask, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s: %v", ErrInvalidSchedule, spec, cronFields[i].name, err)
+		}
+		masks[i] = mask
+		wildcard[i] = strings.HasPrefix(field, "*")
+	}
+	// 7 в дне недели — тоже воскресенье.
+	if masks[4]&(1<<7) != 0 {
+		masks[4] = masks[4]&^(1<<7) | 1
+	}
+	schedule := &cronSchedule{
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    masks[4],
+		domAny: wildcard[2],
+		dowAny: wildcard[4],
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("%w %q: never fires", ErrInvalidSchedule, spec)
+	}
+	return schedule, nil
+}
+
+// parseCronField разбирает поле cron: список через запятую из «*», «N», «N-M» с необязательным шагом «/S».
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var mask uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = parsed
+		}
+
+		low, high := bounds.min, bounds.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseCronValue(from, bounds); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(to, bounds); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := parseCronValue(rangePart, bounds)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if !hasStep {
+				high = value
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			mask |= 1 << value
+		}
+	}
+	return mask, nil
+}
+
+func parseCronValue(value string, bounds cronField) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < bounds.min || parsed > bounds.max {
+		return 0, fmt.Errorf("value %q out of range %d-%d", value, bounds.min, bounds.max)
+	}
+	return parsed, nil
+}
+
+// Next перебирает время вперёд, пропуская целиком неподходящие месяцы, дни и часы.
+// Для выражения, которое не срабатывает в ближайшие пять лет, возвращает нулевое время.
+func (schedule *cronSchedule) Next(after time.Time) time.Time {
+	location := after.Location()
+	next := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronSearchLimit)
+
+	for next.Before(limit) {
+		if !has(schedule.month, int(next.Month())) {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, location)
+			continue
+		}
+		if !schedule.dayMatches(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, location)
+			continue
+		}
+		if !has(schedule.hour, next.Hour()) {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, location)
+			continue
+		}
+		if !has(schedule.minute, next.Minute()) {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+func (schedule *cronSchedule) dayMatches(at time.Time) bool {
+	domMatch := has(schedule.dom, at.Day())
+	dowMatch := has(schedule.dow, int(at.Weekday()))
+	if !schedule.domAny && !schedule.dowAny {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func has(mask uint64, value int) bool {
+	return mask&(1<<value) != 0
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse_Next(t *testing.T) {
+	// 2024-03-15 — пятница.
+	after := time.Date(2024, 3, 15, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{spec: "* * * * *", want: time.Date(2024, 3, 15, 10, 8, 0, 0, time.UTC)},
+		{spec: "*/5 * * * *", want: time.Date(2024, 3, 15, 10, 10, 0, 0, time.UTC)},
+		{spec: "0 3 * * *", want: time.Date(2024, 3, 16, 3, 0, 0, 0, time.UTC)},
+		{spec: "30 3 * * *", want: time.Date(2024, 3, 16, 3, 30, 0, 0, time.UTC)},
+		{spec: "15,45 10-12 * * *", want: time.Date(2024, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{spec: "0 9 * * 1-5", want: time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)},
+		{spec: "0 0 * * 7", want: time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 1 */3 *", want: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Ограничены оба дня: достаточно совпадения любого (1-е число или понедельник).
+		{spec: "0 0 1 * 1", want: time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{spec: "@hourly", want: time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC)},
+		{spec: "@daily", want: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{spec: "@every 90s", want: after.Add(90 * time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if got := schedule.Next(after); !got.Equal(tt.want) {
+				t.Fatalf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"0 0 30 2 *",
+		"@every 0s",
+		"@every soon",
+	} {
+		if _, err := Parse(spec); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("Parse(%q): expected ErrInvalidSchedule, got %v", spec, err)
+		}
+	}
+}
+
+func TestEveryFunc_UsesCurrentInterval(t *testing.T) {
+	interval := time.Minute
+	schedule := EveryFunc(func() time.Duration { return interval })
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := schedule.Next(after); !got.Equal(after.Add(time.Minute)) {
+		t.Fatalf("unexpected next run: %v", got)
+	}
+	interval = 5 * time.Second
+	if got := schedule.Next(after); !got.Equal(after.Add(5 * time.Second)) {
+		t.Fatalf("interval change not applied: %v", got)
+	}
+}
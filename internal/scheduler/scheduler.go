@@ -0,0 +1,317 @@
+// Package scheduler запускает фоновые задачи по расписанию (cron или интервал) с таймаутом
+// и политикой перекрытия запусков и записывает каждый запуск в журнал.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"loyalty/internal/domain/jobrun/model"
+	"loyalty/internal/logger"
+	"loyalty/internal/metrics"
+	"loyalty/internal/tracing"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// recordTimeout — таймаут записи начала и итога запуска в журнал.
+const recordTimeout = 5 * time.Second
+
+// ErrInvalidJob возвращается из Register для задачи без имени, расписания или функции,
+// с отрицательным таймаутом или с уже занятым именем.
+var ErrInvalidJob = errors.New("invalid job")
+
+// Overlap — что делать, если подошло время запуска, а предыдущий запуск задачи ещё выполняется.
+type Overlap int
+
+const (
+	// OverlapSkip — запуск пропускается.
+	OverlapSkip Overlap = iota
+	// OverlapQueue — запуск выполняется сразу после завершения текущего; несколько отложенных
+	// запусков схлопываются в один.
+	OverlapQueue
+)
+
+// Journal — какие запуски задачи записываются в журнал.
+type Journal int
+
+const (
+	// JournalAll — записывается каждый запуск: RUNNING при старте и итог по завершении.
+	JournalAll Journal = iota
+	// JournalFailures — записываются только неуспешные запуски, одной записью по завершении.
+	// Для частых задач, чтобы журнал не заполнялся успешными запусками.
+	JournalFailures
+)
+
+// Job — фоновая задача планировщика.
+type Job struct {
+	// Name — уникальное имя задачи: метка метрик, поле логов и журнала запусков.
+	Name     string
+	Schedule Schedule
+	// Timeout ограничивает один запуск через ctx (0 — без ограничения).
+	Timeout time.Duration
+	Overlap Overlap
+	Journal Journal
+	// Run выполняет задачу; отмена ctx означает таймаут или остановку планировщика.
+	Run func(ctx context.Context) error
+}
+
+// Recorder — журнал запусков задач (jobrunrepo.JobRunRepository).
+type Recorder interface {
+	Start(ctx context.Context, job string, startedAt time.Time) (int64, error)
+	Finish(ctx context.Context, id int64, finishedAt time.Time, status model.Status, errText string) error
+}
+
+// Scheduler запускает зарегистрированные задачи по их расписаниям.
+// Запуски разных задач выполняются параллельно, запуски одной задачи — никогда.
+type Scheduler struct {
+	recorder Recorder
+
+	mu   sync.Mutex
+	jobs []Job
+
+	// reschedule сигнализирует циклу Start о необходимости пересчитать время следующих запусков.
+	reschedule chan struct{}
+}
+
+// New создаёт планировщик. recorder может быть nil — тогда запуски только логируются и попадают в метрики.
+func New(recorder Recorder) *Scheduler {
+	return &Scheduler{recorder: recorder, reschedule: make(chan struct{}, 1)}
+}
+
+// Register добавляет задачу. Вызывается до Start; задачи, добавленные после, подхватываются
+// при следующем запуске планировщика.
+func (scheduler *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil || job.Timeout < 0 {
+		return fmt.Errorf("%w %q", ErrInvalidJob, job.Name)
+	}
+
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+	if slices.ContainsFunc(scheduler.jobs, func(registered Job) bool { return registered.Name == job.Name }) {
+		return fmt.Errorf("%w %q: duplicate name", ErrInvalidJob, job.Name)
+	}
+	scheduler.jobs = append(scheduler.jobs, job)
+	return nil
+}
+
+// Reschedule пересчитывает время следующих запусков от текущего момента, например после
+// изменения интервала задачи с расписанием EveryFunc. Выполняющиеся запуски не прерываются.
+func (scheduler *Scheduler) Reschedule() {
+	select {
+	case scheduler.reschedule <- struct{}{}:
+	default:
+	}
+}
+
+// Start запускает планировщик в фоне и возвращает канал, который закрывается после его остановки
+// (сигнатура совпадает с leader.Job, поэтому планировщик может работать синглтоном на лидере).
+// Отмена ctx прекращает новые запуски и отменяет ctx выполняющихся; канал закрывается после их завершения.
+func (scheduler *Scheduler) Start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.run(ctx)
+	}()
+	return done
+}
+
+// entry — состояние задачи в рамках одного запуска планировщика.
+type entry struct {
+	job     Job
+	next    time.Time
+	running bool
+	pending bool
+}
+
+func (scheduler *Scheduler) run(ctx context.Context) {
+	ctx = logger.With(ctx, "component", "scheduler")
+
+	scheduler.mu.Lock()
+	jobs := slices.Clone(scheduler.jobs)
+	scheduler.mu.Unlock()
+
+	entries := make([]*entry, len(jobs))
+	now := time.Now()
+	for i, job := range jobs {
+		entries[i] = &entry{job: job, next: job.Schedule.Next(now)}
+	}
+	zerolog.Ctx(ctx).Info().Int("jobs", len(entries)).Msg("scheduler started")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	finished := make(chan *entry)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		timer.Reset(untilNext(entries, time.Now()))
+		select {
+		case <-ctx.Done():
+			zerolog.Ctx(ctx).Info().Msg("scheduler stopped")
+			return
+		case <-scheduler.reschedule:
+			now := time.Now()
+			for _, e := range entries {
+				e.next = e.job.Schedule.Next(now)
+			}
+		case e := <-finished:
+			e.running = false
+			if e.pending {
+				e.pending = false
+				scheduler.launch(ctx, &wg, finished, e)
+			}
+		case <-timer.C:
+			now := time.Now()
+			for _, e := range entries {
+				if e.next.IsZero() || e.next.After(now) {
+					continue
+				}
+				e.next = e.job.Schedule.Next(now)
+				scheduler.trigger(ctx, &wg, finished, e)
+			}
+		}
+	}
+}
+
+// untilNext возвращает время до ближайшего запуска (час, если запускать нечего: таймер просто перевзводится).
+func untilNext(entries []*entry, now time.Time) time.Duration {
+	wait := time.Hour
+	for _, e := range entries {
+		if !e.next.IsZero() {
+			wait = min(wait, e.next.Sub(now))
+		}
+	}
+	return max(wait, 0)
+}
+
+// trigger запускает задачу, если она не выполняется, иначе применяет её политику перекрытия.
+func (scheduler *Scheduler) trigger(ctx context.Context, wg *sync.WaitGroup, finished chan<- *entry, e *entry) {
+	if !e.running {
+		scheduler.launch(ctx, wg, finished, e)
+		return
+	}
+	if e.job.Overlap == OverlapQueue {
+		e.pending = true
+		zerolog.Ctx(ctx).Debug().Str("job", e.job.Name).Msg("job run queued behind the running one")
+		return
+	}
+	metrics.SchedulerJobSkips.WithLabelValues(e.job.Name).Inc()
+	zerolog.Ctx(ctx).Info().Str("job", e.job.Name).Msg("job run skipped: previous run still in progress")
+}
+
+func (scheduler *Scheduler) launch(ctx context.Context, wg *sync.WaitGroup, finished chan<- *entry, e *entry) {
+	e.running = true
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		scheduler.execute(ctx, e.job)
+		select {
+		case finished <- e:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// execute выполняет один запуск задачи с её таймаутом и записывает его в журнал (с JournalFailures —
+// только неуспешный, по завершении). Записи лога запуска содержат поле job и, если запуск записан
+// в журнал, job_run_id — его ID в журнале.
+func (scheduler *Scheduler) execute(ctx context.Context, job Job) {
+	ctx = logger.With(ctx, "job", job.Name)
+	ctx, span := tracing.Start(ctx, "Scheduler.execute", attribute.String("job.name", job.Name))
+	var spanErr error
+	defer func() { tracing.End(span, spanErr) }()
+
+	startedAt := time.Now()
+	var id int64
+	if job.Journal == JournalAll {
+		id = scheduler.recordStart(ctx, job.Name, startedAt)
+	}
+	if id != 0 {
+		ctx = logger.With(ctx, "job_run_id", strconv.FormatInt(id, 10))
+	}
+	zerolog.Ctx(ctx).Debug().Msg("job run started")
+
+	runCtx, cancel := ctx, context.CancelFunc(func() {})
+	if job.Timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, job.Timeout)
+	}
+	err := call(runCtx, job)
+	timedOut := errors.Is(runCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	cancel()
+
+	status := model.StatusSucceeded
+	switch {
+	case err == nil:
+	case timedOut:
+		status = model.StatusTimedOut
+	case ctx.Err() != nil:
+		status = model.StatusCanceled
+	default:
+		status = model.StatusFailed
+	}
+	spanErr = err
+
+	finishedAt := time.Now()
+	duration := finishedAt.Sub(startedAt)
+	metrics.SchedulerJobRuns.WithLabelValues(job.Name, string(status)).Inc()
+	metrics.SchedulerJobDuration.WithLabelValues(job.Name).Observe(duration.Seconds())
+	if job.Journal == JournalFailures && status != model.StatusSucceeded {
+		if id = scheduler.recordStart(ctx, job.Name, startedAt); id != 0 {
+			ctx = logger.With(ctx, "job_run_id", strconv.FormatInt(id, 10))
+		}
+	}
+	scheduler.recordFinish(ctx, id, finishedAt, status, err)
+
+	event := zerolog.Ctx(ctx).Info()
+	if err != nil {
+		event = zerolog.Ctx(ctx).Error().Err(err)
+	}
+	event.Str("status", string(status)).Dur("duration", duration).Msg("job run finished")
+}
+
+// call вызывает задачу, превращая панику в ошибку запуска, чтобы она не роняла сервис.
+func call(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// recordStart записывает начало запуска; при ошибке записи (или без журнала) возвращает 0.
+// Журнал пишется и при остановке, чтобы прерванный запуск не остался в статусе RUNNING.
+func (scheduler *Scheduler) recordStart(ctx context.Context, job string, startedAt time.Time) int64 {
+	if scheduler.recorder == nil {
+		return 0
+	}
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	id, err := scheduler.recorder.Start(recordCtx, job, startedAt)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("failed to record job run start")
+		return 0
+	}
+	return id
+}
+
+func (scheduler *Scheduler) recordFinish(ctx context.Context, id int64, finishedAt time.Time, status model.Status, runErr error) {
+	if scheduler.recorder == nil || id == 0 {
+		return
+	}
+	errText := ""
+	if runErr != nil {
+		errText = runErr.Error()
+	}
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	if err := scheduler.recorder.Finish(recordCtx, id, finishedAt, status, errText); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("failed to record job run result")
+	}
+}
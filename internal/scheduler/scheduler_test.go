@@ -0,0 +1,268 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"loyalty/internal/domain/jobrun/model"
+)
+
+// memoryRecorder хранит запуски в памяти.
+type memoryRecorder struct {
+	mu   sync.Mutex
+	runs []model.Run
+}
+
+func (r *memoryRecorder) Start(_ context.Context, job string, startedAt time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, model.Run{ID: int64(len(r.runs) + 1), Job: job, Status: model.StatusRunning, StartedAt: startedAt})
+	return int64(len(r.runs)), nil
+}
+
+func (r *memoryRecorder) Finish(_ context.Context, id int64, finishedAt time.Time, status model.Status, errText string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run := &r.runs[id-1]
+	run.Status, run.Error, run.FinishedAt = status, errText, &finishedAt
+	return nil
+}
+
+func (r *memoryRecorder) finished() []model.Run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []model.Run
+	for _, run := range r.runs {
+		if run.FinishedAt != nil {
+			out = append(out, run)
+		}
+	}
+	return out
+}
+
+// waitFor ждёт выполнения условия не дольше секунды.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// burstSchedule срабатывает через 1ms первые fires раз, а затем — через час.
+type burstSchedule struct {
+	fires int64
+	calls atomic.Int64
+}
+
+func (s *burstSchedule) Next(after time.Time) time.Time {
+	if s.calls.Add(1) <= s.fires {
+		return after.Add(time.Millisecond)
+	}
+	return after.Add(time.Hour)
+}
+
+func TestScheduler_Register_Invalid(t *testing.T) {
+	scheduler := New(nil)
+	run := func(context.Context) error { return nil }
+	valid := Job{Name: "job", Schedule: Every(time.Second), Run: run}
+	if err := scheduler.Register(valid); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	for name, job := range map[string]Job{
+		"no name":          {Schedule: Every(time.Second), Run: run},
+		"no schedule":      {Name: "other", Run: run},
+		"no run":           {Name: "other", Schedule: Every(time.Second)},
+		"negative timeout": {Name: "other", Schedule: Every(time.Second), Run: run, Timeout: -time.Second},
+		"duplicate name":   valid,
+	} {
+		if err := scheduler.Register(job); !errors.Is(err, ErrInvalidJob) {
+			t.Errorf("%s: expected ErrInvalidJob, got %v", name, err)
+		}
+	}
+}
+
+func TestScheduler_RecordsRunStatuses(t *testing.T) {
+	recorder := &memoryRecorder{}
+	scheduler := New(recorder)
+	jobs := []Job{
+		{Name: "ok", Schedule: &burstSchedule{fires: 1}, Run: func(context.Context) error { return nil }},
+		{Name: "failing", Schedule: &burstSchedule{fires: 1}, Run: func(context.Context) error { return errors.New("boom") }},
+		{Name: "slow", Schedule: &burstSchedule{fires: 1}, Timeout: 5 * time.Millisecond, Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		{Name: "panicking", Schedule: &burstSchedule{fires: 1}, Run: func(context.Context) error { panic("oops") }},
+	}
+	for _, job := range jobs {
+		if err := scheduler.Register(job); err != nil {
+			t.Fatalf("register %s: %v", job.Name, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := scheduler.Start(ctx)
+	waitFor(t, "all runs to finish", func() bool { return len(recorder.finished()) == len(jobs) })
+	cancel()
+	<-done
+
+	want := map[string]model.Status{
+		"ok":        model.StatusSucceeded,
+		"failing":   model.StatusFailed,
+		"slow":      model.StatusTimedOut,
+		"panicking": model.StatusFailed,
+	}
+	for _, run := range recorder.finished() {
+		if run.Status != want[run.Job] {
+			t.Errorf("%s: status %s, want %s", run.Job, run.Status, want[run.Job])
+		}
+		if (run.Status == model.StatusSucceeded) != (run.Error == "") {
+			t.Errorf("%s: unexpected error text %q", run.Job, run.Error)
+		}
+		if run.FinishedAt.Before(run.StartedAt) {
+			t.Errorf("%s: finished before start", run.Job)
+		}
+	}
+}
+
+func TestScheduler_JournalFailures(t *testing.T) {
+	recorder := &memoryRecorder{}
+	scheduler := New(recorder)
+	var runs atomic.Int64
+	job := Job{Name: "sweep", Schedule: &burstSchedule{fires: 3}, Journal: JournalFailures, Run: func(context.Context) error {
+		if runs.Add(1) == 2 {
+			return errors.New("boom")
+		}
+		return nil
+	}}
+	if err := scheduler.Register(job); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := scheduler.Start(ctx)
+	waitFor(t, "three runs", func() bool { return runs.Load() == 3 })
+	cancel()
+	<-done
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if len(recorder.runs) != 1 {
+		t.Fatalf("want only the failed run recorded, got %+v", recorder.runs)
+	}
+	if run := recorder.runs[0]; run.Status != model.StatusFailed || run.Error != "boom" || run.FinishedAt == nil {
+		t.Fatalf("unexpected recorded run: %+v", run)
+	}
+}
+
+func TestScheduler_Overlap(t *testing.T) {
+	tests := []struct {
+		overlap  Overlap
+		wantRuns int64
+	}{
+		{overlap: OverlapSkip, wantRuns: 1},
+		// Четыре срабатывания во время первого запуска схлопываются в один отложенный.
+		{overlap: OverlapQueue, wantRuns: 2},
+	}
+	for _, tt := range tests {
+		schedule := &burstSchedule{fires: 5}
+		release := make(chan struct{})
+		var runs atomic.Int64
+		scheduler := New(nil)
+		err := scheduler.Register(Job{Name: "job", Schedule: schedule, Overlap: tt.overlap, Run: func(context.Context) error {
+			if runs.Add(1) == 1 {
+				<-release
+			}
+			return nil
+		}})
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := scheduler.Start(ctx)
+		waitFor(t, "all triggers", func() bool { return schedule.calls.Load() > schedule.fires })
+		if got := runs.Load(); got != 1 {
+			t.Fatalf("overlap %d: runs must not overlap, started %d", tt.overlap, got)
+		}
+		close(release)
+		waitFor(t, "queued runs", func() bool { return runs.Load() >= tt.wantRuns })
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		<-done
+
+		if got := runs.Load(); got != tt.wantRuns {
+			t.Fatalf("overlap %d: %d runs, want %d", tt.overlap, got, tt.wantRuns)
+		}
+	}
+}
+
+func TestScheduler_StopCancelsRunningJob(t *testing.T) {
+	recorder := &memoryRecorder{}
+	scheduler := New(recorder)
+	started := make(chan struct{})
+	err := scheduler.Register(Job{Name: "job", Schedule: &burstSchedule{fires: 1}, Run: func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := scheduler.Start(ctx)
+	<-started
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+
+	runs := recorder.finished()
+	if len(runs) != 1 || runs[0].Status != model.StatusCanceled {
+		t.Fatalf("expected one canceled run, got %+v", runs)
+	}
+}
+
+func TestScheduler_Reschedule(t *testing.T) {
+	var interval atomic.Int64
+	interval.Store(int64(time.Hour))
+	var runs atomic.Int64
+	scheduler := New(nil)
+	err := scheduler.Register(Job{
+		Name:     "job",
+		Schedule: EveryFunc(func() time.Duration { return time.Duration(interval.Load()) }),
+		Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := scheduler.Start(ctx)
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if runs.Load() != 0 {
+		t.Fatalf("job must not run before its interval")
+	}
+	interval.Store(int64(time.Millisecond))
+	scheduler.Reschedule()
+	waitFor(t, "runs with the new interval", func() bool { return runs.Load() >= 3 })
+}
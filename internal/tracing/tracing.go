@@ -0,0 +1,148 @@
+// Package tracing настраивает OpenTelemetry-трассировку и содержит хелперы для создания спанов.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName — имя, под которым сервис создаёт собственные спаны.
+const instrumentationName = "loyalty"
+
+// Ключи атрибутов спанов, общие для всех слоёв.
+const (
+	AttrUserID      = attribute.Key("user.id")
+	AttrOrderNumber = attribute.Key("order.number")
+)
+
+// Exporter — способ выгрузки спанов.
+type Exporter string
+
+const (
+	// ExporterNone отключает выгрузку: спаны не записываются, но контекст (traceparent) пробрасывается.
+	ExporterNone Exporter = "none"
+	// ExporterStdout печатает спаны в stdout (для локального запуска).
+	ExporterStdout Exporter = "stdout"
+	// ExporterOTLP отправляет спаны по OTLP/HTTP.
+	ExporterOTLP Exporter = "otlp"
+)
+
+// ErrUnknownExporter возвращается при неизвестном значении экспортёра.
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// Config содержит параметры трассировки.
+type Config struct {
+	Exporter    Exporter
+	ServiceName string
+	// OTLPEndpoint — адрес коллектора (host:port или URL); пустой — берётся из OTEL_EXPORTER_OTLP_ENDPOINT.
+	OTLPEndpoint string
+	// SampleRatio — доля трассируемых корневых запросов (0..1).
+	SampleRatio float64
+}
+
+// ParseExporter разбирает название экспортёра; пустая строка означает ExporterNone.
+func ParseExporter(value string) (Exporter, error) {
+	switch exporter := Exporter(strings.ToLower(strings.TrimSpace(value))); exporter {
+	case "":
+		return ExporterNone, nil
+	case ExporterNone, ExporterStdout, ExporterOTLP:
+		return exporter, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownExporter, value)
+	}
+}
+
+// Init настраивает глобальные TracerProvider и W3C-пропагатор.
+// Возвращает функцию, которая сбрасывает накопленные спаны и останавливает провайдер.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			if strings.Contains(cfg.OTLPEndpoint, "://") {
+				opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+			} else {
+				opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint), otlptracehttp.WithInsecure())
+			}
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = instrumentationName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("build resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start открывает спан с именем name как дочерний к спану из ctx.
+// Трейсер берётся из глобального провайдера при каждом вызове, поэтому Init может быть вызван позже.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End завершает спан, помечая его ошибкой, если err != nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// UserID возвращает атрибут спана с идентификатором пользователя.
+func UserID(userID int64) attribute.KeyValue {
+	return AttrUserID.Int64(userID)
+}
+
+// OrderNumber возвращает атрибут спана с номером заказа.
+func OrderNumber(number string) attribute.KeyValue {
+	return AttrOrderNumber.String(number)
+}
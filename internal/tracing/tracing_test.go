@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestParseExporter(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Exporter
+		wantErr bool
+	}{
+		{"", ExporterNone, false},
+		{"none", ExporterNone, false},
+		{" Stdout ", ExporterStdout, false},
+		{"OTLP", ExporterOTLP, false},
+		{"jaeger", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseExporter(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownExporter) {
+					t.Fatalf("expected ErrUnknownExporter, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseExporter(%q) = %q, %v; want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInit_Exporters(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(sdktrace.NewTracerProvider()) })
+
+	for _, exporter := range []Exporter{ExporterNone, ExporterStdout} {
+		shutdown, err := Init(context.Background(), Config{Exporter: exporter, SampleRatio: 1})
+		if err != nil {
+			t.Fatalf("Init(%s): unexpected err: %v", exporter, err)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Fatalf("shutdown(%s): unexpected err: %v", exporter, err)
+		}
+	}
+
+	if _, err := Init(context.Background(), Config{Exporter: "unknown"}); !errors.Is(err, ErrUnknownExporter) {
+		t.Fatalf("expected ErrUnknownExporter, got %v", err)
+	}
+}
+
+func TestStartEnd_RecordsAttributesAndError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(sdktrace.NewTracerProvider()) })
+
+	_, span := Start(context.Background(), "test.span", UserID(7), OrderNumber("12345678903"))
+	End(span, errors.New("boom"))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	got := spans[0]
+	if got.Name() != "test.span" {
+		t.Fatalf("unexpected span name %q", got.Name())
+	}
+	if got.Status().Code != codes.Error {
+		t.Fatalf("expected error status, got %v", got.Status())
+	}
+
+	attrs := map[string]string{}
+	for _, attr := range got.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	if attrs[string(AttrUserID)] != "7" || attrs[string(AttrOrderNumber)] != "12345678903" {
+		t.Fatalf("unexpected attributes: %v", attrs)
+	}
+}
@@ -0,0 +1,148 @@
+package accrual
+
+import (
+	"context"
+	ordersmodel "loyalty/internal/domain/order/model"
+	"loyalty/internal/metrics"
+	"sync"
+)
+
+// job — заказ, переданный в пул, и идентификатор прохода, который его поставил.
+type job struct {
+	order   ordersmodel.Order
+	batchID string
+}
+
+// pool — долгоживущий пул обработчиков заказов.
+// Заказ, который уже в работе (передан обработчику или ждёт передачи), повторно не принимается,
+// поэтому один и тот же заказ никогда не обрабатывается параллельно. Передача блокирует
+// производителя, пока не освободится обработчик (обратное давление). Размер меняется на лету.
+type pool struct {
+	ctx    context.Context
+	handle func(ctx context.Context, j job)
+	jobs   chan job
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// size — целевое число обработчиков, live — число запущенных. Лишний обработчик (live > size)
+	// завершается сам, когда свободен, поэтому уменьшение и последующее увеличение не теряют обработчиков.
+	size int
+	live int
+	// resized закрывается при смене размера, чтобы свободные обработчики проверили, не лишние ли они.
+	resized  chan struct{}
+	inFlight map[string]struct{}
+}
+
+// newPool запускает size обработчиков. Отмена ctx останавливает пул: свободные обработчики
+// завершаются сразу, занятые — после текущего заказа.
+func newPool(ctx context.Context, size int, handle func(ctx context.Context, j job)) *pool {
+	p := &pool{
+		ctx:      ctx,
+		handle:   handle,
+		jobs:     make(chan job),
+		resized:  make(chan struct{}),
+		inFlight: make(map[string]struct{}),
+	}
+	p.Resize(size)
+	return p
+}
+
+// Resize меняет число обработчиков. Лишние обработчики завершаются после текущего заказа.
+func (p *pool) Resize(size int) {
+	if size < 1 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.size = size
+	for p.live < p.size {
+		p.live++
+		p.wg.Add(1)
+		go p.loop()
+	}
+	close(p.resized)
+	p.resized = make(chan struct{})
+	metrics.WorkerPoolSize.Set(float64(p.size))
+}
+
+// Submit передаёт заказ свободному обработчику, блокируясь до его появления, отмены ctx или остановки пула.
+// Возвращает false, если заказ уже в работе, ctx отменён или пул остановлен.
+func (p *pool) Submit(ctx context.Context, j job) bool {
+	p.mu.Lock()
+	if _, busy := p.inFlight[j.order.Number]; busy {
+		p.mu.Unlock()
+		return false
+	}
+	p.inFlight[j.order.Number] = struct{}{}
+	metrics.WorkerInFlightOrders.Set(float64(len(p.inFlight)))
+	p.mu.Unlock()
+
+	select {
+	case p.jobs <- j:
+		return true
+	case <-ctx.Done():
+		p.release(j.order.Number)
+		return false
+	case <-p.ctx.Done():
+		p.release(j.order.Number)
+		return false
+	}
+}
+
+// Size возвращает целевое число обработчиков.
+func (p *pool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size
+}
+
+// Live возвращает число запущенных обработчиков (больше Size, пока лишние дообрабатывают заказы).
+func (p *pool) Live() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.live
+}
+
+// Len возвращает число заказов в работе.
+func (p *pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.inFlight)
+}
+
+// Wait ждёт завершения всех обработчиков после остановки пула.
+func (p *pool) Wait() {
+	p.wg.Wait()
+}
+
+// loop — обработчик пула. Решение о выходе лишнего обработчика и уменьшение live принимаются под
+// одной блокировкой, иначе несколько свободных обработчиков могли бы выйти за один лишний.
+func (p *pool) loop() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		if p.live > p.size || p.ctx.Err() != nil {
+			p.live--
+			p.mu.Unlock()
+			return
+		}
+		resized := p.resized
+		p.mu.Unlock()
+
+		select {
+		case <-p.ctx.Done():
+		case <-resized:
+		case j := <-p.jobs:
+			p.handle(p.ctx, j)
+			p.release(j.order.Number)
+		}
+	}
+}
+
+func (p *pool) release(number string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.inFlight, number)
+	metrics.WorkerInFlightOrders.Set(float64(len(p.inFlight)))
+}
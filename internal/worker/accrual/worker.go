@@ -3,36 +3,89 @@ package accrual
 import (
 	"context"
 	"errors"
+	"fmt"
 	"loyalty/internal/domain/accrual/client"
 	"loyalty/internal/domain/accrual/model"
 	ordersmodel "loyalty/internal/domain/order/model"
 	ordersrepo "loyalty/internal/domain/order/repository"
 	orderssvc "loyalty/internal/domain/order/service"
+	tiersvc "loyalty/internal/domain/tier/service"
+	"loyalty/internal/logger"
+	"loyalty/internal/metrics"
+	"loyalty/internal/tracing"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// Значения метки outcome для заказов, по которым не удалось получить статус accrual.
+const (
+	outcomeRateLimited   = "rate_limited"
+	outcomeUnavailable   = "unavailable"
+	outcomeError         = "error"
+	outcomeNotRegistered = "not_registered"
+	outcomeUpdateFailed  = "update_failed"
+	outcomeDeferred      = "deferred"
+)
+
+// ErrNotRunning возвращается из Sweep, если воркер не запущен.
+var ErrNotRunning = errors.New("accrual worker is not running")
+
+// Notifier — источник уведомлений о новых заказах (например, LISTEN orders_new в PostgreSQL).
+type Notifier interface {
+	// Listen блокируется до отмены ctx и вызывает notify на каждое уведомление.
+	// Реализация сама восстанавливает соединение после его потери и вызывает notify
+	// после каждого (пере)подключения, чтобы не пропустить заказы, созданные без слушателя.
+	Listen(ctx context.Context, notify func())
+}
+
 // Worker — фоновый воркер для обновления статусов заказов через систему accrual.
 type Worker struct {
-	ordersRepo     ordersrepo.OrdersRepository
-	ordersService  orderssvc.OrdersService
-	accrualClient  client.AccrualClient
-	pollInterval   time.Duration
-	maxConcurrency int
-	queryTimeout   time.Duration
-	requestDelay   time.Duration
-	retryAfterMin  time.Duration
+	ordersRepo    ordersrepo.OrdersRepository
+	ordersService orderssvc.OrdersService
+	accrualClient client.AccrualClient
+	tierService   tiersvc.TierService
+	notifier      Notifier
+	queryTimeout  time.Duration
+	requestDelay  time.Duration
+	retryAfterMin time.Duration
+	sweepInterval time.Duration
+	// accrualTimeout ограничивает запрос начисления по заказу вместе с повторами клиента.
+	accrualTimeout time.Duration
+
+	// pollInterval (наносекунды) и maxConcurrency меняются на лету через Reconfigure.
+	pollInterval   atomic.Int64
+	maxConcurrency atomic.Int64
+	// reconfigured сигнализирует циклу Start о смене размера пула.
+	reconfigured chan struct{}
+	// wakeup сигнализирует циклу Start об уведомлении о новых заказах; уведомления,
+	// пришедшие во время прохода, схлопываются в один следующий проход.
+	wakeup chan struct{}
+
+	// pausedUntil — до какого времени отложены запросы к системам accrual (по провайдерам)
+	// после 429 или недоступности; заказы остальных систем обрабатываются как обычно.
+	pausedMu    sync.Mutex
+	pausedUntil map[string]time.Time
+
+	// orders — пул обработчиков запущенного воркера (nil, пока воркер не запущен).
+	orders atomic.Pointer[pool]
+
+	// heartbeat — время (UnixNano) последнего завершённого прохода воркера.
+	heartbeat atomic.Int64
 }
 
 // Config содержит параметры воркера.
 type Config struct {
 	PollInterval   time.Duration // Интервал опроса БД (по умолчанию 5s)
-	MaxConcurrency int           // Количество параллельных воркеров (по умолчанию 5)
+	MaxConcurrency int           // Размер пула обработчиков заказов (по умолчанию 5)
 	QueryTimeout   time.Duration // Таймаут для БД операций (по умолчанию 3s)
-	RequestDelay   time.Duration // Задержка между запросами (по умолчанию 100ms)
-	RetryAfterMin  time.Duration // Минимальная пауза при 429 (по умолчанию 60s)
+	RequestDelay   time.Duration // Задержка между запросами (по умолчанию 0: темп задаёт лимитер клиента accrual)
+	RetryAfterMin  time.Duration // Минимальная пауза запросов к системе accrual после 429/недоступности (по умолчанию 60s)
+	SweepInterval  time.Duration // Интервал страховочного опроса при наличии уведомлений (по умолчанию 1m; 0 — PollInterval)
+	AccrualTimeout time.Duration // Дедлайн запроса начисления по заказу со всеми повторами (по умолчанию 10s; 0 — без ограничения)
 }
 
 // DefaultConfig возвращает дефолтную конфигурацию воркера.
@@ -41,140 +94,422 @@ func DefaultConfig() Config {
 		PollInterval:   5 * time.Second,
 		MaxConcurrency: 5,
 		QueryTimeout:   3 * time.Second,
-		RequestDelay:   100 * time.Millisecond,
+		RequestDelay:   0,
 		RetryAfterMin:  60 * time.Second,
+		SweepInterval:  time.Minute,
+		AccrualTimeout: 10 * time.Second,
 	}
 }
 
 // NewWorker создаёт воркер для обновления заказов через accrual.
+// tierService может быть nil — тогда уровни пользователей после начислений не пересчитываются.
+// notifier может быть nil — тогда новые заказы подхватываются только опросом (Sweep) раз в PollInterval;
+// с notifier заказ обрабатывается сразу по уведомлению, а опрос раз в SweepInterval остаётся страховкой.
+// Опрос запускает планировщик с интервалом TickInterval.
 func NewWorker(
 	ordersRepo ordersrepo.OrdersRepository,
 	ordersService orderssvc.OrdersService,
 	accrualClient client.AccrualClient,
+	tierService tiersvc.TierService,
+	notifier Notifier,
 	cfg Config,
 ) *Worker {
-	return &Worker{
-		ordersRepo:     ordersRepo,
-		ordersService:  ordersService,
-		accrualClient:  accrualClient,
-		pollInterval:   cfg.PollInterval,
-		maxConcurrency: cfg.MaxConcurrency,
-		queryTimeout:   cfg.QueryTimeout,
-		requestDelay:   cfg.RequestDelay,
-		retryAfterMin:  cfg.RetryAfterMin,
-	}
-}
-
-// Start запускает воркер в фоне. Блокируется до отмены ctx.
-func (worker *Worker) Start(ctx context.Context) {
-	log.Info().
-		Dur("poll_interval", worker.pollInterval).
-		Int("max_concurrency", worker.maxConcurrency).
+	worker := &Worker{
+		ordersRepo:    ordersRepo,
+		ordersService: ordersService,
+		accrualClient: accrualClient,
+		tierService:   tierService,
+		notifier:      notifier,
+		queryTimeout:  cfg.QueryTimeout,
+		requestDelay:  cfg.RequestDelay,
+		retryAfterMin: cfg.RetryAfterMin,
+		sweepInterval: cfg.SweepInterval,
+		reconfigured:  make(chan struct{}, 1),
+		wakeup:        make(chan struct{}, 1),
+
+		accrualTimeout: cfg.AccrualTimeout,
+		pausedUntil:    make(map[string]time.Time),
+	}
+	worker.pollInterval.Store(int64(cfg.PollInterval))
+	worker.maxConcurrency.Store(int64(cfg.MaxConcurrency))
+	return worker
+}
+
+// Reconfigure меняет интервал опроса и размер пула обработчиков без перезапуска.
+// Неположительные значения игнорируются. Новый интервал опроса применяет планировщик (Reschedule),
+// пул расширяется сразу, а при уменьшении лишние обработчики завершаются после текущего заказа.
+func (worker *Worker) Reconfigure(pollInterval time.Duration, maxConcurrency int) {
+	if pollInterval > 0 {
+		worker.pollInterval.Store(int64(pollInterval))
+	}
+	if maxConcurrency > 0 && worker.maxConcurrency.Swap(int64(maxConcurrency)) != int64(maxConcurrency) {
+		select {
+		case worker.reconfigured <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// PollInterval возвращает текущий интервал опроса.
+func (worker *Worker) PollInterval() time.Duration {
+	return time.Duration(worker.pollInterval.Load())
+}
+
+// TickInterval возвращает интервал опроса БД: SweepInterval, если воркер получает уведомления, иначе PollInterval.
+func (worker *Worker) TickInterval() time.Duration {
+	if worker.notifier != nil && worker.sweepInterval > 0 {
+		return worker.sweepInterval
+	}
+	return worker.PollInterval()
+}
+
+// MaxConcurrency возвращает текущее число параллельных запросов к accrual.
+func (worker *Worker) MaxConcurrency() int {
+	return int(worker.maxConcurrency.Load())
+}
+
+// Start запускает воркер в фоне и возвращает канал, который закрывается после его остановки.
+// Воркер держит пул из MaxConcurrency обработчиков; по вызову Sweep (и по уведомлениям notifier, если он задан)
+// производитель выбирает ожидающие заказы и по одному передаёт их в пул, пропуская заказы, которые
+// уже в работе, и ожидая свободного обработчика. Медленный заказ занимает только свой обработчик.
+// Отмена ctx останавливает воркер и подписку: новые заказы не берутся, а заказы, уже переданные
+// в accrual, дообрабатываются (HTTP-запрос и обновление в БД ограничены своими таймаутами),
+// после чего канал закрывается. Записи лога воркера содержат поле component, записи прохода
+// производителя — batch_id, записи обработки заказа — batch_id поставившего его прохода, order и order_run_id.
+func (worker *Worker) Start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		worker.run(ctx)
+	}()
+	return done
+}
+
+func (worker *Worker) run(ctx context.Context) {
+	ctx = logger.With(ctx, "component", "accrual_worker")
+	zerolog.Ctx(ctx).Info().
+		Dur("poll_interval", worker.TickInterval()).
+		Bool("notifications", worker.notifier != nil).
+		Int("max_concurrency", worker.MaxConcurrency()).
 		Msg("accrual worker started")
 
-	ticker := time.NewTicker(worker.pollInterval)
-	defer ticker.Stop()
+	if worker.notifier != nil {
+		listenerDone := make(chan struct{})
+		go func() {
+			defer close(listenerDone)
+			worker.notifier.Listen(ctx, worker.wake)
+		}()
+		defer func() { <-listenerDone }()
+	}
+
+	orders := newPool(ctx, worker.MaxConcurrency(), worker.handle)
+	defer orders.Wait()
+	worker.orders.Store(orders)
+	defer worker.orders.Store(nil)
+
+	// Передача заказов по уведомлениям блокируется, пока обработчики заняты, поэтому идёт отдельно:
+	// смена размера пула не ждёт, пока будет передана вся очередь.
+	producerDone := make(chan struct{})
+	go func() {
+		defer close(producerDone)
+		worker.produce(ctx, orders)
+	}()
+	defer func() { <-producerDone }()
+
+	worker.beat()
+	for {
+		select {
+		case <-ctx.Done():
+			zerolog.Ctx(ctx).Info().Msg("accrual worker stopped")
+			return
+		case <-worker.reconfigured:
+			orders.Resize(worker.MaxConcurrency())
+			zerolog.Ctx(ctx).Info().
+				Int("max_concurrency", worker.MaxConcurrency()).
+				Msg("accrual worker reconfigured")
+		}
+	}
+}
 
+// produce передаёт в пул ожидающие заказы по каждому уведомлению до остановки воркера.
+func (worker *Worker) produce(ctx context.Context, orders *pool) {
 	for {
 		select {
 		case <-ctx.Done():
-			log.Info().Msg("accrual worker stopped")
 			return
-		case <-ticker.C:
-			worker.processBatch(ctx)
+		case <-worker.wakeup:
+			_ = worker.enqueuePending(ctx, orders)
+			worker.beat()
 		}
 	}
 }
 
-func (worker *Worker) processBatch(ctx context.Context) {
+// Sweep — страховочный опрос: выбирает ожидающие заказы и передаёт в пул запущенного воркера те,
+// что ещё не в работе. Вызывается планировщиком раз в TickInterval; отмена ctx прерывает передачу
+// (уже переданные заказы дообрабатываются). Возвращает ErrNotRunning, если воркер не запущен.
+func (worker *Worker) Sweep(ctx context.Context) error {
+	orders := worker.orders.Load()
+	if orders == nil {
+		return ErrNotRunning
+	}
+	ctx = logger.With(ctx, "component", "accrual_worker")
+	err := worker.enqueuePending(ctx, orders)
+	worker.beat()
+	return err
+}
+
+// wake будит цикл воркера; если проход уже запланирован, уведомление схлопывается с ним.
+func (worker *Worker) wake() {
+	select {
+	case worker.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Heartbeat возвращает время последнего завершённого прохода воркера (нулевое, если воркер не запущен).
+func (worker *Worker) Heartbeat() time.Time {
+	nanos := worker.heartbeat.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+func (worker *Worker) beat() {
+	worker.heartbeat.Store(time.Now().UnixNano())
+}
+
+// enqueuePending — проход производителя: выбирает ожидающие заказы и передаёт в пул те, что ещё не в работе.
+// Возвращается, когда все выбранные заказы переданы обработчикам (или пропущены), либо при остановке.
+// Ошибка означает, что ожидающие заказы выбрать не удалось.
+func (worker *Worker) enqueuePending(ctx context.Context, orders *pool) (err error) {
+	ctx, span := tracing.Start(ctx, "AccrualWorker.enqueuePending")
+	defer func() { tracing.End(span, err) }()
+	batchID := logger.NewID()
+	ctx = logger.With(ctx, "batch_id", batchID)
+
 	queryCtx, cancel := context.WithTimeout(ctx, worker.queryTimeout)
 	defer cancel()
 
-	orders, err := worker.ordersRepo.ListPending(queryCtx)
+	pending, err := worker.ordersRepo.ListPending(queryCtx)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to list pending orders")
-		return
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list pending orders")
+		return fmt.Errorf("list pending orders: %w", err)
 	}
 
-	if len(orders) == 0 {
-		return
+	span.SetAttributes(attribute.Int("batch.size", len(pending)))
+	metrics.WorkerPendingOrders.Set(float64(len(pending)))
+	metrics.WorkerBatchSize.Observe(float64(len(pending)))
+
+	if len(pending) == 0 {
+		return nil
 	}
 
-	log.Debug().Int("count", len(orders)).Msg("processing pending orders")
+	zerolog.Ctx(ctx).Debug().Int("count", len(pending)).Msg("enqueueing pending orders")
 
-	ordersChan := make(chan ordersmodel.Order, len(orders))
+	enqueued := 0
+	for _, order := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		if orders.Submit(ctx, job{order: order, batchID: batchID}) {
+			enqueued++
+		}
+	}
+	span.SetAttributes(attribute.Int("batch.enqueued", enqueued))
+	return nil
+}
 
-	var wg sync.WaitGroup
-	for i := 0; i < worker.maxConcurrency; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
+// handle обрабатывает заказ в обработчике пула и пересчитывает уровень пользователя после начисления.
+// Уровень пересчитывается и при остановке: начисление по заказу уже записано.
+func (worker *Worker) handle(ctx context.Context, j job) {
+	ctx = logger.With(ctx, "batch_id", j.batchID)
+	if !worker.pause(ctx, worker.requestDelay) {
+		return
+	}
+	if worker.processOrder(ctx, j.order) {
+		worker.recalculateTier(context.WithoutCancel(ctx), j.order.UserID)
+	}
+}
 
-			for order := range ordersChan {
-				if ctx.Err() != nil {
-					return
-				}
+// pause ждёт delay или остановки воркера; возвращает false, если воркер остановлен.
+func (worker *Worker) pause(ctx context.Context, delay time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
 
-				time.Sleep(worker.requestDelay)
-				worker.processOrder(ctx, order)
-			}
-		}(i)
+// recalculateTier пересчитывает уровень пользователя, по заказу которого было начисление.
+func (worker *Worker) recalculateTier(ctx context.Context, userID int64) {
+	if worker.tierService == nil {
+		return
 	}
 
-	for _, order := range orders {
-		ordersChan <- order
+	recalcCtx, cancel := context.WithTimeout(ctx, worker.queryTimeout)
+	tier, err := worker.tierService.Recalculate(recalcCtx, userID)
+	cancel()
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Int64("user_id", userID).Msg("failed to recalculate user tier")
+		return
 	}
-	close(ordersChan)
 
-	wg.Wait()
+	zerolog.Ctx(ctx).Debug().
+		Int64("user_id", userID).
+		Str("tier", string(tier.Level)).
+		Str("rolling_accrual", tier.RollingAccrual.String()).
+		Msg("user tier recalculated")
 }
 
-func (worker *Worker) processOrder(ctx context.Context, order ordersmodel.Order) {
-	accrualResp, err := worker.accrualClient.GetOrderAccrual(ctx, order.Number)
+// processOrder запрашивает начисление по заказу и обновляет заказ.
+// Возвращает true, если заказ перешёл в финальный статус PROCESSED.
+// После 429 или недоступности системы accrual её заказы откладываются на RetryAfterMin
+// (остаются ожидающими до следующего прохода), а заказы других систем обрабатываются без паузы.
+// Начатая обработка не прерывается отменой ctx (остановкой воркера), чтобы заказ не остался
+// обновлённым наполовину.
+// Запрос к accrual вместе с повторами клиента ограничен AccrualTimeout, поэтому остановка воркера
+// ждёт заказ не дольше AccrualTimeout и QueryTimeout на обновление.
+func (worker *Worker) processOrder(ctx context.Context, order ordersmodel.Order) bool {
+	ctx, span := tracing.Start(ctx, "AccrualWorker.processOrder",
+		tracing.OrderNumber(order.Number),
+		tracing.UserID(order.UserID),
+	)
+	var spanErr error
+	defer func() { tracing.End(span, spanErr) }()
+	ctx = logger.With(logger.With(context.WithoutCancel(ctx), "order_run_id", logger.NewID()), "order", order.Number)
+	provider := worker.accrualProvider(order.Number)
+	ctx = logger.With(ctx, "accrual_provider", provider)
+	if worker.providerPaused(provider) {
+		metrics.WorkerOrderOutcomes.WithLabelValues(outcomeDeferred).Inc()
+		zerolog.Ctx(ctx).Debug().Msg("accrual provider paused, order deferred")
+		return false
+	}
+
+	accrualCtx, cancelAccrual := worker.withAccrualTimeout(ctx)
+	accrualResp, err := worker.getOrderAccrual(accrualCtx, provider, order.Number)
+	cancelAccrual()
 	if err != nil {
+		spanErr = err
 		if errors.Is(err, model.ErrTooManyRequests) {
-			log.Warn().
+			metrics.WorkerOrderOutcomes.WithLabelValues(outcomeRateLimited).Inc()
+			zerolog.Ctx(ctx).Warn().
 				Dur("retry_after", worker.retryAfterMin).
-				Msg("accrual rate limit exceeded, pausing worker")
-			time.Sleep(worker.retryAfterMin)
-			return
+				Msg("accrual rate limit exceeded, pausing provider")
+			worker.pauseProvider(provider)
+			return false
 		}
 		if errors.Is(err, model.ErrTemporarilyUnavailable) {
-			log.Warn().
+			metrics.WorkerOrderOutcomes.WithLabelValues(outcomeUnavailable).Inc()
+			zerolog.Ctx(ctx).Warn().
 				Dur("retry_after", worker.retryAfterMin).
-				Msg("accrual temporarily unavailable, pausing worker")
-			time.Sleep(worker.retryAfterMin)
-			return
+				Msg("accrual temporarily unavailable, pausing provider")
+			worker.pauseProvider(provider)
+			return false
 		}
 
-		log.Error().
+		metrics.WorkerOrderOutcomes.WithLabelValues(outcomeError).Inc()
+		zerolog.Ctx(ctx).Error().
 			Err(err).
-			Str("order", order.Number).
 			Msg("failed to get accrual for order")
-		return
+		return false
 	}
 
 	if accrualResp == nil {
-		log.Debug().Str("order", order.Number).Msg("order not registered in accrual system yet")
-		return
+		metrics.WorkerOrderOutcomes.WithLabelValues(outcomeNotRegistered).Inc()
+		zerolog.Ctx(ctx).Debug().Msg("order not registered in accrual system yet")
+		return false
 	}
 
 	updateCtx, cancel := context.WithTimeout(ctx, worker.queryTimeout)
 	defer cancel()
 
-	if err := worker.ordersService.UpdateFromAccrual(updateCtx, order.Number, accrualResp.Status, accrualResp.Accrual); err != nil {
-		log.Error().
+	span.SetAttributes(attribute.String("accrual.status", string(accrualResp.Status)))
+	if err := worker.ordersService.UpdateFromAccrual(updateCtx, order.Number, ordersmodel.SourceWorker, *accrualResp); err != nil {
+		spanErr = err
+		metrics.WorkerOrderOutcomes.WithLabelValues(outcomeUpdateFailed).Inc()
+		zerolog.Ctx(ctx).Error().
 			Err(err).
-			Str("order", order.Number).
 			Str("accrual_status", string(accrualResp.Status)).
 			Msg("failed to update order from accrual")
-		return
+		return false
 	}
 
-	log.Info().
-		Str("order", order.Number).
+	metrics.WorkerOrderOutcomes.WithLabelValues(string(accrualResp.Status)).Inc()
+	zerolog.Ctx(ctx).Info().
 		Str("old_status", string(order.Status)).
 		Str("accrual_status", string(accrualResp.Status)).
 		Interface("accrual", accrualResp.Accrual).
 		Msg("order updated from accrual")
+
+	return accrualResp.Status == model.StatusProcessed
+}
+
+// pauseProvider откладывает запросы к системе accrual provider на RetryAfterMin.
+func (worker *Worker) pauseProvider(provider string) {
+	if worker.retryAfterMin <= 0 {
+		return
+	}
+	worker.pausedMu.Lock()
+	defer worker.pausedMu.Unlock()
+	worker.pausedUntil[provider] = time.Now().Add(worker.retryAfterMin)
+}
+
+// providerPaused сообщает, отложены ли запросы к системе accrual provider.
+func (worker *Worker) providerPaused(provider string) bool {
+	worker.pausedMu.Lock()
+	defer worker.pausedMu.Unlock()
+	until, ok := worker.pausedUntil[provider]
+	if ok && !time.Now().Before(until) {
+		delete(worker.pausedUntil, provider)
+		return false
+	}
+	return ok
+}
+
+// withAccrualTimeout ограничивает запрос начисления дедлайном AccrualTimeout: клиент accrual
+// не начинает повтор, пауза перед которым в дедлайн не укладывается.
+func (worker *Worker) withAccrualTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if worker.accrualTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, worker.accrualTimeout)
+}
+
+// accrualProvider возвращает имя системы accrual, которая считает заказ.
+func (worker *Worker) accrualProvider(orderNumber string) string {
+	if resolver, ok := worker.accrualClient.(client.ProviderResolver); ok {
+		return resolver.Provider(orderNumber)
+	}
+	return client.DefaultProvider
+}
+
+// getOrderAccrual вызывает accrual-клиент и фиксирует длительность вызова в метриках.
+func (worker *Worker) getOrderAccrual(ctx context.Context, provider, orderNumber string) (*model.Accrual, error) {
+	start := time.Now()
+	accrualResp, err := worker.accrualClient.GetOrderAccrual(ctx, orderNumber)
+
+	result := "ok"
+	switch {
+	case errors.Is(err, model.ErrTooManyRequests):
+		result = outcomeRateLimited
+	case errors.Is(err, model.ErrTemporarilyUnavailable):
+		result = outcomeUnavailable
+	case err != nil:
+		result = outcomeError
+	case accrualResp == nil:
+		result = outcomeNotRegistered
+	}
+	metrics.AccrualRequestDuration.WithLabelValues(provider, result).Observe(time.Since(start).Seconds())
+
+	return accrualResp, err
 }
@@ -32,17 +32,17 @@ func TestWorkerPool_ParallelProcessing(t *testing.T) {
 	cfg.MaxConcurrency = 3
 	cfg.RequestDelay = 10 * time.Millisecond
 
-	w := NewWorker(repo, &mockOrdersService{}, client, cfg)
+	w := NewWorker(repo, &mockOrdersService{}, client, nil, nil, cfg)
 
 	start := time.Now()
-	w.processBatch(context.Background())
+	drainPending(context.Background(), w)
 	elapsed := time.Since(start)
 
 	// С параллельной обработкой (3 воркера) это должно занять ~20ms
 	// (5 заказов / 3 воркера = 2 раунда * 10ms)
 	// Без параллелизации: 5 * 10ms = 50ms
 	if elapsed > 40*time.Millisecond {
-		t.Errorf("processBatch took %v, expected <40ms with concurrency", elapsed)
+		t.Errorf("drainPending took %v, expected <40ms with concurrency", elapsed)
 	}
 
 	if atomic.LoadInt32(&processedCount) != 5 {
@@ -65,7 +65,7 @@ func TestWorkerPool_GracefulShutdown(t *testing.T) {
 	cfg.MaxConcurrency = 5
 	cfg.RequestDelay = 1 * time.Millisecond
 
-	w := NewWorker(repo, &mockOrdersService{}, client, cfg)
+	w := NewWorker(repo, &mockOrdersService{}, client, nil, nil, cfg)
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -75,7 +75,7 @@ func TestWorkerPool_GracefulShutdown(t *testing.T) {
 		cancel()
 	}()
 
-	w.processBatch(ctx)
+	drainPending(ctx, w)
 
 	// Должны обработать хотя бы несколько заказов, но не все 100
 	processed := atomic.LoadInt32(&processedCount)
@@ -101,3 +101,188 @@ func (c *countingAccrualClient) GetOrderAccrual(ctx context.Context, orderNumber
 		Accrual: decimalPtr(100),
 	}, nil
 }
+
+// blockingHandler сообщает о начале обработки заказа в started и ждёт release.
+type blockingHandler struct {
+	started chan string
+	release chan struct{}
+}
+
+func (h *blockingHandler) handle(ctx context.Context, j job) {
+	h.started <- j.order.Number
+	<-h.release
+}
+
+func TestPool_SkipsOrdersInFlight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := &blockingHandler{started: make(chan string, 1), release: make(chan struct{})}
+	p := newPool(ctx, 2, h.handle)
+
+	order := ordersmodel.Order{Number: "79927398713"}
+	if !p.Submit(context.Background(), job{order: order}) {
+		t.Fatal("first submit must be accepted")
+	}
+	<-h.started
+	if p.Submit(context.Background(), job{order: order}) {
+		t.Fatal("order in flight must not be accepted again")
+	}
+	if p.Len() != 1 {
+		t.Fatalf("want 1 order in flight, got %d", p.Len())
+	}
+
+	h.release <- struct{}{}
+	for p.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if !p.Submit(context.Background(), job{order: order}) {
+		t.Fatal("order must be accepted again after it was handled")
+	}
+	<-h.started
+	close(h.release)
+	cancel()
+	p.Wait()
+}
+
+func TestPool_Resize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := &blockingHandler{started: make(chan string, 3), release: make(chan struct{})}
+	p := newPool(ctx, 1, h.handle)
+
+	submitted := make(chan struct{})
+	go func() {
+		defer close(submitted)
+		for _, number := range []string{"1", "2", "3"} {
+			p.Submit(context.Background(), job{order: ordersmodel.Order{Number: number}})
+		}
+	}()
+
+	<-h.started
+	select {
+	case <-h.started:
+		t.Fatal("pool of one handler must not start a second order")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	p.Resize(3)
+	for range 2 {
+		select {
+		case <-h.started:
+		case <-time.After(time.Second):
+			t.Fatal("resized pool did not pick up waiting orders")
+		}
+	}
+	<-submitted
+
+	p.Resize(1)
+	close(h.release)
+	for p.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	p.Wait()
+}
+
+// waitLive ждёт, пока число запущенных обработчиков пула станет want.
+func waitLive(t *testing.T, p *pool, want int) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for p.Live() != want {
+		select {
+		case <-deadline:
+			t.Fatalf("want %d live handlers, got %d", want, p.Live())
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestPool_ShrinkThenGrowKeepsLiveCount(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := &blockingHandler{started: make(chan string, 3), release: make(chan struct{})}
+	p := newPool(ctx, 3, h.handle)
+	waitLive(t, p, 3)
+
+	// Увеличение сразу после уменьшения: свободные обработчики не должны выйти по устаревшему сигналу.
+	p.Resize(1)
+	p.Resize(3)
+	time.Sleep(20 * time.Millisecond)
+	waitLive(t, p, 3)
+
+	p.Resize(1)
+	waitLive(t, p, 1)
+	p.Resize(2)
+	waitLive(t, p, 2)
+
+	// Занятый обработчик дообрабатывает заказ и только потом выходит.
+	p.Submit(context.Background(), job{order: ordersmodel.Order{Number: "1"}})
+	<-h.started
+	p.Resize(1)
+	p.Resize(2)
+	p.Resize(1)
+	close(h.release)
+	for p.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	waitLive(t, p, 1)
+
+	cancel()
+	p.Wait()
+	if got := p.Live(); got != 0 {
+		t.Fatalf("want no live handlers after stop, got %d", got)
+	}
+}
+
+// stallingAccrualClient никогда не отвечает по заказу slow (до release) и сразу отвечает по остальным.
+type stallingAccrualClient struct {
+	slow        string
+	release     chan struct{}
+	slowStarted atomic.Int32
+	fastCalls   atomic.Int32
+}
+
+func (c *stallingAccrualClient) GetOrderAccrual(ctx context.Context, orderNumber string) (*model.Accrual, error) {
+	if orderNumber == c.slow {
+		c.slowStarted.Add(1)
+		<-c.release
+	} else {
+		c.fastCalls.Add(1)
+	}
+	return &model.Accrual{Order: orderNumber, Status: model.StatusProcessing}, nil
+}
+
+func TestWorker_Start_SlowOrderDoesNotStallOthers(t *testing.T) {
+	repo := &mockOrdersRepo{orders: []ordersmodel.Order{
+		{Number: "slow", Status: ordersmodel.StatusNew},
+		{Number: "fast", Status: ordersmodel.StatusNew},
+	}}
+	client := &stallingAccrualClient{slow: "slow", release: make(chan struct{})}
+
+	cfg := DefaultConfig()
+	cfg.MaxConcurrency = 2
+	cfg.RequestDelay = 0
+	w := NewWorker(repo, &mockOrdersService{}, client, nil, nil, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := w.Start(ctx)
+	sweepEvery(ctx, w, 2*time.Millisecond)
+
+	deadline := time.After(time.Second)
+	for client.fastCalls.Load() < 5 {
+		select {
+		case <-deadline:
+			t.Fatalf("fast order stalled behind the slow one: %d calls", client.fastCalls.Load())
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if got := client.slowStarted.Load(); got != 1 {
+		t.Fatalf("slow order must be processed once while in flight, started %d times", got)
+	}
+
+	close(client.release)
+	cancel()
+	<-done
+}
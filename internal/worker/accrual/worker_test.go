@@ -1,14 +1,24 @@
 package accrual
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	accrualhttp "loyalty/internal/adapter/accrual/http"
 	accrualmodel "loyalty/internal/domain/accrual/model"
 	ordersmodel "loyalty/internal/domain/order/model"
+	tiermodel "loyalty/internal/domain/tier/model"
 
+	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
 )
 
@@ -23,6 +33,14 @@ func (m *mockOrdersRepo) Create(ctx context.Context, userID int64, number string
 	return nil
 }
 
+func (m *mockOrdersRepo) Get(ctx context.Context, number string) (ordersmodel.Order, error) {
+	return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+}
+
+func (m *mockOrdersRepo) ListHistory(ctx context.Context, number string) ([]ordersmodel.StatusChange, error) {
+	return nil, nil
+}
+
 func (m *mockOrdersRepo) ListByUser(ctx context.Context, userID int64) ([]ordersmodel.Order, error) {
 	return nil, nil
 }
@@ -34,11 +52,20 @@ func (m *mockOrdersRepo) ListPending(ctx context.Context) ([]ordersmodel.Order,
 	return m.orders, nil
 }
 
-func (m *mockOrdersRepo) UpdateFromAccrual(ctx context.Context, number string, status ordersmodel.Status, accrual *decimal.Decimal) error {
+func (m *mockOrdersRepo) UpdateFromAccrual(
+	ctx context.Context,
+	number string,
+	update ordersmodel.StatusUpdate,
+	rewards ordersmodel.Rewards,
+) error {
 	m.updateCalls++
 	return m.updateErr
 }
 
+func (m *mockOrdersRepo) Requeue(ctx context.Context, number string) (ordersmodel.Order, error) {
+	return ordersmodel.Order{}, nil
+}
+
 type mockOrdersService struct {
 	updateErr error
 }
@@ -51,10 +78,22 @@ func (m *mockOrdersService) LoadOrders(ctx context.Context, userID int64) ([]ord
 	return nil, nil
 }
 
-func (m *mockOrdersService) UpdateFromAccrual(ctx context.Context, orderNumber string, accrualStatus accrualmodel.AccrualStatus, accrual *decimal.Decimal) error {
+func (m *mockOrdersService) GetOrder(context.Context, string) (ordersmodel.Order, error) {
+	return ordersmodel.Order{}, ordersmodel.ErrOrderNotFound
+}
+
+func (m *mockOrdersService) LoadHistory(ctx context.Context, userID int64, orderNumber string) ([]ordersmodel.StatusChange, error) {
+	return nil, nil
+}
+
+func (m *mockOrdersService) UpdateFromAccrual(ctx context.Context, orderNumber string, source ordersmodel.Source, response accrualmodel.Accrual) error {
 	return m.updateErr
 }
 
+func (m *mockOrdersService) RequeueOrder(ctx context.Context, orderNumber string) (ordersmodel.Order, error) {
+	return ordersmodel.Order{}, nil
+}
+
 type mockAccrualClient struct {
 	response *accrualmodel.Accrual
 	err      error
@@ -64,7 +103,36 @@ func (m *mockAccrualClient) GetOrderAccrual(ctx context.Context, orderNumber str
 	return m.response, m.err
 }
 
-func TestWorker_processBatch(t *testing.T) {
+// drainPending выполняет один проход производителя на отдельном пуле и ждёт обработки переданных заказов.
+func drainPending(ctx context.Context, w *Worker) {
+	poolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	orders := newPool(poolCtx, w.MaxConcurrency(), w.handle)
+	w.enqueuePending(ctx, orders)
+	for orders.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	orders.Wait()
+}
+
+// sweepEvery вызывает Sweep раз в interval до отмены ctx — как это делает планировщик.
+func sweepEvery(ctx context.Context, w *Worker, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = w.Sweep(ctx)
+			}
+		}
+	}()
+}
+
+func TestWorker_enqueuePending(t *testing.T) {
 	tests := []struct {
 		name string
 		repo *mockOrdersRepo
@@ -81,8 +149,8 @@ func TestWorker_processBatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			w := NewWorker(tt.repo, &mockOrdersService{}, &mockAccrualClient{}, DefaultConfig())
-			w.processBatch(context.Background())
+			w := NewWorker(tt.repo, &mockOrdersService{}, &mockAccrualClient{}, nil, nil, DefaultConfig())
+			drainPending(context.Background(), w)
 		})
 	}
 }
@@ -149,13 +217,460 @@ func TestWorker_processOrder(t *testing.T) {
 			cfg := DefaultConfig()
 			cfg.RequestDelay = 0
 			cfg.RetryAfterMin = 10 * time.Millisecond
-			w := NewWorker(tt.repo, tt.service, tt.client, cfg)
+			w := NewWorker(tt.repo, tt.service, tt.client, nil, nil, cfg)
 			w.processOrder(context.Background(), tt.order)
 		})
 	}
 }
 
+type mockTierService struct {
+	mu           sync.Mutex
+	recalculated []int64
+}
+
+func (m *mockTierService) Recalculate(ctx context.Context, userID int64) (tiermodel.UserTier, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recalculated = append(m.recalculated, userID)
+	return tiermodel.UserTier{UserID: userID, Level: tiermodel.LevelBase}, nil
+}
+
+func (m *mockTierService) GetProfile(ctx context.Context, userID int64) (tiermodel.Profile, error) {
+	return tiermodel.Profile{}, nil
+}
+
+func TestWorker_RecalculatesTierAfterEachProcessedOrder(t *testing.T) {
+	repo := &mockOrdersRepo{orders: []ordersmodel.Order{
+		{Number: "1", UserID: 7, Status: ordersmodel.StatusNew},
+		{Number: "2", UserID: 7, Status: ordersmodel.StatusNew},
+	}}
+	client := &mockAccrualClient{response: &accrualmodel.Accrual{
+		Status:  accrualmodel.StatusProcessed,
+		Accrual: decimalPtr(10),
+	}}
+	tiers := &mockTierService{}
+
+	cfg := DefaultConfig()
+	cfg.RequestDelay = 0
+	w := NewWorker(repo, &mockOrdersService{}, client, tiers, nil, cfg)
+	drainPending(context.Background(), w)
+
+	if len(tiers.recalculated) != 2 || tiers.recalculated[0] != 7 || tiers.recalculated[1] != 7 {
+		t.Fatalf("expected a recalculation for user 7 per processed order, got %v", tiers.recalculated)
+	}
+}
+
+func TestWorker_SkipsTiersWhenNotProcessed(t *testing.T) {
+	repo := &mockOrdersRepo{orders: []ordersmodel.Order{{Number: "1", UserID: 7, Status: ordersmodel.StatusNew}}}
+	client := &mockAccrualClient{response: &accrualmodel.Accrual{Status: accrualmodel.StatusProcessing}}
+	tiers := &mockTierService{}
+
+	cfg := DefaultConfig()
+	cfg.RequestDelay = 0
+	w := NewWorker(repo, &mockOrdersService{}, client, tiers, nil, cfg)
+	drainPending(context.Background(), w)
+
+	if len(tiers.recalculated) != 0 {
+		t.Fatalf("expected no recalculation, got %v", tiers.recalculated)
+	}
+}
+
 func decimalPtr(v float64) *decimal.Decimal {
 	d := decimal.NewFromFloat(v)
 	return &d
 }
+
+func TestWorker_LogsCorrelationIDs(t *testing.T) {
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+	ctx := base.WithContext(context.Background())
+
+	repo := &mockOrdersRepo{orders: []ordersmodel.Order{{Number: "79927398713", UserID: 7, Status: ordersmodel.StatusNew}}}
+	client := &mockAccrualClient{response: &accrualmodel.Accrual{
+		Status:  accrualmodel.StatusProcessed,
+		Accrual: decimalPtr(10),
+	}}
+
+	cfg := DefaultConfig()
+	cfg.RequestDelay = 0
+	w := NewWorker(repo, &mockOrdersService{}, client, nil, nil, cfg)
+	drainPending(ctx, w)
+
+	var updated map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry["message"] == "order updated from accrual" {
+			updated = entry
+		}
+	}
+	if updated == nil {
+		t.Fatalf("expected order update log line, got %s", buf.String())
+	}
+	if updated["batch_id"] == nil || updated["order_run_id"] == nil || updated["order"] != "79927398713" {
+		t.Fatalf("expected batch_id, order_run_id and order fields, got %v", updated)
+	}
+}
+
+func TestWorker_Sweep_UpdatesHeartbeat(t *testing.T) {
+	w := NewWorker(&mockOrdersRepo{}, &mockOrdersService{}, &mockAccrualClient{}, nil, nil, DefaultConfig())
+	if !w.Heartbeat().IsZero() {
+		t.Fatalf("expected zero heartbeat before start")
+	}
+	if err := w.Sweep(context.Background()); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning before start, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := w.Start(ctx)
+	sweepEvery(ctx, w, 5*time.Millisecond)
+
+	deadline := time.After(time.Second)
+	first := time.Time{}
+	for first.IsZero() {
+		select {
+		case <-deadline:
+			t.Fatalf("heartbeat not set")
+		default:
+			first = w.Heartbeat()
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for !w.Heartbeat().After(first) {
+		select {
+		case <-deadline:
+			t.Fatalf("heartbeat not refreshed after a poll")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	cancel()
+	<-done
+	if err := w.Sweep(context.Background()); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning after stop, got %v", err)
+	}
+}
+
+func TestWorker_Sweep_ReturnsListError(t *testing.T) {
+	w := NewWorker(&mockOrdersRepo{listErr: errors.New("db down")}, &mockOrdersService{}, &mockAccrualClient{}, nil, nil, DefaultConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := w.Start(ctx)
+	defer func() {
+		cancel()
+		<-done
+	}()
+	for w.Heartbeat().IsZero() {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := w.Sweep(ctx); err == nil {
+		t.Fatalf("expected error when pending orders cannot be listed")
+	}
+}
+
+func TestWorker_Reconfigure(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PollInterval = time.Hour
+	cfg.MaxConcurrency = 1
+	w := NewWorker(&mockOrdersRepo{}, &mockOrdersService{}, &mockAccrualClient{}, nil, nil, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := w.Start(ctx)
+
+	for w.Heartbeat().IsZero() {
+		time.Sleep(time.Millisecond)
+	}
+
+	w.Reconfigure(5*time.Millisecond, 2)
+	w.Reconfigure(0, -1)
+	if w.PollInterval() != 5*time.Millisecond || w.TickInterval() != 5*time.Millisecond || w.MaxConcurrency() != 2 {
+		t.Fatalf("unexpected settings: poll=%v concurrency=%d", w.PollInterval(), w.MaxConcurrency())
+	}
+
+	deadline := time.After(time.Second)
+	for {
+		if orders := w.orders.Load(); orders != nil && orders.Size() == 2 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("concurrency change not applied to running worker")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWorker_ReconfigureWhileEnqueueBlocked(t *testing.T) {
+	repo := &mockOrdersRepo{}
+	for _, number := range []string{"1", "2", "3", "4", "5"} {
+		repo.orders = append(repo.orders, ordersmodel.Order{Number: number, Status: ordersmodel.StatusNew})
+	}
+	client := &blockingAccrualClient{started: make(chan struct{}, 5), release: make(chan struct{})}
+	cfg := DefaultConfig()
+	cfg.MaxConcurrency = 1
+	cfg.RequestDelay = 0
+	w := NewWorker(repo, &mockOrdersService{}, client, nil, nil, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := w.Start(ctx)
+	defer func() {
+		close(client.release)
+		cancel()
+		<-done
+	}()
+	for w.Heartbeat().IsZero() {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Единственный обработчик занят, передача остальных заказов ждёт свободного обработчика.
+	w.wake()
+	<-client.started
+
+	w.Reconfigure(0, 3)
+	for range 2 {
+		select {
+		case <-client.started:
+		case <-time.After(time.Second):
+			t.Fatal("resize waited for the pending backlog to be submitted")
+		}
+	}
+}
+
+// blockingAccrualClient отвечает только после release, сообщая о начале запроса в started.
+type blockingAccrualClient struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingAccrualClient) GetOrderAccrual(ctx context.Context, orderNumber string) (*accrualmodel.Accrual, error) {
+	m.started <- struct{}{}
+	<-m.release
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	accrual := decimal.NewFromInt(10)
+	return &accrualmodel.Accrual{Order: orderNumber, Status: accrualmodel.StatusProcessed, Accrual: &accrual}, nil
+}
+
+type recordingOrdersService struct {
+	mockOrdersService
+	updated chan string
+}
+
+func (m *recordingOrdersService) UpdateFromAccrual(ctx context.Context, orderNumber string, source ordersmodel.Source, response accrualmodel.Accrual) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.updated <- orderNumber
+	return nil
+}
+
+func TestWorker_Start_DrainsInFlightOrderOnStop(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PollInterval = time.Millisecond
+	cfg.RequestDelay = 0
+	cfg.MaxConcurrency = 1
+	repo := &mockOrdersRepo{orders: []ordersmodel.Order{
+		{Number: "79927398713", UserID: 1, Status: ordersmodel.StatusNew},
+		{Number: "12345678903", UserID: 1, Status: ordersmodel.StatusNew},
+	}}
+	client := &blockingAccrualClient{started: make(chan struct{}, 2), release: make(chan struct{})}
+	service := &recordingOrdersService{updated: make(chan string, 2)}
+	w := NewWorker(repo, service, client, nil, nil, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := w.Start(ctx)
+	sweepEvery(ctx, w, time.Millisecond)
+
+	select {
+	case <-client.started:
+	case <-time.After(time.Second):
+		t.Fatalf("worker did not pick up the order")
+	}
+	cancel()
+
+	select {
+	case <-done:
+		t.Fatalf("worker stopped before in-flight order finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(client.release)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("worker did not stop after in-flight order finished")
+	}
+
+	if len(service.updated) != 1 || <-service.updated != "79927398713" {
+		t.Fatalf("expected only the in-flight order to be updated")
+	}
+	if len(client.started) != 0 {
+		t.Fatalf("no new orders must be taken after stop")
+	}
+}
+
+func TestWorker_pause_InterruptedByStop(t *testing.T) {
+	w := NewWorker(&mockOrdersRepo{}, &mockOrdersService{}, &mockAccrualClient{}, nil, nil, DefaultConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	startedAt := time.Now()
+	if w.pause(ctx, time.Minute) {
+		t.Fatalf("expected pause to report stop")
+	}
+	if time.Since(startedAt) > time.Second {
+		t.Fatalf("pause was not interrupted by stop")
+	}
+	if !w.pause(context.Background(), 0) {
+		t.Fatalf("zero pause must not report stop")
+	}
+}
+
+// chanNotifier пересылает в notify сигналы из events до отмены ctx.
+type chanNotifier struct {
+	events chan struct{}
+}
+
+func (n *chanNotifier) Listen(ctx context.Context, notify func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-n.events:
+			notify()
+		}
+	}
+}
+
+func TestWorker_Start_ProcessesOrdersOnNotification(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PollInterval = time.Millisecond
+	cfg.SweepInterval = time.Hour
+	cfg.RequestDelay = 0
+	repo := &mockOrdersRepo{orders: []ordersmodel.Order{{Number: "79927398713", UserID: 1, Status: ordersmodel.StatusNew}}}
+	client := &blockingAccrualClient{started: make(chan struct{}, 1), release: make(chan struct{})}
+	close(client.release)
+	notifier := &chanNotifier{events: make(chan struct{})}
+	w := NewWorker(repo, &mockOrdersService{}, client, nil, notifier, cfg)
+	if w.TickInterval() != time.Hour {
+		t.Fatalf("expected sweep interval with notifier, got %v", w.TickInterval())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := w.Start(ctx)
+
+	select {
+	case <-client.started:
+		t.Fatal("order processed before notification")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	notifier.events <- struct{}{}
+	select {
+	case <-client.started:
+	case <-time.After(time.Second):
+		t.Fatal("order not processed after notification")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWorker_processOrder_AccrualTimeoutCutsRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	clientConfig := accrualhttp.DefaultConfig()
+	clientConfig.Retry.Retries = 10
+	clientConfig.Retry.Backoff = 30 * time.Millisecond
+	clientConfig.Breaker.Failures = 100
+	client := accrualhttp.NewClient("worker_budget_test", server.URL, clientConfig)
+
+	cfg := DefaultConfig()
+	cfg.AccrualTimeout = 100 * time.Millisecond
+	cfg.RetryAfterMin = 0
+	w := NewWorker(&mockOrdersRepo{}, &mockOrdersService{}, client, nil, nil, cfg)
+
+	startedAt := time.Now()
+	if w.processOrder(context.Background(), ordersmodel.Order{Number: "123", Status: ordersmodel.StatusNew}) {
+		t.Fatalf("order must not be processed")
+	}
+	if elapsed := time.Since(startedAt); elapsed > time.Second {
+		t.Fatalf("accrual request outlived AccrualTimeout: %v", elapsed)
+	}
+	if got := calls.Load(); got < 2 || got > 4 {
+		t.Fatalf("want retries cut off by the deadline, got %d calls", got)
+	}
+}
+
+// providerAccrualClient относит заказы на "1" к системе "throttled", которая отвечает 429, остальные — к "ok".
+type providerAccrualClient struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (m *providerAccrualClient) Provider(orderNumber string) string {
+	if strings.HasPrefix(orderNumber, "1") {
+		return "throttled"
+	}
+	return "ok"
+}
+
+func (m *providerAccrualClient) GetOrderAccrual(ctx context.Context, orderNumber string) (*accrualmodel.Accrual, error) {
+	provider := m.Provider(orderNumber)
+	m.mu.Lock()
+	m.calls[provider]++
+	m.mu.Unlock()
+	if provider == "throttled" {
+		return nil, accrualmodel.ErrTooManyRequests
+	}
+	return &accrualmodel.Accrual{Order: orderNumber, Status: accrualmodel.StatusProcessed, Accrual: decimalPtr(1)}, nil
+}
+
+func TestWorker_processOrder_PausesOnlyThrottledProvider(t *testing.T) {
+	client := &providerAccrualClient{calls: make(map[string]int)}
+	cfg := DefaultConfig()
+	cfg.RetryAfterMin = 50 * time.Millisecond
+	w := NewWorker(&mockOrdersRepo{}, &mockOrdersService{}, client, nil, nil, cfg)
+	ctx := context.Background()
+
+	startedAt := time.Now()
+	if w.processOrder(ctx, ordersmodel.Order{Number: "11"}) {
+		t.Fatalf("throttled order must not be processed")
+	}
+	if !w.processOrder(ctx, ordersmodel.Order{Number: "21"}) {
+		t.Fatalf("order of another provider must be processed")
+	}
+	if w.processOrder(ctx, ordersmodel.Order{Number: "12"}) {
+		t.Fatalf("order of the paused provider must be deferred")
+	}
+	if elapsed := time.Since(startedAt); elapsed >= cfg.RetryAfterMin {
+		t.Fatalf("handler must not wait for the provider pause, took %v", elapsed)
+	}
+	if client.calls["throttled"] != 1 || client.calls["ok"] != 1 {
+		t.Fatalf("paused provider must not be requested, calls: %v", client.calls)
+	}
+
+	time.Sleep(cfg.RetryAfterMin)
+	w.processOrder(ctx, ordersmodel.Order{Number: "12"})
+	if client.calls["throttled"] != 2 {
+		t.Fatalf("provider must be requested again after the pause, calls: %v", client.calls)
+	}
+}